@@ -18,8 +18,13 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/karmada-io/karmada/pkg/sharedcli/klogflag"
 	"github.com/spf13/cobra"
@@ -28,31 +33,49 @@ import (
 
 	"github.com/karmada-io/dashboard/cmd/api/app/options"
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
-	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/auth"                     // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/audit" // Importing route packages forces route registration
+	"github.com/karmada-io/dashboard/cmd/api/app/routes/auth"
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/bootstraptoken"           // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/cluster"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/clusteroverridepolicy"    // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/clusterpropagationpolicy" // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/config"                   // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/configmap"                // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/cronjob"                  // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/csr"                      // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/daemonset"                // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/deployment"               // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/ingress"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/job"                      // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/member"                   // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/namespace"                // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/operation"                // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/overridepolicy"           // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/overview"                 // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/policy"                   // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/propagationpolicy"        // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/secret"                   // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/service"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/statefulset"              // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/unstructured"             // Importing route packages forces route registration
+	"github.com/karmada-io/dashboard/cmd/api/app/routes/user"
+	"github.com/karmada-io/dashboard/pkg/audit"
+	"github.com/karmada-io/dashboard/pkg/auth/csrf"
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+	"github.com/karmada-io/dashboard/pkg/auth/oidc"
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+	"github.com/karmada-io/dashboard/pkg/certificate"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/clusterhistory"
 	"github.com/karmada-io/dashboard/pkg/config"
 	"github.com/karmada-io/dashboard/pkg/environment"
+	"github.com/karmada-io/dashboard/pkg/operation"
 )
 
+// serviceName is the name of the service in front of karmada-dashboard-api, it's used as host of the self-signed certificate.
+const serviceName = "karmada-dashboard-api"
+
 // NewAPICommand creates a *cobra.Command object with default parameters
 func NewAPICommand(ctx context.Context) *cobra.Command {
 	opts := options.NewOptions()
@@ -61,9 +84,9 @@ func NewAPICommand(ctx context.Context) *cobra.Command {
 		Long: `The karmada-dashboard-api provide api for karmada-dashboard web ui. It need to access host cluster apiserver and karmada apiserver internally, it will access host cluster apiserver for creating some resource like configmap in host cluster, meanwhile it will access karmada apiserver for interactiving for purpose of managing karmada-specific resources, like cluster、override policy、propagation policy and so on.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			// validate options
-			//if errs := opts.Validate(); len(errs) != 0 {
-			//	return errs.ToAggregate()
-			//}
+			if errs := opts.Validate(); len(errs) != 0 {
+				return errs.ToAggregate()
+			}
 			if err := run(ctx, opts); err != nil {
 				return err
 			}
@@ -100,6 +123,7 @@ func run(ctx context.Context, opts *options.Options) error {
 		client.WithKubeconfig(opts.KarmadaKubeConfig),
 		client.WithKubeContext(opts.KarmadaContext),
 		client.WithInsecureTLSSkipVerify(opts.SkipKarmadaApiserverTLSVerify),
+		client.WithServiceAccountMode(opts.EnableServiceAccountMode),
 	)
 
 	client.InitKubeConfig(
@@ -109,7 +133,54 @@ func run(ctx context.Context, opts *options.Options) error {
 		client.WithInsecureTLSSkipVerify(opts.SkipKubeApiserverTLSVerify),
 	)
 	ensureAPIServerConnectionOrDie()
-	serve(opts)
+	token.SetTTL(opts.TokenIdleTTL, opts.TokenAbsoluteTTL)
+	if err := jwe.Init(ctx, client.InClusterClient(), opts.Namespace, opts.EncryptionKeyRotationInterval); err != nil {
+		return fmt.Errorf("failed to init encryption keys: %w", err)
+	}
+	if err := token.Init(ctx, client.InClusterClient(), opts.Namespace); err != nil {
+		return fmt.Errorf("failed to init revoked tokens: %w", err)
+	}
+	session.Init(ctx, client.InClusterClient(), opts.Namespace)
+	if opts.DisableCSRFProtection {
+		csrf.Disable()
+	} else if err := csrf.Init(ctx, client.InClusterClient(), opts.Namespace); err != nil {
+		return fmt.Errorf("failed to init csrf protection: %w", err)
+	}
+	if err := audit.Init(audit.Config{
+		Sinks:         opts.AuditSinks,
+		LogPath:       opts.AuditLogPath,
+		LogMaxSize:    opts.AuditLogMaxSize,
+		LogMaxBackups: opts.AuditLogMaxBackups,
+		WebhookURL:    opts.AuditWebhookURL,
+	}); err != nil {
+		return fmt.Errorf("failed to init audit: %w", err)
+	}
+	if err := operation.Init(ctx, client.InClusterClient(), opts.Namespace); err != nil {
+		return fmt.Errorf("failed to init operations: %w", err)
+	}
+	if len(opts.ClusterHistoryPath) > 0 {
+		// the history is informative, e.g. a volume which is not writable must not stop the api from serving
+		if err := clusterhistory.Init(ctx, client.InClusterKarmadaClient(), opts.ClusterHistoryPath); err != nil {
+			klog.ErrorS(err, "Failed to init cluster history, it is disabled", "path", opts.ClusterHistoryPath)
+		}
+	}
+	if len(opts.OIDCIssuerURL) > 0 {
+		if err := oidc.Init(ctx, oidc.Config{
+			IssuerURL:    opts.OIDCIssuerURL,
+			ClientID:     opts.OIDCClientID,
+			ClientSecret: opts.OIDCClientSecret,
+			RedirectURL:  opts.OIDCRedirectURL,
+			Scopes:       opts.OIDCScopes,
+			JWKSURL:      opts.OIDCJWKSURL,
+		}); err != nil {
+			return fmt.Errorf("failed to init oidc provider: %w", err)
+		}
+		auth.SetPostLoginRedirectURL(opts.OIDCPostLoginRedirectURL)
+	}
+	user.SetNamespace(opts.UserNamespace)
+	if err := serve(ctx, opts); err != nil {
+		return err
+	}
 	config.InitDashboardConfig(client.InClusterClient(), ctx.Done())
 	<-ctx.Done()
 	os.Exit(0)
@@ -134,10 +205,55 @@ func ensureAPIServerConnectionOrDie() {
 	klog.InfoS("Successful initial request to the Karmada apiserver", "version", karmadaVersionInfo.String())
 }
 
-func serve(opts *options.Options) {
+func serve(ctx context.Context, opts *options.Options) error {
 	insecureAddress := fmt.Sprintf("%s:%d", opts.InsecureBindAddress, opts.InsecurePort)
 	klog.V(1).InfoS("Listening and serving on", "address", insecureAddress)
 	go func() {
 		klog.Fatal(router.Router().Run(insecureAddress))
 	}()
+
+	if opts.Port == 0 {
+		return nil
+	}
+	tlsConfig, err := secureServingConfig(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to init secure serving: %w", err)
+	}
+	secureAddress := net.JoinHostPort(opts.BindAddress.String(), strconv.Itoa(opts.Port))
+	server := &http.Server{
+		Addr:              secureAddress,
+		Handler:           router.Router(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	klog.V(1).InfoS("Listening and serving securely on", "address", secureAddress)
+	go func() {
+		klog.Fatal(server.ListenAndServeTLS("", ""))
+	}()
+	return nil
+}
+
+// secureServingConfig returns the tls config of the secure port, the certificate is loaded from --tls-cert-file
+// and --tls-key-file, or generated and persisted in a secret of host cluster if they are not set.
+func secureServingConfig(ctx context.Context, opts *options.Options) (*tls.Config, error) {
+	reloader, err := certificate.NewReloader(opts.TLSCertFile, opts.TLSKeyFile, opts.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.TLSCertFile) == 0 {
+		host := fmt.Sprintf("%s.%s.svc", serviceName, opts.Namespace)
+		selfSigned, err := certificate.LoadOrCreateSelfSigned(ctx, client.InClusterClient(), opts.Namespace, host, []string{
+			"localhost",
+			serviceName,
+			fmt.Sprintf("%s.%s", serviceName, opts.Namespace),
+			host,
+			fmt.Sprintf("%s.cluster.local", host),
+		})
+		if err != nil {
+			return nil, err
+		}
+		reloader.SetCertificate(selfSigned)
+	}
+	go reloader.Run(ctx)
+	return reloader.TLSConfig(), nil
 }
@@ -18,8 +18,12 @@ package options
 
 import (
 	"net"
+	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+	"github.com/karmada-io/dashboard/pkg/resource/user"
 )
 
 // Options contains everything necessary to create and run api.
@@ -28,6 +32,9 @@ type Options struct {
 	Port                          int
 	InsecureBindAddress           net.IP
 	InsecurePort                  int
+	TLSCertFile                   string
+	TLSKeyFile                    string
+	ClientCAFile                  string
 	KubeConfig                    string
 	KubeContext                   string
 	SkipKubeApiserverTLSVerify    bool
@@ -37,6 +44,24 @@ type Options struct {
 	Namespace                     string
 	DisableCSRFProtection         bool
 	OpenAPIEnabled                bool
+	EnableServiceAccountMode      bool
+	OIDCIssuerURL                 string
+	OIDCClientID                  string
+	OIDCClientSecret              string
+	OIDCRedirectURL               string
+	OIDCScopes                    []string
+	OIDCJWKSURL                   string
+	OIDCPostLoginRedirectURL      string
+	TokenIdleTTL                  time.Duration
+	TokenAbsoluteTTL              time.Duration
+	EncryptionKeyRotationInterval time.Duration
+	AuditSinks                    []string
+	AuditLogPath                  string
+	AuditLogMaxSize               int
+	AuditLogMaxBackups            int
+	AuditWebhookURL               string
+	UserNamespace                 string
+	ClusterHistoryPath            string
 }
 
 // NewOptions returns initialized Options.
@@ -50,9 +75,12 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 		return
 	}
 	fs.IPVar(&o.BindAddress, "bind-address", net.IPv4(127, 0, 0, 1), "IP address on which to serve the --port, set to 0.0.0.0 for all interfaces")
-	fs.IntVar(&o.Port, "port", 8001, "secure port to listen to for incoming HTTPS requests")
+	fs.IntVar(&o.Port, "port", 8001, "secure port to listen to for incoming HTTPS requests, set to 0 to disable")
 	fs.IPVar(&o.InsecureBindAddress, "insecure-bind-address", net.IPv4(127, 0, 0, 1), "IP address on which to serve the --insecure-port, set to 0.0.0.0 for all interfaces")
 	fs.IntVar(&o.InsecurePort, "insecure-port", 8000, "port to listen to for incoming HTTP requests")
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", "", "file containing the x509 certificate for HTTPS, a self-signed certificate persisted in a secret of --namespace is used if not set, the file is reloaded once it changes")
+	fs.StringVar(&o.TLSKeyFile, "tls-key-file", "", "file containing the x509 private key matching --tls-cert-file")
+	fs.StringVar(&o.ClientCAFile, "client-ca-file", "", "if set, requests on the secure port presenting a client certificate signed by one of the authorities in this file are served as the user in the common name and the groups in the organizations of the certificate, by impersonation with the dashboard's own karmada credentials")
 	fs.StringVar(&o.KubeConfig, "kubeconfig", "", "Path to the host cluster kubeconfig file.")
 	fs.StringVar(&o.KubeContext, "context", "", "The name of the kubeconfig context to use.")
 	fs.BoolVar(&o.SkipKubeApiserverTLSVerify, "skip-kube-apiserver-tls-verify", false, "enable if connection with remote Kubernetes API server should skip TLS verify")
@@ -62,4 +90,22 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Namespace, "namespace", "karmada-dashboard", "Namespace to use when accessing Dashboard specific resources, i.e. configmap")
 	fs.BoolVar(&o.DisableCSRFProtection, "disable-csrf-protection", false, "allows disabling CSRF protection")
 	fs.BoolVar(&o.OpenAPIEnabled, "openapi-enabled", false, "enables OpenAPI v2 endpoint under '/apidocs.json'")
+	fs.BoolVar(&o.EnableServiceAccountMode, "enable-service-account-mode", false, "serve requests without a bearer token with the dashboard's own karmada credentials, only for trusted single-tenant installs")
+	fs.StringVar(&o.OIDCIssuerURL, "oidc-issuer-url", "", "URL of the OIDC issuer used for login, oidc login is disabled if not set")
+	fs.StringVar(&o.OIDCClientID, "oidc-client-id", "", "client id of dashboard registered in the OIDC issuer")
+	fs.StringVar(&o.OIDCClientSecret, "oidc-client-secret", "", "client secret of dashboard registered in the OIDC issuer")
+	fs.StringVar(&o.OIDCRedirectURL, "oidc-redirect-url", "", "callback url of oidc login, e.g. https://dashboard.example.com/api/v1/login/oidc/callback")
+	fs.StringSliceVar(&o.OIDCScopes, "oidc-scopes", []string{"openid", "profile", "email", "offline_access"}, "scopes requested during oidc login")
+	fs.StringVar(&o.OIDCJWKSURL, "oidc-jwks-url", "", "URL of the JWKS used to verify id tokens, defaults to the jwks_uri advertised by the issuer")
+	fs.StringVar(&o.OIDCPostLoginRedirectURL, "oidc-post-login-redirect-url", "/", "URL the browser is redirected to after oidc login succeeded")
+	fs.DurationVar(&o.TokenIdleTTL, "token-idle-ttl", token.DefaultIdleTTL, "duration a dashboard token or session stays valid without being used or refreshed")
+	fs.DurationVar(&o.TokenAbsoluteTTL, "token-absolute-ttl", token.DefaultAbsoluteTTL, "duration after login that a dashboard token or session can be refreshed, the user must login again afterwards")
+	fs.DurationVar(&o.EncryptionKeyRotationInterval, "encryption-key-rotation-interval", 24*time.Hour, "interval to rotate the key which encrypts dashboard tokens and sessions, it must be longer than --token-idle-ttl")
+	fs.StringSliceVar(&o.AuditSinks, "audit-sinks", []string{}, "sinks audit events of mutating requests are written to, supported sinks are file, stdout and webhook. Recent events can be queried from /api/v1/audit regardless of sinks")
+	fs.StringVar(&o.AuditLogPath, "audit-log-path", "/var/log/karmada-dashboard/audit.log", "path of the audit log written by the file sink")
+	fs.IntVar(&o.AuditLogMaxSize, "audit-log-max-size", 100, "size in megabytes the audit log is rotated at")
+	fs.IntVar(&o.AuditLogMaxBackups, "audit-log-max-backups", 5, "number of rotated audit logs to keep")
+	fs.StringVar(&o.AuditWebhookURL, "audit-webhook-url", "", "URL the webhook sink posts audit events to as json")
+	fs.StringVar(&o.UserNamespace, "user-namespace", user.DefaultNamespace, "namespace of karmada control plane the ServiceAccounts of users provisioned by /api/v1/users are created in")
+	fs.StringVar(&o.ClusterHistoryPath, "cluster-history-path", "", "path of the SQLite database the condition transitions of member clusters are recorded in, it should be on a persistent volume, the cluster history is disabled if empty")
 }
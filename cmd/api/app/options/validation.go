@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate checks Options and return a slice of found errs.
+func (o *Options) Validate() field.ErrorList {
+	errs := field.ErrorList{}
+	newPath := field.NewPath("Options")
+	// the previous key only stays valid for one more rotation interval, idle tokens must not outlive it
+	if o.EncryptionKeyRotationInterval <= o.TokenIdleTTL {
+		errs = append(errs, field.Invalid(newPath.Child("EncryptionKeyRotationInterval"), o.EncryptionKeyRotationInterval.String(),
+			"--encryption-key-rotation-interval must be longer than --token-idle-ttl"))
+	}
+	return errs
+}
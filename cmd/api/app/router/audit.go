@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/audit"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
+	"github.com/karmada-io/dashboard/pkg/client"
+)
+
+const (
+	// maxRequestBodyBytes is the largest body of a mutating request which is served.
+	maxRequestBodyBytes = 8 << 20
+	// auditedBodyBytes is the size of the prefix of request body the target of an audit event is read from.
+	auditedBodyBytes = 64 << 10
+	// auditUserKey is the key of the verified user of a request in gin context.
+	auditUserKey = "auditUser"
+	// verifiedUserTTL is how long the user a token was verified as is cached.
+	verifiedUserTTL = 5 * time.Minute
+)
+
+// verifiedUsers caches the users tokens were verified as by the sha256 digest of token, so that a TokenReview
+// is not sent on every mutating request.
+var verifiedUsers = cache.NewLRUExpireCache(1024)
+
+// auditBody replays the prefix of request body read by the audit middleware and digests the body as it's read.
+type auditBody struct {
+	io.Reader
+	io.Closer
+	hash hash.Hash
+	eof  bool
+}
+
+func (b *auditBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.hash.Write(p[:n])
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+// auditTarget is the part of a request body which identifies the object being mutated.
+type auditTarget struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// MemberClusterName is the name of cluster being joined.
+	MemberClusterName string `json:"memberClusterName"`
+	Metadata          struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// AuditMiddleware records an audit event for every mutating request once it has been served, including
+// the ones rejected by later middlewares. The body of request is limited to maxRequestBodyBytes, only its
+// prefix is kept to find the target of event.
+func AuditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		var prefix []byte
+		var body *auditBody
+		if c.Request.Body != nil {
+			limited := http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodyBytes)
+			prefix, _ = io.ReadAll(io.LimitReader(limited, auditedBodyBytes))
+			body = &auditBody{Reader: io.MultiReader(bytes.NewReader(prefix), limited), Closer: limited, hash: sha256.New()}
+			c.Request.Body = body
+		}
+
+		c.Next()
+
+		event := &audit.Event{
+			ID:        string(uuid.NewUUID()),
+			Timestamp: start,
+			SourceIP:  c.ClientIP(),
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Cluster:   c.Param("clustername"),
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+		event.User = c.GetString(auditUserKey)
+		if len(event.User) == 0 {
+			event.UnverifiedUser = identity.FromRequest(c.Request)
+		}
+		if karmadaToken, err := client.GetTokenFromRequest(c.Request); err == nil && len(karmadaToken) > 0 {
+			digest := sha256.Sum256([]byte(karmadaToken))
+			event.CredentialDigest = hex.EncodeToString(digest[:])
+		}
+		fillTarget(c, event, prefix)
+		if event.Kind == "cluster" && len(event.Cluster) == 0 {
+			event.Cluster = event.Name
+		}
+		// the body is digested only if it's known as a whole, either as the prefix or read up to its end
+		if len(prefix) > 0 && len(prefix) < auditedBodyBytes {
+			digest := sha256.Sum256(prefix)
+			event.RequestDigest = hex.EncodeToString(digest[:])
+		} else if body != nil && body.eof {
+			event.RequestDigest = hex.EncodeToString(body.hash.Sum(nil))
+		}
+
+		event.Code = c.Writer.Status()
+		if code, ok := c.Get(common.ResponseCodeKey); ok {
+			event.Code, _ = code.(int)
+		}
+		event.Message = c.GetString(common.ResponseMessageKey)
+		event.Outcome = audit.OutcomeSuccess
+		if event.Code < http.StatusOK || event.Code >= http.StatusBadRequest {
+			event.Outcome = audit.OutcomeFailure
+		}
+		audit.Record(event)
+	}
+}
+
+// AuditIdentityMiddleware verifies the user of a mutating request for its audit event, it runs after the
+// requests are authorized by CSRFMiddleware and before they are served, which may revoke the credentials
+// they are sent with. Users are cached by token so that a TokenReview is not sent on every request.
+func AuditIdentityMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		var key string
+		if karmadaToken, err := client.GetTokenFromRequest(c.Request); err == nil && len(karmadaToken) > 0 {
+			digest := sha256.Sum256([]byte(karmadaToken))
+			key = hex.EncodeToString(digest[:])
+			if user, ok := verifiedUsers.Get(key); ok {
+				c.Set(auditUserKey, user)
+				c.Next()
+				return
+			}
+		}
+		if user, err := identity.Verify(c.Request); err == nil {
+			if len(key) > 0 {
+				verifiedUsers.Add(key, user, verifiedUserTTL)
+			}
+			c.Set(auditUserKey, user)
+		}
+		c.Next()
+	}
+}
+
+// fillTarget sets the kind, namespace and name of the object a request mutates, they are taken from the path
+// parameters of the route and from the metadata of request body if the route doesn't carry them.
+func fillTarget(c *gin.Context, event *audit.Event, body []byte) {
+	event.Kind = c.Param("kind")
+	if len(event.Kind) == 0 {
+		event.Kind = kindFromPath(c.Request.URL.Path, event.Cluster)
+	}
+	event.Namespace = c.Param("namespace")
+	event.Name = c.Param("name")
+	if (len(event.Name) > 0 && len(event.Namespace) > 0) || len(body) == 0 {
+		return
+	}
+
+	target := &auditTarget{}
+	if err := json.Unmarshal(body, target); err != nil {
+		return
+	}
+	if len(event.Name) == 0 {
+		for _, name := range []string{target.Metadata.Name, target.Name, target.MemberClusterName} {
+			if len(name) > 0 {
+				event.Name = name
+				break
+			}
+		}
+	}
+	if len(event.Namespace) == 0 {
+		event.Namespace = target.Metadata.Namespace
+		if len(event.Namespace) == 0 {
+			event.Namespace = target.Namespace
+		}
+	}
+}
+
+// kindFromPath returns the first segment after the api version, or after the member cluster prefix for
+// requests proxied to member clusters, e.g. "deployment" for /api/v1/member/member1/deployment/default/nginx.
+func kindFromPath(path, cluster string) string {
+	path = strings.TrimPrefix(path, "/api/v1/")
+	if len(cluster) > 0 {
+		path = strings.TrimPrefix(path, "member/"+cluster+"/")
+	}
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
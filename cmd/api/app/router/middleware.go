@@ -24,21 +24,69 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/csrf"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
 )
 
 // EnsureMemberClusterMiddleware ensures that the member cluster exists.
 func EnsureMemberClusterMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		karmadaClient := client.InClusterKarmadaClient()
-		_, err := karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), c.Param("clustername"), metav1.GetOptions{})
+		karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusOK, common.BaseResponse{
-				Code: 500,
-				Msg:  err.Error(),
-			})
+			common.Abort(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		_, err = karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), c.Param("clustername"), metav1.GetOptions{})
+		if err != nil {
+			common.Abort(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// CSRFMiddleware rejects mutating requests which do not carry a valid csrf token for their action
+// in the X-CSRF-TOKEN header, tokens are issued by /api/v1/csrftoken/:action.
+func CSRFMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !csrf.Enabled() || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if !csrf.Validate(c.GetHeader(csrf.HeaderName), csrf.ActionFromPath(c.Request.URL.Path)) {
+			common.Abort(c, http.StatusForbidden, errors.MsgCSRFValidationError)
 			return
 		}
 		c.Next()
 	}
 }
+
+// NonResourceAccessMiddleware rejects requests whose user may not perform verb on the non-resource path of
+// karmada apiserver, it guards dashboard features which are not backed by a kubernetes resource.
+func NonResourceAccessMiddleware(path, verb string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+		if err != nil {
+			code, err := errors.HandleError(err)
+			common.Abort(c, code, err.Error())
+			return
+		}
+		allowed, err := permission.CanAccessNonResource(kubeClient, path, verb)
+		if err != nil {
+			code, err := errors.HandleError(err)
+			common.Abort(c, code, err.Error())
+			return
+		}
+		if !allowed {
+			common.Abort(c, http.StatusForbidden, errors.MsgForbiddenError)
+			return
+		}
+		c.Next()
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
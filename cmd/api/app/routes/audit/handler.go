@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/audit"
+)
+
+// auditPath is the non-resource path users must be allowed to get in order to query the audit log, e.g.
+// with a ClusterRole granting nonResourceURLs: ["/karmada-dashboard/audit"] and verbs: ["get"].
+const auditPath = "/karmada-dashboard/audit"
+
+func handleGetAuditEvents(c *gin.Context) {
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	common.Success(c, audit.GetEventList(dataSelect))
+}
+
+func init() {
+	r := router.V1()
+	r.GET("/audit", router.NonResourceAccessMiddleware(auditPath, "get"), handleGetAuditEvents)
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package auth
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"k8s.io/klog/v2"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/csrf"
+	"github.com/karmada-io/dashboard/pkg/common/types"
 )
 
 func handleLogin(c *gin.Context) {
@@ -32,6 +36,18 @@ func handleLogin(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
+	if len(loginRequest.Kubeconfig) > 0 {
+		response, cookie, _, err := loginWithKubeconfig(c.Request.Context(), loginRequest)
+		if err != nil {
+			klog.ErrorS(err, "Could not login with kubeconfig")
+			common.Fail(c, err)
+			return
+		}
+		cookie.Secure = isSecureRequest(c.Request)
+		http.SetCookie(c.Writer, cookie)
+		common.Success(c, response)
+		return
+	}
 	response, _, err := login(loginRequest, c.Request)
 	if err != nil {
 		common.Fail(c, err)
@@ -51,7 +67,33 @@ func handleMe(c *gin.Context) {
 	common.Success(c, response)
 }
 
+func handlePermissions(c *gin.Context) {
+	kinds := make([]types.ResourceKind, 0)
+	for _, kind := range splitQuery(c.Query("kind")) {
+		kinds = append(kinds, types.ResourceKind(kind))
+	}
+	response, _, err := permissions(c.Request, splitQuery(c.Query("namespace")), kinds)
+	if err != nil {
+		klog.ErrorS(err, "Could not get permissions of user")
+		common.Fail(c, err)
+		return
+	}
+
+	common.Success(c, response)
+}
+
+func handleCSRFToken(c *gin.Context) {
+	common.Success(c, v1.CSRFToken{Token: csrf.Generate(c.Param("action"))})
+}
+
 func init() {
 	router.V1().POST("/login", handleLogin)
+	router.V1().POST("/login/kubeconfig/contexts", handleKubeconfigContexts)
 	router.V1().GET("/me", handleMe)
+	router.V1().GET("/me/permissions", handlePermissions)
+	router.V1().GET("/login/oidc", handleOIDCLogin)
+	router.V1().GET("/login/oidc/callback", handleOIDCCallback)
+	router.V1().POST("/token/refresh", handleTokenRefresh)
+	router.V1().POST("/logout", handleLogout)
+	router.V1().GET("/csrftoken/:action", handleCSRFToken)
 }
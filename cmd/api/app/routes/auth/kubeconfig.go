@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// kubeconfigProviderName is the name of provider recorded in sessions issued by kubeconfig login.
+const kubeconfigProviderName = "kubeconfig"
+
+// loginWithKubeconfig validates the credentials of the chosen context of kubeconfig against karmada apiserver
+// and keeps them encrypted in a new session, the returned cookie refers to the session.
+func loginWithKubeconfig(ctx context.Context, spec *v1.LoginRequest) (*v1.LoginResponse, *http.Cookie, int, error) {
+	credentials, err := client.CredentialsFromKubeconfig([]byte(spec.Kubeconfig), spec.Context)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	config, err := client.ConfigFromCredentials(credentials)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	karmadaClient, err := karmadaclientset.NewForConfig(config)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	if _, err = karmadaClient.Discovery().ServerVersion(); err != nil {
+		code, err := errors.HandleError(err)
+		return nil, nil, code, err
+	}
+
+	s := &session.Session{Provider: kubeconfigProviderName}
+	if err = s.SetCredentials(credentials); err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	cookie, err := session.New(ctx, s)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	return &v1.LoginResponse{ExpiresAt: s.ExpiresAt}, cookie, http.StatusOK, nil
+}
+
+func handleKubeconfigContexts(c *gin.Context) {
+	kubeconfigContextsRequest := new(v1.KubeconfigContextsRequest)
+	if err := c.ShouldBind(kubeconfigContextsRequest); err != nil {
+		klog.ErrorS(err, "Could not read KubeconfigContextsRequest")
+		common.Fail(c, err)
+		return
+	}
+	contexts, currentContext, err := client.KubeconfigContexts([]byte(kubeconfigContextsRequest.Kubeconfig))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, v1.KubeconfigContextsResponse{Contexts: contexts, CurrentContext: currentContext})
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
@@ -36,7 +37,11 @@ func login(spec *v1.LoginRequest, request *http.Request) (*v1.LoginResponse, int
 		return nil, code, err
 	}
 
-	return &v1.LoginResponse{Token: spec.Token}, http.StatusOK, nil
+	dashboardToken, claims, err := token.Generate(spec.Token)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &v1.LoginResponse{Token: dashboardToken, ExpiresAt: claims.ExpiresAt}, http.StatusOK, nil
 }
 
 func ensureAuthorizationHeader(spec *v1.LoginRequest, request *http.Request) {
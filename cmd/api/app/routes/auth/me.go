@@ -17,21 +17,14 @@ limitations under the License.
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
 	"net/http"
 
-	"github.com/golang-jwt/jwt/v5"
-
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
 
-const (
-	tokenServiceAccountKey = "serviceaccount"
-)
-
 func me(request *http.Request) (*v1.User, int, error) {
 	karmadaClient, err := client.GetKarmadaClientFromRequest(request)
 	if err != nil {
@@ -45,52 +38,5 @@ func me(request *http.Request) (*v1.User, int, error) {
 		return nil, code, err
 	}
 
-	return getUserFromToken(client.GetBearerToken(request)), http.StatusOK, nil
-}
-
-func getUserFromToken(token string) *v1.User {
-	parsed, _ := jwt.Parse(token, nil)
-	if parsed == nil {
-		return &v1.User{Authenticated: true}
-	}
-
-	claims := parsed.Claims.(jwt.MapClaims)
-
-	found, value := traverse(tokenServiceAccountKey, claims)
-	if !found {
-		return &v1.User{Authenticated: true}
-	}
-
-	var sa v1.ServiceAccount
-	ok := transcode(value, &sa)
-	if !ok {
-		return &v1.User{Authenticated: true}
-	}
-
-	return &v1.User{Name: sa.Name, Authenticated: true}
-}
-
-func traverse(key string, m map[string]interface{}) (found bool, value interface{}) {
-	for k, v := range m {
-		if k == key {
-			return true, v
-		}
-
-		if innerMap, ok := v.(map[string]interface{}); ok {
-			return traverse(key, innerMap)
-		}
-	}
-
-	return false, ""
-}
-
-func transcode(in, out interface{}) bool {
-	buf := new(bytes.Buffer)
-	err := json.NewEncoder(buf).Encode(in)
-	if err != nil {
-		return false
-	}
-
-	err = json.NewDecoder(buf).Decode(out)
-	return err == nil
+	return &v1.User{Name: identity.FromRequest(request), Authenticated: true}, http.StatusOK, nil
 }
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/oidc"
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+)
+
+// postLoginRedirectURL is where the browser will be redirected after the oidc login succeeded.
+var postLoginRedirectURL = "/"
+
+// SetPostLoginRedirectURL sets the url the browser will be redirected to after the oidc login succeeded.
+func SetPostLoginRedirectURL(url string) {
+	if len(url) > 0 {
+		postLoginRedirectURL = url
+	}
+}
+
+func handleOIDCLogin(c *gin.Context) {
+	provider := oidc.GetProvider()
+	if provider == nil {
+		common.Fail(c, fmt.Errorf("oidc login is not enabled"))
+		return
+	}
+	authCodeURL, stateCookie, err := provider.AuthCodeURL()
+	if err != nil {
+		klog.ErrorS(err, "Could not start oidc login")
+		common.Fail(c, err)
+		return
+	}
+	stateCookie.Secure = isSecureRequest(c.Request)
+	http.SetCookie(c.Writer, stateCookie)
+	c.Redirect(http.StatusFound, authCodeURL)
+}
+
+func handleOIDCCallback(c *gin.Context) {
+	provider := oidc.GetProvider()
+	if provider == nil {
+		common.Fail(c, fmt.Errorf("oidc login is not enabled"))
+		return
+	}
+	// the state cookie is single use, it's cleared whatever the outcome of the callback
+	clearStateCookie := oidc.ClearStateCookie()
+	clearStateCookie.Secure = isSecureRequest(c.Request)
+	http.SetCookie(c.Writer, clearStateCookie)
+	if errCode := c.Query("error"); len(errCode) > 0 {
+		klog.ErrorS(nil, "OIDC provider returned an error", "error", errCode, "description", c.Query("error_description"))
+		common.Fail(c, fmt.Errorf("oidc login failed: %s %s", errCode, c.Query("error_description")))
+		return
+	}
+
+	stateCookie, _ := c.Request.Cookie(oidc.StateCookieName)
+	token, err := provider.Exchange(c.Request.Context(), stateCookie, c.Query("state"), c.Query("code"))
+	if err != nil {
+		klog.ErrorS(err, "Could not finish oidc login")
+		common.Fail(c, err)
+		return
+	}
+	cookie, err := session.New(c.Request.Context(), &session.Session{
+		Provider:     oidc.ProviderName,
+		Token:        token.IDToken,
+		RefreshToken: token.RefreshToken,
+		TokenExpiry:  token.Expiry,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Could not create session")
+		common.Fail(c, err)
+		return
+	}
+	cookie.Secure = isSecureRequest(c.Request)
+	http.SetCookie(c.Writer, cookie)
+	c.Redirect(http.StatusFound, postLoginRedirectURL)
+}
+
+func isSecureRequest(request *http.Request) bool {
+	return request.TLS != nil || request.Header.Get("X-Forwarded-Proto") == "https"
+}
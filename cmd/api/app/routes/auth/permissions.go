@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
+)
+
+func permissions(request *http.Request, namespaces []string, kinds []types.ResourceKind) (*v1.PermissionsResponse, int, error) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(request)
+	if err != nil {
+		code, err := errors.HandleError(err)
+		return nil, code, err
+	}
+
+	user, err := identity.Review(request, kubeClient)
+	if err != nil {
+		code, err := errors.HandleError(err)
+		return nil, code, err
+	}
+
+	result, err := permission.GetPermissions(kubeClient, namespaces, kinds)
+	if err != nil {
+		code, err := errors.HandleError(err)
+		return nil, code, err
+	}
+	return &v1.PermissionsResponse{User: user, Permissions: result}, http.StatusOK, nil
+}
+
+func splitQuery(value string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
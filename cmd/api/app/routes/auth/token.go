@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	goerrors "errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// handleTokenRefresh renews the idle ttl of the dashboard token in authorization header and returns the new token.
+// Requests authenticated by a session cookie get their session extended and an empty token.
+func handleTokenRefresh(c *gin.Context) {
+	if !client.HasAuthorizationHeader(c.Request) {
+		s, err := session.FromRequest(c.Request)
+		if err != nil {
+			common.Fail(c, toTokenError(err))
+			return
+		}
+		common.Success(c, v1.LoginResponse{ExpiresAt: s.ExpiresAt})
+		return
+	}
+
+	dashboardToken, claims, err := token.Refresh(client.GetBearerToken(c.Request))
+	if err != nil {
+		klog.V(4).InfoS("Could not refresh dashboard token", "err", err)
+		common.Fail(c, toTokenError(err))
+		return
+	}
+	common.Success(c, v1.LoginResponse{Token: dashboardToken, ExpiresAt: claims.ExpiresAt})
+}
+
+// handleLogout revokes the dashboard token in authorization header and deletes the session of request.
+func handleLogout(c *gin.Context) {
+	if client.HasAuthorizationHeader(c.Request) {
+		if bearerToken := client.GetBearerToken(c.Request); token.IsDashboardToken(bearerToken) {
+			if err := token.Revoke(bearerToken); err != nil {
+				klog.ErrorS(err, "Could not revoke dashboard token")
+				common.Fail(c, toTokenError(err))
+				return
+			}
+		}
+	}
+	cookie := session.Delete(c.Request)
+	cookie.Secure = isSecureRequest(c.Request)
+	http.SetCookie(c.Writer, cookie)
+	common.Success(c, nil)
+}
+
+func toTokenError(err error) error {
+	if goerrors.Is(err, token.ErrExpired) {
+		return errors.NewTokenExpired(errors.MsgTokenExpiredError)
+	}
+	return errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+}
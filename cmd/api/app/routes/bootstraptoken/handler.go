@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstraptoken
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/resource/bootstraptoken"
+)
+
+func handleCreateBootstrapToken(c *gin.Context) {
+	createRequest := new(v1.CreateBootstrapTokenRequest)
+	if err := c.ShouldBind(createRequest); err != nil {
+		klog.ErrorS(err, "Could not read CreateBootstrapTokenRequest")
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	restConfig, _, err := client.GetKarmadaConfig()
+	if err != nil {
+		klog.ErrorS(err, "Get restConfig for karmada failed")
+		common.Fail(c, err)
+		return
+	}
+	result, err := bootstraptoken.CreateToken(kubeClient, restConfig, createRequest.APIServerEndpoint,
+		time.Duration(createRequest.TTLSeconds)*time.Second, createRequest.Description)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create bootstrap token")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleGetBootstrapTokens(c *gin.Context) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	result, err := bootstraptoken.GetTokenList(kubeClient, dataSelect)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list bootstrap tokens")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleDeleteBootstrapToken(c *gin.Context) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	id := c.Param("id")
+	if err = bootstraptoken.DeleteToken(kubeClient, id); err != nil {
+		klog.ErrorS(err, "Failed to delete bootstrap token", "id", id)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+func init() {
+	r := router.V1()
+	r.POST("/bootstraptoken", handleCreateBootstrapToken)
+	r.GET("/bootstraptoken", handleGetBootstrapTokens)
+	r.DELETE("/bootstraptoken/:id", handleDeleteBootstrapToken)
+}
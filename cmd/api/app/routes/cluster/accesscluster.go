@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -28,12 +29,18 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/operation"
 )
 
 const (
@@ -43,30 +50,50 @@ const (
 	KarmadaAgentServiceAccountName = "karmada-agent-sa"
 	// KarmadaAgentName is the name of karmada-agent
 	KarmadaAgentName = "karmada-agent"
-	// KarmadaAgentImage is the image of karmada-agent
-	KarmadaAgentImage = "karmada/karmada-agent:latest"
+	// KarmadaAgentImage is the image repository of karmada-agent
+	KarmadaAgentImage = "karmada/karmada-agent"
 	// ClusterNamespace is the namespace of cluster
 	ClusterNamespace = "karmada-cluster"
 )
 
+// names of the steps of cluster operations
+const (
+	stepNamespaceCreated   = "namespace created"
+	stepSecretCreated      = "secret created"
+	stepRBACCreated        = "RBAC created"
+	stepAgentCreated       = "agent deployment created"
+	stepAgentAvailable     = "agent deployment available"
+	stepIdentityChecked    = "cluster identity checked"
+	stepCredentialsCreated = "credentials created"
+	stepClusterRegistered  = "cluster registered"
+	stepClusterReady       = "cluster Ready"
+)
+
 var (
 	karmadaAgentLabels   = map[string]string{"app": KarmadaAgentName}
 	karmadaAgentReplicas = int32(2)
 	timeout              = 5 * time.Minute
+	pollInterval         = 2 * time.Second
 )
 
 type pullModeOption struct {
-	karmadaClient          karmadaclientset.Interface
-	karmadaAgentCfg        *clientcmdapi.Config
+	karmadaClient karmadaclientset.Interface
+	// karmadaKubeClient acts with the credentials of caller, it creates the RBAC and bootstrap token of karmada-agent.
+	karmadaKubeClient kubeclient.Interface
+	// karmadaServerConfig is the address and ca of karmada apiserver without any credentials.
+	karmadaServerConfig    *rest.Config
 	memberClusterNamespace string
 	memberClusterClient    *kubeclient.Clientset
 	memberClusterName      string
 	memberClusterEndpoint  string
+	memberClusterLabels    map[string]string
+	karmadaAgent           *karmadaAgentOption
 }
 
-// createSecretAndRBACInMemberCluster create required secrets and rbac in member cluster
-func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
-	configBytes, err := clientcmd.Write(*o.karmadaAgentCfg)
+// createSecretInMemberCluster creates the secret carrying the karmada kubeconfig of karmada-agent in member cluster,
+// as well as the ImagePullSecret of the registry karmada-agent is pulled from.
+func (o pullModeOption) createSecretInMemberCluster(karmadaAgentCfg *clientcmdapi.Config) error {
+	configBytes, err := clientcmd.Write(*karmadaAgentCfg)
 	if err != nil {
 		return fmt.Errorf("failure while serializing karmada-agent kubeConfig. %w", err)
 	}
@@ -89,6 +116,21 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 		return fmt.Errorf("create secret %s failed: %v", kubeConfigSecret.Name, err)
 	}
 
+	if o.karmadaAgent.registry == nil {
+		return nil
+	}
+	imagePullSecret, err := makeImagePullSecret(o.memberClusterNamespace, o.karmadaAgent.registry)
+	if err != nil {
+		return err
+	}
+	if err = cmdutil.CreateOrUpdateSecret(o.memberClusterClient, imagePullSecret); err != nil {
+		return fmt.Errorf("create secret %s failed: %v", imagePullSecret.Name, err)
+	}
+	return nil
+}
+
+// createRBACInMemberCluster creates the service account of karmada-agent in member cluster and grants it permissions
+func (o pullModeOption) createRBACInMemberCluster() error {
 	clusterRole := &rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: KarmadaAgentName,
@@ -119,8 +161,7 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 	}
 
 	// create service account for karmada-agent
-	_, err = karmadautil.EnsureServiceAccountExist(o.memberClusterClient, sa, false)
-	if err != nil {
+	if _, err := karmadautil.EnsureServiceAccountExist(o.memberClusterClient, sa, false); err != nil {
 		return err
 	}
 
@@ -164,35 +205,27 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 		},
 	}
 
-	//controllers := []string{"*"}
+	command := []string{
+		"/bin/karmada-agent",
+		"--karmada-kubeconfig=/etc/kubeconfig/karmada-kubeconfig",
+		fmt.Sprintf("--cluster-name=%s", o.memberClusterName),
+		fmt.Sprintf("--cluster-api-endpoint=%s", o.memberClusterEndpoint),
+		"--feature-gates=CustomizedClusterResourceModeling=true,MultiClusterService=true",
+		"--cluster-status-update-frequency=10s",
+		"--bind-address=0.0.0.0",
+		"--secure-port=10357",
+		"--v=4",
+	}
+	// the flags parsed later take precedence, so extra args are able to override the defaults above
+	command = append(command, o.karmadaAgent.args...)
 	podSpec := corev1.PodSpec{
-		ImagePullSecrets: []corev1.LocalObjectReference{
-			{
-				Name: "fronted-cn-beijing",
-			},
-		},
 		ServiceAccountName: KarmadaAgentServiceAccountName,
 		Containers: []corev1.Container{
 			{
-				Name:  KarmadaAgentName,
-				Image: KarmadaAgentImage,
-				Command: []string{
-					"/bin/karmada-agent",
-					"--karmada-kubeconfig=/etc/kubeconfig/karmada-kubeconfig",
-					fmt.Sprintf("--cluster-name=%s", o.memberClusterName),
-					fmt.Sprintf("--cluster-api-endpoint=%s", o.memberClusterEndpoint),
-					//fmt.Sprintf("--cluster-provider=%s", clusterProvider),
-					//fmt.Sprintf("--cluster-region=%s", clusterRegion),
-					//fmt.Sprintf("--cluster-zones=%s", strings.Join(clusterZones, ",")),
-					//fmt.Sprintf("--controllers=%s", strings.Join(controllers, ",")),
-					//fmt.Sprintf("--proxy-server-address=%s", proxyServerAddress),
-					//fmt.Sprintf("--leader-elect-resource-namespace=%s", o.memberClusterNamespace),
-					"--feature-gates=CustomizedClusterResourceModeling=true,MultiClusterService=true",
-					"--cluster-status-update-frequency=10s",
-					"--bind-address=0.0.0.0",
-					"--secure-port=10357",
-					"--v=4",
-				},
+				Name:      KarmadaAgentName,
+				Image:     o.karmadaAgent.image,
+				Command:   command,
+				Resources: o.karmadaAgent.resources,
 				VolumeMounts: []corev1.VolumeMount{
 					{
 						Name:      "kubeconfig",
@@ -211,12 +244,11 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 				},
 			},
 		},
-		Tolerations: []corev1.Toleration{
-			{
-				Key:      "node-role.kubernetes.io/master",
-				Operator: corev1.TolerationOpExists,
-			},
-		},
+		NodeSelector: o.karmadaAgent.nodeSelector,
+		Tolerations:  o.karmadaAgent.tolerations,
+	}
+	if o.karmadaAgent.registry != nil {
+		podSpec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: KarmadaAgentImagePullSecretName}}
 	}
 	// PodTemplateSpec
 	podTemplateSpec := corev1.PodTemplateSpec{
@@ -229,7 +261,7 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 	}
 	// DeploymentSpec
 	karmadaAgent.Spec = appsv1.DeploymentSpec{
-		Replicas: &karmadaAgentReplicas,
+		Replicas: &o.karmadaAgent.replicas,
 		Template: podTemplateSpec,
 		Selector: &metav1.LabelSelector{
 			MatchLabels: karmadaAgentLabels,
@@ -239,90 +271,208 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 	return karmadaAgent
 }
 
-func accessClusterInPullMode(opts *pullModeOption) error {
-	_, exist, err := karmadautil.GetClusterWithKarmadaClient(opts.karmadaClient, opts.memberClusterName)
+// pullModeSteps are the steps of joining a cluster in pull mode.
+var pullModeSteps = []string{
+	stepNamespaceCreated, stepCredentialsCreated, stepSecretCreated, stepRBACCreated, stepAgentCreated, stepAgentAvailable, stepClusterReady,
+}
+
+// checkClusterNotExist makes sure no cluster with the name has been joined, it's checked before the join
+// operation starts so that the request fails fast.
+func checkClusterNotExist(karmadaClient karmadaclientset.Interface, name string) error {
+	_, exist, err := karmadautil.GetClusterWithKarmadaClient(karmadaClient, name)
 	if err != nil {
 		return err
 	}
 	if exist {
-		return fmt.Errorf("failed to register as cluster with name %s already exists", opts.memberClusterName)
+		return fmt.Errorf("failed to register as cluster with name %s already exists", name)
+	}
+	return nil
+}
+
+func accessClusterInPullMode(ctx context.Context, recorder *operation.Recorder, opts *pullModeOption) error {
+	err := recorder.Step(ctx, stepNamespaceCreated, func(_ context.Context) error {
+		// It's necessary to set the label of namespace to make sure that the namespace is created by Karmada.
+		labels := map[string]string{
+			karmadautil.ManagedByKarmadaLabel: karmadautil.ManagedByKarmadaLabelValue,
+		}
+		// ensure namespace where the karmada-agent resources be deployed exists in the member cluster
+		_, err := karmadautil.EnsureNamespaceExistWithLabels(opts.memberClusterClient, opts.memberClusterNamespace, false, labels)
+		return err
+	})
+	if err != nil {
+		return err
 	}
-	// It's necessary to set the label of namespace to make sure that the namespace is created by Karmada.
-	labels := map[string]string{
-		karmadautil.ManagedByKarmadaLabel: karmadautil.ManagedByKarmadaLabelValue,
+	var karmadaAgentCfg *clientcmdapi.Config
+	if err = recorder.Step(ctx, stepCredentialsCreated, func(ctx context.Context) error {
+		karmadaAgentCfg, err = opts.issueAgentKubeconfig(ctx, recorder)
+		return err
+	}); err != nil {
+		return err
 	}
-	// ensure namespace where the karmada-agent resources be deployed exists in the member cluster
-	if _, err = karmadautil.EnsureNamespaceExistWithLabels(opts.memberClusterClient, opts.memberClusterNamespace, false, labels); err != nil {
+	if err = recorder.Step(ctx, stepSecretCreated, func(_ context.Context) error {
+		return opts.createSecretInMemberCluster(karmadaAgentCfg)
+	}); err != nil {
 		return err
 	}
-	if err = opts.createSecretAndRBACInMemberCluster(); err != nil {
+	if err = recorder.Step(ctx, stepRBACCreated, func(_ context.Context) error {
+		return opts.createRBACInMemberCluster()
+	}); err != nil {
 		return err
 	}
 	karmadaAgentDeployment := opts.makeKarmadaAgentDeployment()
-	if _, err = opts.memberClusterClient.AppsV1().Deployments(opts.memberClusterNamespace).Create(context.TODO(), karmadaAgentDeployment, metav1.CreateOptions{}); err != nil {
+	if err = recorder.Step(ctx, stepAgentCreated, func(ctx context.Context) error {
+		_, err := opts.memberClusterClient.AppsV1().Deployments(opts.memberClusterNamespace).Create(ctx, karmadaAgentDeployment, metav1.CreateOptions{})
+		return err
+	}); err != nil {
 		return err
 	}
-	// TODO: deployment ready cannot exactly express that cluster is ready
-	// It should also check cluster resource on karmada control-plane
-	// maybe karmadactl should optimized it
-	if err = cmdutil.WaitForDeploymentRollout(opts.memberClusterClient, karmadaAgentDeployment, int(timeout)); err != nil {
+	if err = recorder.Step(ctx, stepAgentAvailable, func(ctx context.Context) error {
+		return waitForDeploymentAvailable(ctx, opts.memberClusterClient, karmadaAgentDeployment)
+	}); err != nil {
 		return err
 	}
-	return nil
+	// deployment ready cannot exactly express that cluster is ready, the cluster registered by karmada-agent
+	// must become ready as well
+	return recorder.Step(ctx, stepClusterReady, func(ctx context.Context) error {
+		if err := waitForClusterReady(ctx, opts.karmadaClient, opts.memberClusterName); err != nil {
+			return err
+		}
+		// karmada-agent can not label the cluster it registers, the labels are added once it exists
+		return labelCluster(ctx, opts.karmadaClient, opts.memberClusterName, opts.memberClusterLabels)
+	})
+}
+
+// labelCluster adds labels to the cluster object in karmada control plane.
+func labelCluster(ctx context.Context, karmadaClient karmadaclientset.Interface, name string, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = karmadaClient.ClusterV1alpha1().Clusters().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// waitForDeploymentAvailable waits until all replicas of the latest revision of deployment are available.
+func waitForDeploymentAvailable(ctx context.Context, kubeClient kubeclient.Interface, deployment *appsv1.Deployment) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		current, err := kubeClient.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if current.Spec.Replicas != nil {
+			replicas = *current.Spec.Replicas
+		}
+		return current.Status.ObservedGeneration >= current.Generation &&
+			current.Status.UpdatedReplicas == replicas &&
+			current.Status.AvailableReplicas == replicas, nil
+	})
+}
+
+// waitForClusterReady waits until the cluster object exists in karmada control plane and reports ready.
+func waitForClusterReady(ctx context.Context, karmadaClient karmadaclientset.Interface, name string) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady), nil
+	})
 }
 
 type pushModeOption struct {
 	karmadaClient           karmadaclientset.Interface
 	clusterName             string
+	clusterProvider         string
+	clusterRegion           string
+	clusterZones            []string
+	clusterLabels           map[string]string
 	karmadaRestConfig       *rest.Config
 	memberClusterRestConfig *rest.Config
 }
 
-func accessClusterInPushMode(opts *pushModeOption) error {
+// pushModeSteps are the steps of joining a cluster in push mode.
+var pushModeSteps = []string{stepIdentityChecked, stepCredentialsCreated, stepClusterRegistered, stepClusterReady}
+
+func accessClusterInPushMode(ctx context.Context, recorder *operation.Recorder, opts *pushModeOption) error {
 	registerOption := karmadautil.ClusterRegisterOption{
 		ClusterNamespace:   ClusterNamespace,
 		ClusterName:        opts.clusterName,
+		ClusterProvider:    opts.clusterProvider,
+		ClusterRegion:      opts.clusterRegion,
+		ClusterZones:       opts.clusterZones,
 		ReportSecrets:      []string{karmadautil.KubeCredentials, karmadautil.KubeImpersonator},
 		ControlPlaneConfig: opts.karmadaRestConfig,
 		ClusterConfig:      opts.memberClusterRestConfig,
 	}
 
-	controlPlaneKubeClient := kubeclient.NewForConfigOrDie(opts.karmadaRestConfig)
-	memberClusterKubeClient := kubeclient.NewForConfigOrDie(opts.memberClusterRestConfig)
-	id, err := karmadautil.ObtainClusterID(memberClusterKubeClient)
+	controlPlaneKubeClient, err := kubeclient.NewForConfig(opts.karmadaRestConfig)
 	if err != nil {
-		klog.ErrorS(err, "ObtainClusterID failed")
 		return err
 	}
-	exist, name, err := karmadautil.IsClusterIdentifyUnique(opts.karmadaClient, id)
+	memberClusterKubeClient, err := kubeclient.NewForConfig(opts.memberClusterRestConfig)
 	if err != nil {
-		klog.ErrorS(err, "Check ClusterIdentify failed")
 		return err
 	}
-	if !exist {
-		return fmt.Errorf("the same cluster has been registered with name %s", name)
+	if err = recorder.Step(ctx, stepIdentityChecked, func(_ context.Context) error {
+		id, err := karmadautil.ObtainClusterID(memberClusterKubeClient)
+		if err != nil {
+			return err
+		}
+		exist, name, err := karmadautil.IsClusterIdentifyUnique(opts.karmadaClient, id)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return fmt.Errorf("the same cluster has been registered with name %s", name)
+		}
+		registerOption.ClusterID = id
+		return nil
+	}); err != nil {
+		return err
 	}
-	registerOption.ClusterID = id
 
-	clusterSecret, impersonatorSecret, err := karmadautil.ObtainCredentialsFromMemberCluster(memberClusterKubeClient, registerOption)
-	if err != nil {
-		klog.ErrorS(err, "ObtainCredentialsFromMemberCluster failed")
+	if err = recorder.Step(ctx, stepCredentialsCreated, func(_ context.Context) error {
+		clusterSecret, impersonatorSecret, err := karmadautil.ObtainCredentialsFromMemberCluster(memberClusterKubeClient, registerOption)
+		if err != nil {
+			return err
+		}
+		registerOption.Secret = *clusterSecret
+		registerOption.ImpersonatorSecret = *impersonatorSecret
+		return nil
+	}); err != nil {
 		return err
 	}
-	registerOption.Secret = *clusterSecret
-	registerOption.ImpersonatorSecret = *impersonatorSecret
 
-	err = karmadautil.RegisterClusterInControllerPlane(registerOption, controlPlaneKubeClient, generateClusterInControllerPlane)
-	if err != nil {
+	if err = recorder.Step(ctx, stepClusterRegistered, func(_ context.Context) error {
+		return karmadautil.RegisterClusterInControllerPlane(registerOption, controlPlaneKubeClient,
+			func(registerOption karmadautil.ClusterRegisterOption) (*clusterv1alpha1.Cluster, error) {
+				return generateClusterInControllerPlane(registerOption, opts.clusterLabels)
+			})
+	}); err != nil {
+		return err
+	}
+	if err = recorder.Step(ctx, stepClusterReady, func(ctx context.Context) error {
+		return waitForClusterReady(ctx, opts.karmadaClient, opts.clusterName)
+	}); err != nil {
 		return err
 	}
 	klog.Infof("cluster(%s) is joined successfully\n", opts.clusterName)
 	return nil
 }
 
-func generateClusterInControllerPlane(opts karmadautil.ClusterRegisterOption) (*clusterv1alpha1.Cluster, error) {
+func generateClusterInControllerPlane(opts karmadautil.ClusterRegisterOption, labels map[string]string) (*clusterv1alpha1.Cluster, error) {
 	clusterObj := &clusterv1alpha1.Cluster{}
 	clusterObj.Name = opts.ClusterName
+	clusterObj.Labels = labels
 	clusterObj.Spec.SyncMode = clusterv1alpha1.Push
 	clusterObj.Spec.APIEndpoint = opts.ClusterConfig.Host
 	clusterObj.Spec.ID = opts.ClusterID
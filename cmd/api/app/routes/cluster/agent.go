@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/routes/overview"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/config"
+)
+
+const (
+	// DefaultMemberClusterNamespace is the namespace karmada-agent is deployed into if it's not specified.
+	DefaultMemberClusterNamespace = "karmada-system"
+	// KarmadaAgentImagePullSecretName is the name of the ImagePullSecret generated from the credentials of registry
+	KarmadaAgentImagePullSecretName = "karmada-agent-registry"
+	// defaultKarmadaAgentTag is the tag of karmada-agent image used if the version of control plane is unknown
+	defaultKarmadaAgentTag = "latest"
+)
+
+// reservedKarmadaAgentArgs are the flags set by dashboard which extra args must not override.
+var reservedKarmadaAgentArgs = []string{"--karmada-kubeconfig", "--cluster-name", "--cluster-api-endpoint"}
+
+// karmadaAgentOption is the resolved spec of karmada-agent.
+type karmadaAgentOption struct {
+	image        string
+	replicas     int32
+	resources    corev1.ResourceRequirements
+	nodeSelector map[string]string
+	tolerations  []corev1.Toleration
+	args         []string
+	// registry is the registry whose credentials are used to pull the image, it's nil if no credentials are needed.
+	registry *config.DockerRegistry
+}
+
+// newKarmadaAgentOption resolves the spec of karmada-agent from request, the fields left empty are defaulted.
+func newKarmadaAgentOption(request *v1.PostClusterRequest) (*karmadaAgentOption, error) {
+	spec := request.KarmadaAgent
+	if spec == nil {
+		spec = &v1.KarmadaAgentSpec{}
+	}
+	opt := &karmadaAgentOption{
+		replicas:     karmadaAgentReplicas,
+		resources:    spec.Resources,
+		nodeSelector: spec.NodeSelector,
+		tolerations:  spec.Tolerations,
+	}
+	if spec.Replicas != nil {
+		if *spec.Replicas < 1 {
+			return nil, fmt.Errorf("replicas of karmada-agent must be at least 1, got %d", *spec.Replicas)
+		}
+		opt.replicas = *spec.Replicas
+	}
+	if len(opt.tolerations) == 0 {
+		opt.tolerations = []corev1.Toleration{
+			{
+				Key:      "node-role.kubernetes.io/master",
+				Operator: corev1.TolerationOpExists,
+			},
+		}
+	}
+
+	repository := spec.Image
+	if len(repository) == 0 {
+		repository = KarmadaAgentImage
+	}
+	if len(spec.Registry) > 0 {
+		registry, err := findDockerRegistry(spec.Registry)
+		if err != nil {
+			return nil, err
+		}
+		repository = registryHost(registry.URL) + "/" + repository
+		if len(registry.User) > 0 {
+			opt.registry = registry
+		}
+	}
+	tag := spec.Tag
+	if len(tag) == 0 {
+		tag = controlPlaneVersion()
+	}
+	opt.image = repository + ":" + tag
+
+	if len(request.ClusterProvider) > 0 {
+		opt.args = append(opt.args, fmt.Sprintf("--cluster-provider=%s", request.ClusterProvider))
+	}
+	if len(request.ClusterRegion) > 0 {
+		opt.args = append(opt.args, fmt.Sprintf("--cluster-region=%s", request.ClusterRegion))
+	}
+	if len(request.ClusterZones) > 0 {
+		opt.args = append(opt.args, fmt.Sprintf("--cluster-zones=%s", strings.Join(request.ClusterZones, ",")))
+	}
+	for _, arg := range spec.ExtraArgs {
+		if !strings.HasPrefix(arg, "--") {
+			return nil, fmt.Errorf("invalid karmada-agent arg %q, args must be in the form of --flag=value", arg)
+		}
+		flag := strings.SplitN(arg, "=", 2)[0]
+		for _, reserved := range reservedKarmadaAgentArgs {
+			if flag == reserved {
+				return nil, fmt.Errorf("karmada-agent arg %s is set by dashboard and can not be overridden", reserved)
+			}
+		}
+		opt.args = append(opt.args, arg)
+	}
+	return opt, nil
+}
+
+// findDockerRegistry returns the docker registry with the name in dashboard config.
+func findDockerRegistry(name string) (*config.DockerRegistry, error) {
+	for _, registry := range config.GetDashboardConfig().DockerRegistries {
+		if registry.Name == name {
+			return &registry, nil
+		}
+	}
+	return nil, fmt.Errorf("docker registry %s not found in dashboard config", name)
+}
+
+// registryHost strips the scheme and trailing slash of registry url so that it can prefix image names.
+func registryHost(url string) string {
+	url = strings.TrimPrefix(url, "https://")
+	url = strings.TrimPrefix(url, "http://")
+	return strings.TrimSuffix(url, "/")
+}
+
+// controlPlaneVersion returns the version of karmada control plane which karmada-agent should match.
+func controlPlaneVersion() string {
+	versionInfo, err := overview.GetControllerManagerVersionInfo()
+	if err != nil || len(versionInfo.GitVersion) == 0 {
+		klog.ErrorS(err, "Could not get the version of karmada control plane, fall back to the default tag", "tag", defaultKarmadaAgentTag)
+		return defaultKarmadaAgentTag
+	}
+	return versionInfo.GitVersion
+}
+
+// makeImagePullSecret generates the ImagePullSecret of the registry with its credentials.
+func makeImagePullSecret(namespace string, registry *config.DockerRegistry) (*corev1.Secret, error) {
+	host := registryHost(registry.URL)
+	dockerConfig := map[string]interface{}{
+		"auths": map[string]interface{}{
+			host: map[string]string{
+				"username": registry.User,
+				"password": registry.Password,
+				"auth":     base64.StdEncoding.EncodeToString([]byte(registry.User + ":" + registry.Password)),
+			},
+		},
+	}
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KarmadaAgentImagePullSecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: data},
+	}, nil
+}
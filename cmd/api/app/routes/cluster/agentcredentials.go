@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate/csr"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/operation"
+	"github.com/karmada-io/dashboard/pkg/resource/bootstraptoken"
+)
+
+// bootstrapTokenTTL bounds the lifetime of the bootstrap token which requests the certificate of karmada-agent,
+// the token is revoked as soon as the certificate is issued.
+const bootstrapTokenTTL = 30 * time.Minute
+
+// agentCertificateDuration is the lifetime of the client certificate of karmada-agent, the same as `karmadactl register` requests.
+var agentCertificateDuration = time.Duration(register.DefaultCertExpirationSeconds) * time.Second
+
+// issueAgentKubeconfig issues the kubeconfig karmada-agent of the joining cluster connects to karmada apiserver with,
+// the same way `karmadactl register` does: the RBAC of the agent is created in karmada control plane with the
+// credentials of caller, then a short-lived bootstrap token requests a client certificate for
+// system:karmada:agent:<cluster>, which is approved by the agentcsrapproving controller of karmada-controller-manager.
+// The agent is only granted the permissions of its own cluster, the credentials of dashboard are never handed out.
+func (o *pullModeOption) issueAgentKubeconfig(ctx context.Context, recorder *operation.Recorder) (*clientcmdapi.Config, error) {
+	if err := ensureAgentRBAC(o.karmadaKubeClient, register.GenerateRBACResources(o.memberClusterName, ClusterNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to create RBAC of karmada-agent in karmada control plane: %w", err)
+	}
+	caData, err := certificateAuthorityData(o.karmadaServerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := bootstraptoken.CreateToken(o.karmadaKubeClient, o.karmadaServerConfig, "", bootstrapTokenTTL,
+		"karmada-agent of cluster "+o.memberClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bootstrap token of karmada-agent: %w", err)
+	}
+	defer func() {
+		if err := bootstraptoken.DeleteToken(o.karmadaKubeClient, token.ID); err != nil {
+			klog.ErrorS(err, "Failed to revoke bootstrap token of karmada-agent", "cluster", o.memberClusterName, "id", token.ID)
+		}
+	}()
+	bootstrapConfig := rest.CopyConfig(o.karmadaServerConfig)
+	bootstrapConfig.BearerToken = token.Token
+	bootstrapClient, err := kubeclient.NewForConfig(bootstrapConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	csrData, err := certutil.MakeCSR(privateKey, &pkix.Name{
+		CommonName:   register.ClusterPermissionPrefix + o.memberClusterName,
+		Organization: []string{register.ClusterPermissionGroups},
+	}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	reqName, reqUID, err := csr.RequestCertificate(bootstrapClient, csrData, "", register.SignerName, &agentCertificateDuration,
+		[]certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageClientAuth}, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request certificate of karmada-agent: %w", err)
+	}
+	recorder.Progress(stepCredentialsCreated, fmt.Sprintf("waiting for CertificateSigningRequest %s to be approved, "+
+		"approve it manually if the agentcsrapproving controller of karmada-controller-manager is disabled", reqName))
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	certData, err := csr.WaitForCertificate(waitCtx, bootstrapClient, reqName, reqUID)
+	if err != nil {
+		return nil, fmt.Errorf("certificate of karmada-agent was not issued: %w", err)
+	}
+	keyData, err := keyutil.MarshalPrivateKeyToPEM(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return register.CreateWithCert(o.karmadaServerConfig.Host, register.DefaultClusterName, o.memberClusterName,
+		caData, certData, keyData), nil
+}
+
+// ensureAgentRBAC creates the RBAC resources granting karmada-agent the permissions of its own cluster.
+func ensureAgentRBAC(kubeClient kubeclient.Interface, resources *register.RBACResources) error {
+	for _, clusterRole := range resources.ClusterRoles {
+		if _, err := karmadautil.CreateClusterRole(kubeClient, clusterRole); err != nil {
+			return err
+		}
+	}
+	for _, clusterRoleBinding := range resources.ClusterRoleBindings {
+		if _, err := karmadautil.CreateClusterRoleBinding(kubeClient, clusterRoleBinding); err != nil {
+			return err
+		}
+	}
+	for _, role := range resources.Roles {
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   role.Namespace,
+				Labels: map[string]string{karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue},
+			},
+		}
+		if _, err := karmadautil.CreateNamespace(kubeClient, namespace); err != nil {
+			return err
+		}
+		if _, err := karmadautil.CreateRole(kubeClient, role); err != nil {
+			return err
+		}
+	}
+	for _, roleBinding := range resources.RoleBindings {
+		if _, err := karmadautil.CreateRoleBinding(kubeClient, roleBinding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// certificateAuthorityData returns the ca certificates karmada apiserver is verified with.
+func certificateAuthorityData(config *rest.Config) ([]byte, error) {
+	if len(config.CAData) > 0 {
+		return config.CAData, nil
+	}
+	if len(config.CAFile) > 0 {
+		return os.ReadFile(config.CAFile)
+	}
+	return nil, fmt.Errorf("no ca certificates of karmada apiserver found")
+}
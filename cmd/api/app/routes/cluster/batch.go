@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/operation"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+)
+
+// defaultBatchConcurrency is the number of clusters joined at the same time if the request doesn't tell.
+const defaultBatchConcurrency = 4
+
+func handlePostClusterBatch(c *gin.Context) {
+	batchRequest := new(v1.PostClusterBatchRequest)
+	if err := c.ShouldBind(batchRequest); err != nil {
+		klog.ErrorS(err, "Could not read cluster batch request")
+		common.Fail(c, err)
+		return
+	}
+	kubeconfig := []byte(batchRequest.Kubeconfig)
+	if _, _, err := client.KubeconfigContexts(kubeconfig); err != nil {
+		klog.ErrorS(err, "Could not parse kubeconfig of cluster batch request")
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	user, err := identity.Verify(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to verify user")
+		common.Fail(c, err)
+		return
+	}
+	concurrency := batchRequest.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	// the clusters are prepared in parallel, the started operations share the slots so that no more than
+	// concurrency clusters are being joined at any time
+	slots := cluster.NewSlots(concurrency)
+	results := make([]v1.PostClusterBatchResult, len(batchRequest.Clusters))
+	names := make([]string, 0, len(batchRequest.Clusters))
+	for _, batchCluster := range batchRequest.Clusters {
+		names = append(names, batchCluster.MemberClusterName)
+	}
+	duplicates := cluster.DuplicateNames(names)
+	workqueue.ParallelizeUntil(context.TODO(), concurrency, len(batchRequest.Clusters), func(i int) {
+		batchCluster := batchRequest.Clusters[i]
+		results[i] = v1.PostClusterBatchResult{Context: batchCluster.Context, MemberClusterName: batchCluster.MemberClusterName}
+		if duplicates[batchCluster.MemberClusterName] {
+			results[i].Error = fmt.Sprintf("cluster name %s is requested more than once", batchCluster.MemberClusterName)
+			return
+		}
+		op, err := startBatchJoin(c, karmadaClient, user, kubeconfig, &batchCluster, slots)
+		if err != nil {
+			klog.ErrorS(err, "Could not join cluster of batch", "context", batchCluster.Context, "cluster", batchCluster.MemberClusterName)
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].Operation = op
+	})
+	common.Success(c, v1.PostClusterBatchResponse{Results: results})
+}
+
+func startBatchJoin(c *gin.Context, karmadaClient karmadaclientset.Interface, user string, kubeconfig []byte,
+	batchCluster *v1.PostClusterBatchCluster, slots cluster.Slots) (*operation.Operation, error) {
+	memberClusterKubeconfig, err := client.ContextKubeconfig(kubeconfig, batchCluster.Context)
+	if err != nil {
+		return nil, err
+	}
+	return startJoin(c.Request, karmadaClient, user, &v1.PostClusterRequest{
+		MemberClusterKubeConfig: memberClusterKubeconfig,
+		SyncMode:                batchCluster.SyncMode,
+		MemberClusterName:       batchCluster.MemberClusterName,
+		MemberClusterNamespace:  batchCluster.MemberClusterNamespace,
+		ClusterProvider:         batchCluster.ClusterProvider,
+		ClusterRegion:           batchCluster.ClusterRegion,
+		ClusterZones:            batchCluster.ClusterZones,
+		ClusterLabels:           batchCluster.ClusterLabels,
+		KarmadaAgent:            batchCluster.KarmadaAgent,
+	}, slots)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/operation"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+)
+
+// names of the steps of cluster evacuation
+const (
+	stepClusterTainted   = "cluster tainted"
+	stepWorkloadsEvicted = "workloads evicted"
+)
+
+const (
+	defaultEvacuationTimeout = 30 * time.Minute
+	// maxEvacuationTimeout bounds the timeout requested for an evacuation, longer ones are clamped to it.
+	maxEvacuationTimeout = 24 * time.Hour
+	// maxProgressFailures is how many times in a row getting the evacuation progress may fail before the
+	// evacuation gives up.
+	maxProgressFailures = 5
+)
+
+var (
+	// untaintTimeout bounds removing the evacuation taint once the evacuation was cancelled.
+	untaintTimeout = 30 * time.Second
+)
+
+// handleEvacuateCluster previews the impact of evacuating a cluster, or starts the evacuation once it's confirmed.
+// Evacuation taints the cluster with a NoExecute taint and waits until karmada evicted every binding which does
+// not tolerate it, cancelling the operation removes the taint again.
+func handleEvacuateCluster(c *gin.Context) {
+	name := c.Param("name")
+	evacuateRequest := new(v1.EvacuateClusterRequest)
+	// the body is optional, the impact is previewed without it
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(evacuateRequest); err != nil {
+			klog.ErrorS(err, "Could not read evacuate cluster request")
+			common.Fail(c, err)
+			return
+		}
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if !evacuateRequest.Confirm {
+		preview, err := cluster.PreviewEvacuation(karmadaClient, name)
+		if err != nil {
+			klog.ErrorS(err, "Preview evacuation failed", "cluster", name)
+			common.Fail(c, err)
+			return
+		}
+		common.Success(c, preview)
+		return
+	}
+
+	evacuationTimeout := defaultEvacuationTimeout
+	if evacuateRequest.TimeoutSeconds > 0 {
+		evacuationTimeout = maxEvacuationTimeout
+		if evacuateRequest.TimeoutSeconds < int64(maxEvacuationTimeout/time.Second) {
+			evacuationTimeout = time.Duration(evacuateRequest.TimeoutSeconds) * time.Second
+		}
+	}
+	user, err := identity.Verify(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to verify user")
+		common.Fail(c, err)
+		return
+	}
+	op := operation.Start(operation.TypeClusterEvacuate, name, user,
+		[]string{stepClusterTainted, stepWorkloadsEvicted},
+		func(ctx context.Context, recorder *operation.Recorder) error {
+			return evacuateCluster(ctx, recorder, karmadaClient, name, evacuationTimeout)
+		})
+	common.Success(c, op)
+}
+
+// handleDeleteClusterEvacuation removes the evacuation taint of a cluster, e.g. after the evacuation finished or
+// its operation was interrupted. Workloads which were evicted are not moved back.
+func handleDeleteClusterEvacuation(c *gin.Context) {
+	name := c.Param("name")
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = cluster.RemoveEvacuationTaint(context.TODO(), karmadaClient, name); err != nil {
+		klog.ErrorS(err, "Remove evacuation taint failed", "cluster", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+func evacuateCluster(ctx context.Context, recorder *operation.Recorder, karmadaClient karmadaclientset.Interface,
+	name string, evacuationTimeout time.Duration) error {
+	if err := recorder.Step(ctx, stepClusterTainted, func(ctx context.Context) error {
+		return cluster.AddEvacuationTaint(ctx, karmadaClient, name)
+	}); err != nil {
+		return err
+	}
+
+	err := recorder.Step(ctx, stepWorkloadsEvicted, func(ctx context.Context) error {
+		failures := 0
+		return wait.PollUntilContextTimeout(ctx, pollInterval, evacuationTimeout, true, func(context.Context) (bool, error) {
+			progress, err := cluster.GetEvacuationProgress(karmadaClient, name)
+			if err != nil {
+				failures++
+				if failures >= maxProgressFailures {
+					return false, fmt.Errorf("failed to get evacuation progress %d times in a row: %w", failures, err)
+				}
+				klog.ErrorS(err, "Get evacuation progress failed, retrying", "cluster", name, "failures", failures)
+				return false, nil
+			}
+			failures = 0
+			recorder.Progress(stepWorkloadsEvicted, progress.String())
+			return progress.Done(), nil
+		})
+	})
+	if ctx.Err() == context.Canceled {
+		// the operation was cancelled, its context can not be used anymore
+		untaintCtx, cancel := context.WithTimeout(context.Background(), untaintTimeout)
+		defer cancel()
+		if untaintErr := cluster.RemoveEvacuationTaint(untaintCtx, karmadaClient, name); untaintErr != nil {
+			klog.ErrorS(untaintErr, "Remove evacuation taint of cancelled evacuation failed", "cluster", name)
+		}
+	}
+	return err
+}
@@ -19,25 +19,32 @@ package cluster
 import (
 	"context"
 	"fmt"
-	"time"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/operation"
 	"github.com/karmada-io/dashboard/pkg/resource/cluster"
 )
 
 func handleGetClusterList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := cluster.GetClusterList(karmadaClient, dataSelect)
 	if err != nil {
@@ -49,7 +56,11 @@ func handleGetClusterList(c *gin.Context) {
 }
 
 func handleGetClusterDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("name")
 	result, err := cluster.GetClusterDetail(karmadaClient, name)
 	if err != nil {
@@ -67,73 +78,125 @@ func handlePostCluster(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
-	memberClusterEndpoint, err := parseEndpointFromKubeconfig(clusterRequest.MemberClusterKubeConfig)
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
 	if err != nil {
-		klog.ErrorS(err, "Could not parse member cluster endpoint")
 		common.Fail(c, err)
 		return
 	}
+	user, err := identity.Verify(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to verify user")
+		common.Fail(c, err)
+		return
+	}
+	op, err := startJoin(c.Request, karmadaClient, user, clusterRequest, nil)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, op)
+}
+
+// startJoin starts the operation of user joining the cluster of clusterRequest. If slots is not nil the operation waits
+// for a free slot before joining, so that the number of joins running at the same time is bounded by its capacity.
+func startJoin(request *http.Request, karmadaClient karmadaclientset.Interface, user string, clusterRequest *v1.PostClusterRequest,
+	slots cluster.Slots) (*operation.Operation, error) {
+	memberClusterEndpoint, err := parseEndpointFromKubeconfig(clusterRequest.MemberClusterKubeConfig)
+	if err != nil {
+		klog.ErrorS(err, "Could not parse member cluster endpoint")
+		return nil, err
+	}
 	clusterRequest.MemberClusterEndpoint = memberClusterEndpoint
-	karmadaClient := client.InClusterKarmadaClient()
 
-	if clusterRequest.SyncMode == v1alpha1.Pull {
+	var steps []string
+	var join func(ctx context.Context, recorder *operation.Recorder) error
+	switch clusterRequest.SyncMode {
+	case v1alpha1.Pull:
 		memberClusterClient, err := client.KubeClientSetFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
 		if err != nil {
 			klog.ErrorS(err, "Generate kubeclient from memberClusterKubeconfig failed")
-			common.Fail(c, err)
-			return
+			return nil, err
 		}
-		_, apiConfig, err := client.GetKarmadaConfig()
+		karmadaKubeClient, err := client.GetKarmadaKubeClientFromRequest(request)
 		if err != nil {
-			klog.ErrorS(err, "Get apiConfig for karmada failed")
-			common.Fail(c, err)
-			return
+			return nil, err
+		}
+		// only the address and ca of karmada apiserver are taken from the config of dashboard, karmada-agent is
+		// issued credentials of its own
+		restConfig, _, err := client.GetKarmadaConfig()
+		if err != nil {
+			klog.ErrorS(err, "Get restConfig for karmada failed")
+			return nil, err
+		}
+		karmadaAgent, err := newKarmadaAgentOption(clusterRequest)
+		if err != nil {
+			klog.ErrorS(err, "Invalid karmada-agent spec")
+			return nil, err
+		}
+		memberClusterNamespace := clusterRequest.MemberClusterNamespace
+		if len(memberClusterNamespace) == 0 {
+			memberClusterNamespace = DefaultMemberClusterNamespace
 		}
 		opts := &pullModeOption{
 			karmadaClient:          karmadaClient,
-			karmadaAgentCfg:        apiConfig,
-			memberClusterNamespace: clusterRequest.MemberClusterNamespace,
+			karmadaKubeClient:      karmadaKubeClient,
+			karmadaServerConfig:    rest.AnonymousClientConfig(restConfig),
+			memberClusterNamespace: memberClusterNamespace,
 			memberClusterClient:    memberClusterClient,
 			memberClusterName:      clusterRequest.MemberClusterName,
 			memberClusterEndpoint:  clusterRequest.MemberClusterEndpoint,
+			memberClusterLabels:    clusterRequest.ClusterLabels,
+			karmadaAgent:           karmadaAgent,
 		}
-		if err = accessClusterInPullMode(opts); err != nil {
-			klog.ErrorS(err, "accessClusterInPullMode failed")
-			common.Fail(c, err)
-		} else {
-			klog.Infof("accessClusterInPullMode success")
-			common.Success(c, "ok")
+		steps = pullModeSteps
+		join = func(ctx context.Context, recorder *operation.Recorder) error {
+			return accessClusterInPullMode(ctx, recorder, opts)
 		}
-	} else if clusterRequest.SyncMode == v1alpha1.Push {
+	case v1alpha1.Push:
 		memberClusterRestConfig, err := client.LoadRestConfigFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
 		if err != nil {
 			klog.ErrorS(err, "Generate rest config from memberClusterKubeconfig failed")
-			common.Fail(c, err)
-			return
+			return nil, err
 		}
-		restConfig, _, err := client.GetKarmadaConfig()
+		restConfig, err := client.GetKarmadaConfigFromRequest(request)
 		if err != nil {
 			klog.ErrorS(err, "Get restConfig failed")
-			common.Fail(c, err)
-			return
+			return nil, err
 		}
 		opts := &pushModeOption{
 			karmadaClient:           karmadaClient,
 			clusterName:             clusterRequest.MemberClusterName,
+			clusterProvider:         clusterRequest.ClusterProvider,
+			clusterRegion:           clusterRequest.ClusterRegion,
+			clusterZones:            clusterRequest.ClusterZones,
+			clusterLabels:           clusterRequest.ClusterLabels,
 			karmadaRestConfig:       restConfig,
 			memberClusterRestConfig: memberClusterRestConfig,
 		}
-		if err := accessClusterInPushMode(opts); err != nil {
-			klog.ErrorS(err, "accessClusterInPushMode failed")
-			common.Fail(c, err)
-			return
+		steps = pushModeSteps
+		join = func(ctx context.Context, recorder *operation.Recorder) error {
+			return accessClusterInPushMode(ctx, recorder, opts)
 		}
-		klog.Infof("accessClusterInPushMode success")
-		common.Success(c, "ok")
-	} else {
+	default:
 		klog.Errorf("Unknown sync mode %s", clusterRequest.SyncMode)
-		common.Fail(c, fmt.Errorf("unknown sync mode %s", clusterRequest.SyncMode))
+		return nil, fmt.Errorf("unknown sync mode %s", clusterRequest.SyncMode)
+	}
+
+	if err = checkClusterNotExist(karmadaClient, clusterRequest.MemberClusterName); err != nil {
+		klog.ErrorS(err, "Check cluster failed", "cluster", clusterRequest.MemberClusterName)
+		return nil, err
 	}
+	return operation.Start(operation.TypeClusterJoin, clusterRequest.MemberClusterName, user, steps,
+		func(ctx context.Context, recorder *operation.Recorder) error {
+			if slots != nil {
+				release, err := slots.Acquire(ctx)
+				if err != nil {
+					return err
+				}
+				defer release()
+			}
+			return join(ctx, recorder)
+		}), nil
 }
 
 func handlePutCluster(c *gin.Context) {
@@ -144,7 +207,11 @@ func handlePutCluster(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	memberCluster, err := karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
 		klog.ErrorS(err, "Get cluster failed")
@@ -172,6 +239,7 @@ func handlePutCluster(c *gin.Context) {
 		}
 		memberCluster.Spec.Taints = taints
 	}
+	updateClusterSpec(&memberCluster.Spec, clusterRequest)
 
 	_, err = karmadaClient.ClusterV1alpha1().Clusters().Update(context.TODO(), memberCluster, metav1.UpdateOptions{})
 	if err != nil {
@@ -183,46 +251,128 @@ func handlePutCluster(c *gin.Context) {
 }
 
 func handleDeleteCluster(c *gin.Context) {
-	ctx := context.Context(c)
 	clusterRequest := new(v1.DeleteClusterRequest)
-	if err := c.ShouldBindUri(&clusterRequest); err != nil {
+	if err := c.ShouldBindUri(clusterRequest); err != nil {
 		common.Fail(c, err)
 		return
 	}
+	// the body is optional, the cluster object is deleted only without it
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(clusterRequest); err != nil {
+			klog.ErrorS(err, "Could not read delete cluster request")
+			common.Fail(c, err)
+			return
+		}
+	}
 	clusterName := clusterRequest.MemberClusterName
-	karmadaClient := client.InClusterKarmadaClient()
-	waitDuration := time.Second * 60
-
-	err := karmadaClient.ClusterV1alpha1().Clusters().Delete(ctx, clusterName, metav1.DeleteOptions{})
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaKubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	memberCluster, err := karmadaClient.ClusterV1alpha1().Clusters().Get(c, clusterName, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		common.Fail(c, fmt.Errorf("no cluster object %s found in karmada control Plane", clusterName))
 		return
 	}
 	if err != nil {
-		klog.Errorf("Failed to delete cluster object. cluster name: %s, error: %v", clusterName, err)
+		klog.ErrorS(err, "Get cluster failed", "cluster", clusterName)
 		common.Fail(c, err)
 		return
 	}
 
-	// make sure the given cluster object has been deleted
-	err = wait.PollUntilContextTimeout(ctx, 1*time.Second, waitDuration, true, func(ctx context.Context) (done bool, err error) {
-		_, err = karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			return true, nil
-		}
+	opts := &unjoinOption{
+		karmadaClient:          karmadaClient,
+		karmadaKubeClient:      karmadaKubeClient,
+		clusterName:            clusterName,
+		syncMode:               memberCluster.Spec.SyncMode,
+		memberClusterNamespace: clusterRequest.MemberClusterNamespace,
+		force:                  clusterRequest.Force,
+	}
+	if len(opts.memberClusterNamespace) == 0 {
+		opts.memberClusterNamespace = DefaultMemberClusterNamespace
+	}
+	if len(clusterRequest.MemberClusterKubeConfig) > 0 {
+		memberClusterClient, err := client.KubeClientSetFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
 		if err != nil {
-			klog.Errorf("Failed to get cluster %s. err: %v", clusterName, err)
-			return false, err
+			klog.ErrorS(err, "Generate kubeclient from memberClusterKubeconfig failed")
+			common.Fail(c, err)
+			return
 		}
-		klog.Infof("Waiting for the cluster object %s to be deleted", clusterName)
-		return false, nil
-	})
+		if err = verifyMemberCluster(memberClusterClient, memberCluster); err != nil {
+			klog.ErrorS(err, "Member cluster does not match the cluster to unjoin", "cluster", clusterName)
+			common.Fail(c, err)
+			return
+		}
+		opts.memberClusterClient = memberClusterClient
+	}
+
+	user, err := identity.Verify(c.Request)
 	if err != nil {
-		klog.Errorf("Failed to delete cluster object. cluster name: %s, error: %v", clusterName, err)
+		klog.ErrorS(err, "Failed to verify user")
 		common.Fail(c, err)
 		return
 	}
-	common.Success(c, "ok")
+	op := operation.Start(operation.TypeClusterUnjoin, clusterName, user, opts.steps(),
+		func(ctx context.Context, recorder *operation.Recorder) error {
+			return unjoinCluster(ctx, recorder, opts)
+		})
+	common.Success(c, op)
+}
+
+// updateClusterSpec applies the spec fields set in the request, the cluster is validated by karmada apiserver.
+func updateClusterSpec(spec *v1alpha1.ClusterSpec, request *v1.PutClusterRequest) {
+	if request.ID != nil {
+		spec.ID = *request.ID
+	}
+	if request.Provider != nil {
+		spec.Provider = *request.Provider
+	}
+	if request.Region != nil {
+		spec.Region = *request.Region
+	}
+	if request.Zones != nil {
+		spec.Zones = *request.Zones
+		// Zone is deprecated in favor of Zones, it must not contradict them
+		spec.Zone = ""
+	}
+	if request.ProxyURL != nil {
+		spec.ProxyURL = *request.ProxyURL
+	}
+	if request.ProxyHeader != nil {
+		spec.ProxyHeader = *request.ProxyHeader
+	}
+	if request.SecretRef != nil {
+		spec.SecretRef = request.SecretRef
+	}
+	if request.ImpersonatorSecretRef != nil {
+		spec.ImpersonatorSecretRef = request.ImpersonatorSecretRef
+	}
+	if request.ResourceModels != nil {
+		spec.ResourceModels = *request.ResourceModels
+	}
+}
+
+func handleGetClusterAPIEnablements(c *gin.Context) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	name := c.Param("name")
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	result, err := cluster.GetClusterAPIEnablements(karmadaClient, name, dataSelect)
+	if err != nil {
+		klog.ErrorS(err, "GetClusterAPIEnablements failed")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
 }
 
 func parseEndpointFromKubeconfig(kubeconfigContents string) (string, error) {
@@ -238,6 +388,14 @@ func init() {
 	r.GET("/cluster", handleGetClusterList)
 	r.GET("/cluster/:name", handleGetClusterDetail)
 	r.POST("/cluster", handlePostCluster)
+	r.POST("/cluster/batch", handlePostClusterBatch)
+	r.POST("/cluster/preflight", handlePreflightCluster)
 	r.PUT("/cluster/:name", handlePutCluster)
 	r.DELETE("/cluster/:name", handleDeleteCluster)
+	r.POST("/cluster/:name/evacuate", handleEvacuateCluster)
+	r.DELETE("/cluster/:name/evacuate", handleDeleteClusterEvacuation)
+	r.GET("/cluster/:name/apienablements", handleGetClusterAPIEnablements)
+	r.GET("/cluster/:name/history", handleGetClusterHistory)
+	r.GET("/cluster/:name/kubeconfig", handleGetClusterKubeconfig)
+	r.GET("/kubeconfig", handleGetKubeconfig)
 }
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/clusterhistory"
+)
+
+func handleGetClusterHistory(c *gin.Context) {
+	name := c.Param("name")
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get karmada client")
+		common.Fail(c, err)
+		return
+	}
+	// the history is read with dashboard's own store, make sure the user may see the cluster
+	if _, err = karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+		klog.ErrorS(err, "Get cluster failed", "cluster", name)
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusterhistory.Get(name)
+	if err != nil {
+		klog.ErrorS(err, "Get cluster history failed", "cluster", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
+)
+
+const (
+	// minKubeconfigTokenExpiration is the shortest lifetime of a token minted for a kubeconfig, it's the minimum
+	// accepted by the TokenRequest API.
+	minKubeconfigTokenExpiration = 10 * time.Minute
+	// maxKubeconfigTokenExpiration is the longest lifetime of a token minted for a kubeconfig.
+	maxKubeconfigTokenExpiration = 24 * time.Hour
+)
+
+// handleGetClusterKubeconfig serves a kubeconfig reaching the member cluster through the cluster proxy of karmada
+// apiserver, it carries the credentials of the caller unless a short-lived token is asked for with expirationSeconds.
+// The karmada apiserver address in the kubeconfig can be overridden with server, e.g. when dashboard connects to
+// karmada apiserver with an in-cluster address.
+func handleGetClusterKubeconfig(c *gin.Context) {
+	name := c.Param("name")
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get karmada client")
+		common.Fail(c, err)
+		return
+	}
+	if _, err = karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+		klog.ErrorS(err, "Get cluster failed", "cluster", name)
+		common.Fail(c, err)
+		return
+	}
+	writeProxyKubeconfig(c, fmt.Sprintf("%s-kubeconfig.yaml", name), []string{name})
+}
+
+// handleGetKubeconfig serves a kubeconfig with a context for every cluster the caller may reach through the
+// cluster proxy of karmada apiserver, it accepts the same parameters as handleGetClusterKubeconfig.
+func handleGetKubeconfig(c *gin.Context) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get karmada client")
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get karmada kube client")
+		common.Fail(c, err)
+		return
+	}
+	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		klog.ErrorS(err, "List clusters failed")
+		common.Fail(c, err)
+		return
+	}
+	clusters := make([]string, 0, len(clusterList.Items))
+	for _, cluster := range clusterList.Items {
+		allowed, err := permission.CanAccess(kubeClient, &authorizationv1.ResourceAttributes{
+			Verb:        "get",
+			Group:       v1alpha1.GroupName,
+			Resource:    "clusters",
+			Subresource: "proxy",
+			Name:        cluster.Name,
+		})
+		if err != nil {
+			klog.ErrorS(err, "Failed to review access to cluster proxy", "cluster", cluster.Name)
+			common.Fail(c, err)
+			return
+		}
+		if allowed {
+			clusters = append(clusters, cluster.Name)
+		}
+	}
+	if len(clusters) == 0 {
+		common.Fail(c, errors.NewForbidden("clusters/proxy", fmt.Errorf("no member cluster may be accessed through the cluster proxy")))
+		return
+	}
+	writeProxyKubeconfig(c, "karmada-clusters-kubeconfig.yaml", clusters)
+}
+
+func writeProxyKubeconfig(c *gin.Context, filename string, clusters []string) {
+	authInfo, err := kubeconfigAuthInfo(c)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get credentials for kubeconfig")
+		common.Fail(c, err)
+		return
+	}
+	config, err := client.ProxyKubeconfig(clusters, c.Query("server"), authInfo)
+	if err != nil {
+		klog.ErrorS(err, "Failed to generate kubeconfig")
+		common.Fail(c, err)
+		return
+	}
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		klog.ErrorS(err, "Failed to serialize kubeconfig")
+		common.Fail(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/yaml", data)
+}
+
+// kubeconfigAuthInfo returns the credentials put into the kubeconfig, a token is minted when expirationSeconds
+// is set, otherwise the credentials the caller signed in with are used.
+func kubeconfigAuthInfo(c *gin.Context) (*clientcmdapi.AuthInfo, error) {
+	expirationSeconds := c.Query("expirationSeconds")
+	if len(expirationSeconds) == 0 {
+		return client.CallerAuthInfo(c.Request)
+	}
+	seconds, err := strconv.ParseInt(expirationSeconds, 10, 64)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid expirationSeconds %q", expirationSeconds))
+	}
+	expiration := time.Duration(seconds) * time.Second
+	if expiration < minKubeconfigTokenExpiration || expiration > maxKubeconfigTokenExpiration {
+		return nil, errors.NewBadRequest(fmt.Sprintf("expirationSeconds must be between %d and %d",
+			int64(minKubeconfigTokenExpiration.Seconds()), int64(maxKubeconfigTokenExpiration.Seconds())))
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	token, err := mintToken(kubeClient, seconds)
+	if err != nil {
+		return nil, err
+	}
+	return &clientcmdapi.AuthInfo{Token: token}, nil
+}
+
+// mintToken issues a token of the caller with the TokenRequest API. Only ServiceAccounts can be issued tokens,
+// the request is sent with the caller's own credentials so it must be allowed to create serviceaccounts/token
+// of itself.
+func mintToken(kubeClient kubeclient.Interface, expirationSeconds int64) (string, error) {
+	user, err := permission.ReviewSelf(kubeClient)
+	if err != nil {
+		return "", err
+	}
+	namespace, name, err := serviceaccount.SplitUsername(user.Username)
+	if err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("short-lived tokens can only be minted for ServiceAccounts, you are signed in as %q", user.Username))
+	}
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(namespace).CreateToken(context.TODO(), name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	return tokenRequest.Status.Token, nil
+}
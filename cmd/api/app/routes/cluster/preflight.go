@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/version"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/routes/overview"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
+)
+
+// names of the preflight checks
+const (
+	checkClusterName       = "cluster name"
+	checkReachability      = "member cluster reachable"
+	checkKubernetesVersion = "kubernetes version"
+	checkClusterIdentity   = "cluster identity"
+	checkPermissions       = "permissions"
+	checkNamespace         = "namespace"
+)
+
+const (
+	// preflightTimeout bounds every request sent to member cluster during preflight.
+	preflightTimeout = 10 * time.Second
+	// minMemberKubernetesVersion is the oldest kubernetes version karmada supports as a member cluster.
+	minMemberKubernetesVersion = "v1.16.0"
+)
+
+// requiredAccess is what the identity in member kubeconfig needs to set up karmada-agent or the impersonator.
+// Both modes grant karmada all permissions in member cluster, hence escalate and bind on clusterroles.
+var requiredAccess = map[v1alpha1.ClusterSyncMode][]authorizationv1.ResourceAttributes{
+	v1alpha1.Push: {
+		{Verb: "create", Resource: "namespaces"},
+		{Verb: "create", Resource: "serviceaccounts", Namespace: ClusterNamespace},
+		{Verb: "get", Resource: "secrets", Namespace: ClusterNamespace},
+		{Verb: "create", Resource: "secrets", Namespace: ClusterNamespace},
+		{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+		{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+		{Verb: "escalate", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+		{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	},
+	v1alpha1.Pull: {
+		{Verb: "create", Resource: "namespaces"},
+		{Verb: "create", Resource: "serviceaccounts"},
+		{Verb: "create", Resource: "secrets"},
+		{Verb: "update", Resource: "secrets"},
+		{Verb: "create", Group: "apps", Resource: "deployments"},
+		{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+		{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+		{Verb: "escalate", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+		{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	},
+}
+
+func handlePreflightCluster(c *gin.Context) {
+	clusterRequest := new(v1.PostClusterRequest)
+	if err := c.ShouldBind(clusterRequest); err != nil {
+		klog.ErrorS(err, "Could not read cluster preflight request")
+		common.Fail(c, err)
+		return
+	}
+	if clusterRequest.SyncMode != v1alpha1.Push && clusterRequest.SyncMode != v1alpha1.Pull {
+		common.Fail(c, fmt.Errorf("unknown sync mode %s", clusterRequest.SyncMode))
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaKubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, preflight(c, karmadaClient, karmadaKubeClient, clusterRequest))
+}
+
+// preflight checks whether the cluster of request can be joined, the checks which depend on member cluster are
+// skipped once it's unreachable.
+func preflight(ctx context.Context, karmadaClient karmadaclientset.Interface, karmadaKubeClient kubeclient.Interface,
+	request *v1.PostClusterRequest) *v1.PreflightResponse {
+	checks := []v1.PreflightCheck{checkName(ctx, karmadaClient, request.MemberClusterName)}
+
+	memberClusterClient, reachability := connectMemberCluster(request)
+	checks = append(checks, reachability)
+	if memberClusterClient != nil {
+		checks = append(checks, checkVersion(karmadaKubeClient, memberClusterClient))
+		if request.SyncMode == v1alpha1.Push {
+			checks = append(checks, checkIdentity(karmadaClient, memberClusterClient))
+		}
+		checks = append(checks, checkAccess(memberClusterClient, request.SyncMode))
+		checks = append(checks, checkMemberNamespace(ctx, memberClusterClient, request))
+	}
+
+	response := &v1.PreflightResponse{Passed: true, Checks: checks}
+	for _, check := range checks {
+		if check.Status == v1.PreflightCheckFail {
+			response.Passed = false
+		}
+	}
+	return response
+}
+
+func pass(name, message string) v1.PreflightCheck {
+	return v1.PreflightCheck{Name: name, Status: v1.PreflightCheckPass, Message: message}
+}
+
+func warn(name, message, remediation string) v1.PreflightCheck {
+	return v1.PreflightCheck{Name: name, Status: v1.PreflightCheckWarn, Message: message, Remediation: remediation}
+}
+
+func fail(name, message, remediation string) v1.PreflightCheck {
+	return v1.PreflightCheck{Name: name, Status: v1.PreflightCheckFail, Message: message, Remediation: remediation}
+}
+
+func checkName(ctx context.Context, karmadaClient karmadaclientset.Interface, name string) v1.PreflightCheck {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fail(checkClusterName, fmt.Sprintf("invalid cluster name %q: %s", name, strings.Join(errs, "; ")),
+			"Use a name of at most 63 lowercase alphanumeric characters or '-'.")
+	}
+	_, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return fail(checkClusterName, fmt.Sprintf("cluster %s already exists", name),
+			"Choose another name, or unjoin the existing cluster first.")
+	}
+	if !apierrors.IsNotFound(err) {
+		return warn(checkClusterName, fmt.Sprintf("could not check existing clusters: %v", err),
+			"Make sure you are allowed to get clusters in karmada control plane.")
+	}
+	return pass(checkClusterName, fmt.Sprintf("no cluster named %s exists", name))
+}
+
+// connectMemberCluster checks whether the apiserver of member cluster is reachable with the kubeconfig, it's
+// reached from karmada-dashboard which runs alongside karmada control plane, so that it's the same network path
+// the control plane uses in push mode.
+func connectMemberCluster(request *v1.PostClusterRequest) (kubeclient.Interface, v1.PreflightCheck) {
+	restConfig, err := client.LoadRestConfigFromKubeConfig(request.MemberClusterKubeConfig)
+	if err != nil {
+		return nil, fail(checkReachability, fmt.Sprintf("invalid kubeconfig: %v", err),
+			"Provide a kubeconfig with the server and credentials of member cluster embedded.")
+	}
+	restConfig.Timeout = preflightTimeout
+	memberClusterClient, err := kubeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fail(checkReachability, fmt.Sprintf("invalid kubeconfig: %v", err),
+			"Provide a kubeconfig with the server and credentials of member cluster embedded.")
+	}
+	if _, err = memberClusterClient.Discovery().ServerVersion(); err != nil {
+		remediation := "Make sure the server in kubeconfig is reachable from karmada control plane and its certificate is trusted."
+		if request.SyncMode == v1alpha1.Pull {
+			remediation = "Make sure the server in kubeconfig is reachable from karmada-dashboard, karmada-agent only needs to reach karmada control plane from member cluster once it's deployed."
+		}
+		return nil, fail(checkReachability, fmt.Sprintf("could not reach %s: %v", restConfig.Host, err), remediation)
+	}
+	message := fmt.Sprintf("%s is reachable", restConfig.Host)
+	if request.SyncMode == v1alpha1.Pull {
+		return memberClusterClient, warn(checkReachability, message,
+			"In pull mode karmada-agent must be able to reach karmada apiserver from member cluster, which can not be checked in advance.")
+	}
+	return memberClusterClient, pass(checkReachability, message)
+}
+
+func checkVersion(karmadaKubeClient, memberClusterClient kubeclient.Interface) v1.PreflightCheck {
+	memberInfo, err := memberClusterClient.Discovery().ServerVersion()
+	if err != nil {
+		return warn(checkKubernetesVersion, fmt.Sprintf("could not get kubernetes version of member cluster: %v", err), "")
+	}
+	memberVersion, err := version.ParseGeneric(memberInfo.GitVersion)
+	if err != nil {
+		return warn(checkKubernetesVersion, fmt.Sprintf("could not parse kubernetes version %s: %v", memberInfo.GitVersion, err), "")
+	}
+	if memberVersion.LessThan(version.MustParseGeneric(minMemberKubernetesVersion)) {
+		return fail(checkKubernetesVersion,
+			fmt.Sprintf("kubernetes %s of member cluster is older than %s", memberInfo.GitVersion, minMemberKubernetesVersion),
+			"Upgrade member cluster before joining it.")
+	}
+
+	karmadaVersion := "unknown"
+	if versionInfo, err := overview.GetControllerManagerVersionInfo(); err == nil && len(versionInfo.GitVersion) > 0 {
+		karmadaVersion = versionInfo.GitVersion
+	}
+	controlPlaneInfo, err := karmadaKubeClient.Discovery().ServerVersion()
+	if err != nil {
+		return warn(checkKubernetesVersion, fmt.Sprintf("could not get version of karmada apiserver: %v", err), "")
+	}
+	controlPlaneVersion, err := version.ParseGeneric(controlPlaneInfo.GitVersion)
+	if err == nil && memberVersion.Major() == controlPlaneVersion.Major() && memberVersion.Minor() > controlPlaneVersion.Minor() {
+		return warn(checkKubernetesVersion,
+			fmt.Sprintf("kubernetes %s of member cluster is newer than %s which karmada %s is built on", memberInfo.GitVersion, controlPlaneInfo.GitVersion, karmadaVersion),
+			"APIs only served by the newer version can not be propagated, consider upgrading karmada.")
+	}
+	return pass(checkKubernetesVersion, fmt.Sprintf("kubernetes %s of member cluster is supported by karmada %s", memberInfo.GitVersion, karmadaVersion))
+}
+
+// checkIdentity checks whether member cluster has been joined with another name, push mode only.
+func checkIdentity(karmadaClient karmadaclientset.Interface, memberClusterClient kubeclient.Interface) v1.PreflightCheck {
+	id, err := karmadautil.ObtainClusterID(memberClusterClient)
+	if err != nil {
+		return warn(checkClusterIdentity, fmt.Sprintf("could not obtain the id of member cluster: %v", err),
+			"Make sure the identity in kubeconfig can get the kube-system namespace.")
+	}
+	unique, name, err := karmadautil.IsClusterIdentifyUnique(karmadaClient, id)
+	if err != nil {
+		return warn(checkClusterIdentity, fmt.Sprintf("could not check existing clusters: %v", err), "")
+	}
+	if !unique {
+		return fail(checkClusterIdentity, fmt.Sprintf("member cluster has been joined as %s", name),
+			fmt.Sprintf("Unjoin cluster %s first if you want to join it again.", name))
+	}
+	return pass(checkClusterIdentity, "member cluster has not been joined")
+}
+
+func checkAccess(memberClusterClient kubeclient.Interface, syncMode v1alpha1.ClusterSyncMode) v1.PreflightCheck {
+	denied := make([]string, 0)
+	for _, attributes := range requiredAccess[syncMode] {
+		allowed, err := permission.CanAccess(memberClusterClient, &attributes)
+		if err != nil {
+			return warn(checkPermissions, fmt.Sprintf("could not review access: %v", err), "")
+		}
+		if !allowed {
+			denied = append(denied, describeAccess(attributes))
+		}
+	}
+	if len(denied) > 0 {
+		return fail(checkPermissions, fmt.Sprintf("the identity in kubeconfig is not allowed to %s", strings.Join(denied, ", ")),
+			"Use a kubeconfig bound to cluster-admin of member cluster.")
+	}
+	return pass(checkPermissions, "the identity in kubeconfig has the required permissions")
+}
+
+func describeAccess(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if len(attributes.Group) > 0 {
+		resource = resource + "." + attributes.Group
+	}
+	if len(attributes.Namespace) > 0 {
+		return fmt.Sprintf("%s %s in namespace %s", attributes.Verb, resource, attributes.Namespace)
+	}
+	return fmt.Sprintf("%s %s", attributes.Verb, resource)
+}
+
+func checkMemberNamespace(ctx context.Context, memberClusterClient kubeclient.Interface, request *v1.PostClusterRequest) v1.PreflightCheck {
+	namespace := ClusterNamespace
+	if request.SyncMode == v1alpha1.Pull {
+		namespace = request.MemberClusterNamespace
+		if len(namespace) == 0 {
+			namespace = DefaultMemberClusterNamespace
+		}
+	}
+	_, err := memberClusterClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return pass(checkNamespace, fmt.Sprintf("namespace %s does not exist and will be created", namespace))
+	}
+	if err != nil {
+		return warn(checkNamespace, fmt.Sprintf("could not get namespace %s: %v", namespace, err), "")
+	}
+	if request.SyncMode == v1alpha1.Pull {
+		_, err = memberClusterClient.AppsV1().Deployments(namespace).Get(ctx, KarmadaAgentName, metav1.GetOptions{})
+		if err == nil {
+			return fail(checkNamespace, fmt.Sprintf("%s already exists in namespace %s", KarmadaAgentName, namespace),
+				"Member cluster may have been registered to a karmada control plane, unregister it or use another namespace.")
+		}
+	}
+	return pass(checkNamespace, fmt.Sprintf("namespace %s exists", namespace))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	cmdutil "github.com/karmada-io/karmada/pkg/karmadactl/util"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/operation"
+)
+
+// names of the steps of unjoining a cluster
+const (
+	stepClusterDeleted         = "cluster deleted"
+	stepAgentDeleted           = "agent deployment deleted"
+	stepRBACDeleted            = "RBAC deleted"
+	stepSecretsDeleted         = "secrets deleted"
+	stepServiceAccountsDeleted = "service accounts deleted"
+	stepNamespaceDeleted       = "namespace deleted"
+)
+
+type unjoinOption struct {
+	karmadaClient          karmadaclientset.Interface
+	karmadaKubeClient      kubeclient.Interface
+	clusterName            string
+	syncMode               clusterv1alpha1.ClusterSyncMode
+	memberClusterClient    kubeclient.Interface
+	memberClusterNamespace string
+	// force removes the finalizers of works, execution space and cluster if the cluster is not deleted in time,
+	// and goes on cleaning up member cluster even though some of the steps failed.
+	force bool
+}
+
+// steps returns the steps of unjoining, member cluster is only cleaned up if its client is given.
+func (o *unjoinOption) steps() []string {
+	steps := []string{stepClusterDeleted}
+	if o.memberClusterClient == nil {
+		return steps
+	}
+	if o.syncMode == clusterv1alpha1.Pull {
+		return append(steps, stepAgentDeleted, stepRBACDeleted, stepSecretsDeleted)
+	}
+	return append(steps, stepRBACDeleted, stepServiceAccountsDeleted, stepNamespaceDeleted)
+}
+
+// unjoinCluster deletes the cluster object and waits until the works in its execution space are cleaned up,
+// then removes what joining the cluster created in member cluster.
+func unjoinCluster(ctx context.Context, recorder *operation.Recorder, opts *unjoinOption) error {
+	err := recorder.Step(ctx, stepClusterDeleted, func(_ context.Context) error {
+		err := cmdutil.DeleteClusterObject(opts.karmadaKubeClient, opts.karmadaClient, opts.clusterName, timeout, false, opts.force)
+		if err != nil || opts.syncMode != clusterv1alpha1.Pull {
+			return err
+		}
+		// revoke what the certificate issued to karmada-agent is allowed to do in karmada control plane
+		return register.GenerateRBACResources(opts.clusterName, ClusterNamespace).Delete(opts.karmadaKubeClient)
+	})
+	if err != nil {
+		return err
+	}
+	if opts.memberClusterClient == nil {
+		return nil
+	}
+
+	var cleanups []cleanupStep
+	if opts.syncMode == clusterv1alpha1.Pull {
+		cleanups = opts.pullModeCleanups()
+	} else {
+		cleanups = opts.pushModeCleanups()
+	}
+	for _, cleanup := range cleanups {
+		if err = recorder.Step(ctx, cleanup.name, cleanup.fn); err != nil {
+			if !opts.force {
+				return err
+			}
+			klog.ErrorS(err, "Force deletion, continue cleaning up member cluster", "cluster", opts.clusterName)
+		}
+	}
+	return nil
+}
+
+// verifyMemberCluster checks that memberClusterClient talks to the cluster which was joined, by the uid of its
+// kube-system namespace, so that cleaning up never touches another cluster given by mistake.
+func verifyMemberCluster(memberClusterClient kubeclient.Interface, cluster *clusterv1alpha1.Cluster) error {
+	id, err := karmadautil.ObtainClusterID(memberClusterClient)
+	if err != nil {
+		return fmt.Errorf("could not obtain the id of member cluster: %w", err)
+	}
+	if id != cluster.Spec.ID {
+		return errors.NewBadRequest(fmt.Sprintf("the kubeconfig of member cluster points to cluster %s, not to cluster %s with id %q",
+			id, cluster.Name, cluster.Spec.ID))
+	}
+	return nil
+}
+
+type cleanupStep struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// pullModeCleanups removes karmada-agent and the secrets and RBAC created for it.
+func (o *unjoinOption) pullModeCleanups() []cleanupStep {
+	client := o.memberClusterClient
+	namespace := o.memberClusterNamespace
+	return []cleanupStep{
+		{name: stepAgentDeleted, fn: func(ctx context.Context) error {
+			err := client.AppsV1().Deployments(namespace).Delete(ctx, KarmadaAgentName, metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}},
+		{name: stepRBACDeleted, fn: func(_ context.Context) error {
+			if err := karmadautil.DeleteClusterRoleBinding(client, KarmadaAgentName); err != nil {
+				return err
+			}
+			if err := karmadautil.DeleteClusterRole(client, KarmadaAgentName); err != nil {
+				return err
+			}
+			return karmadautil.DeleteServiceAccount(client, namespace, KarmadaAgentServiceAccountName)
+		}},
+		{name: stepSecretsDeleted, fn: func(_ context.Context) error {
+			if err := karmadautil.DeleteSecret(client, namespace, KarmadaKubeconfigName); err != nil {
+				return err
+			}
+			return karmadautil.DeleteSecret(client, namespace, KarmadaAgentImagePullSecretName)
+		}},
+	}
+}
+
+// pushModeCleanups removes the service accounts, RBAC and namespace created for karmada control plane to access
+// member cluster, the same as `karmadactl unjoin` does.
+func (o *unjoinOption) pushModeCleanups() []cleanupStep {
+	client := o.memberClusterClient
+	serviceAccountName := names.GenerateServiceAccountName(o.clusterName)
+	clusterRoleName := names.GenerateRoleName(serviceAccountName)
+	return []cleanupStep{
+		{name: stepRBACDeleted, fn: func(_ context.Context) error {
+			if err := karmadautil.DeleteClusterRoleBinding(client, clusterRoleName); err != nil {
+				return err
+			}
+			return karmadautil.DeleteClusterRole(client, clusterRoleName)
+		}},
+		{name: stepServiceAccountsDeleted, fn: func(_ context.Context) error {
+			if err := karmadautil.DeleteServiceAccount(client, ClusterNamespace, serviceAccountName); err != nil {
+				return err
+			}
+			return karmadautil.DeleteServiceAccount(client, ClusterNamespace, names.GenerateServiceAccountName("impersonator"))
+		}},
+		{name: stepNamespaceDeleted, fn: func(_ context.Context) error {
+			return karmadautil.DeleteNamespace(client, ClusterNamespace)
+		}},
+	}
+}
@@ -17,9 +17,8 @@ limitations under the License.
 package propagationpolicy
 
 import (
-	"context"
-
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -33,7 +32,11 @@ import (
 )
 
 func handleGetClusterOverridePolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	clusterOverrideList, err := clusteroverridepolicy.GetClusterOverridePolicyList(karmadaClient, dataSelect)
 	if err != nil {
@@ -45,7 +48,11 @@ func handleGetClusterOverridePolicyList(c *gin.Context) {
 }
 
 func handleGetClusterOverridePolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("clusterOverridePolicyName")
 	result, err := clusteroverridepolicy.GetClusterOverridePolicyDetail(karmadaClient, name)
 	if err != nil {
@@ -56,35 +63,140 @@ func handleGetClusterOverridePolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// handlePostClusterOverridePolicy creates a ClusterOverridePolicy from the typed request, the YAML request of earlier
+// releases is still accepted.
 func handlePostClusterOverridePolicy(c *gin.Context) {
-	ctx := context.Context(c)
-	overridepolicyRequest := new(v1.PostOverridePolicyRequest)
-	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
+	manifestRequest := new(v1.PostOverridePolicyRequest)
+	if err := c.ShouldBindBodyWith(manifestRequest, binding.JSON); err == nil {
+		handlePostOverridePolicyManifest(c, manifestRequest)
+		return
+	}
+	overridepolicyRequest := new(v1.PostClusterOverridePolicyRequest)
+	if err := c.ShouldBindBodyWith(overridepolicyRequest, binding.JSON); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusteroverridepolicy.CreateClusterOverridePolicy(c, karmadaClient, &v1alpha1.ClusterOverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        overridepolicyRequest.Name,
+			Labels:      overridepolicyRequest.Labels,
+			Annotations: overridepolicyRequest.Annotations,
+		},
+		Spec: overridepolicyRequest.Spec,
+	}, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to create ClusterOverridePolicy", "name", overridepolicyRequest.Name)
 		common.Fail(c, err)
 		return
 	}
+	common.Success(c, result)
+}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	if overridepolicyRequest.IsClusterScope {
+// handlePostOverridePolicyManifest creates the OverridePolicy or ClusterOverridePolicy in the YAML manifest of request.
+func handlePostOverridePolicyManifest(c *gin.Context, request *v1.PostOverridePolicyRequest) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	kind := v1alpha1.ResourceKindOverridePolicy
+	if request.IsClusterScope {
+		kind = v1alpha1.ResourceKindClusterOverridePolicy
+	}
+	warnings, err := client.ValidateManifestSchema(c.Request, []byte(request.OverrideData), v1alpha1.SchemeGroupVersion.WithKind(kind))
+	if err != nil {
+		klog.ErrorS(err, "Invalid "+kind)
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
+	dryRun := common.ParseDryRunParameter(c)
+	if request.IsClusterScope {
 		clusterOverridePolicy := v1alpha1.ClusterOverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusterOverridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
-			common.Fail(c, err)
-			return
+		if err = yaml.Unmarshal([]byte(request.OverrideData), &clusterOverridePolicy); err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(c, &clusterOverridePolicy, metav1.CreateOptions{DryRun: dryRun})
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusterOverridePolicy, metav1.CreateOptions{})
 	} else {
+		if len(request.Namespace) == 0 {
+			request.Namespace = "default"
+		}
 		overridePolicy := v1alpha1.OverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal OverridePolicy")
-			common.Fail(c, err)
-			return
+		if err = yaml.Unmarshal([]byte(request.OverrideData), &overridePolicy); err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(request.Namespace).Create(c, &overridePolicy, metav1.CreateOptions{DryRun: dryRun})
 		}
-		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, &overridePolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
-		klog.ErrorS(err, "Failed to create OverridePolicy")
+		klog.ErrorS(err, "Failed to create "+kind)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+func handlePutClusterOverridePolicy(c *gin.Context) {
+	name := c.Param("clusterOverridePolicyName")
+	overridepolicyRequest := new(v1.PutClusterOverridePolicyRequest)
+	if err := c.ShouldBindJSON(overridepolicyRequest); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusteroverridepolicy.UpdateClusterOverridePolicy(c, karmadaClient, &v1alpha1.ClusterOverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: overridepolicyRequest.ResourceVersion,
+			Labels:          overridepolicyRequest.Labels,
+			Annotations:     overridepolicyRequest.Annotations,
+		},
+		Spec: overridepolicyRequest.Spec,
+	}, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to update ClusterOverridePolicy", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handlePatchClusterOverridePolicy(c *gin.Context) {
+	name := c.Param("clusterOverridePolicyName")
+	patch, err := c.GetRawData()
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusteroverridepolicy.PatchClusterOverridePolicy(c, karmadaClient, name, patch, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to patch ClusterOverridePolicy", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleDeleteClusterOverridePolicy(c *gin.Context) {
+	name := c.Param("clusterOverridePolicyName")
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = clusteroverridepolicy.DeleteClusterOverridePolicy(c, karmadaClient, name, c.Query("resourceVersion")); err != nil {
+		klog.ErrorS(err, "Failed to delete ClusterOverridePolicy", "name", name)
 		common.Fail(c, err)
 		return
 	}
@@ -96,4 +208,7 @@ func init() {
 	r.GET("/clusteroverridepolicy", handleGetClusterOverridePolicyList)
 	r.GET("/clusteroverridepolicy/:clusterOverridePolicyName", handleGetClusterOverridePolicyDetail)
 	r.POST("/clusteroverridepolicy", handlePostClusterOverridePolicy)
+	r.PUT("/clusteroverridepolicy/:clusterOverridePolicyName", handlePutClusterOverridePolicy)
+	r.PATCH("/clusteroverridepolicy/:clusterOverridePolicyName", handlePatchClusterOverridePolicy)
+	r.DELETE("/clusteroverridepolicy/:clusterOverridePolicyName", handleDeleteClusterOverridePolicy)
 }
@@ -17,9 +17,8 @@ limitations under the License.
 package propagationpolicy
 
 import (
-	"context"
-
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -33,7 +32,11 @@ import (
 )
 
 func handleGetClusterPropagationPolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	clusterPropagationList, err := clusterpropagationpolicy.GetClusterPropagationPolicyList(karmadaClient, dataSelect)
 	if err != nil {
@@ -45,7 +48,11 @@ func handleGetClusterPropagationPolicyList(c *gin.Context) {
 }
 
 func handleGetClusterPropagationPolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("clusterPropagationPolicyName")
 	result, err := clusterpropagationpolicy.GetClusterPropagationPolicyDetail(karmadaClient, name)
 	if err != nil {
@@ -56,35 +63,140 @@ func handleGetClusterPropagationPolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// handlePostClusterPropagationPolicy creates a ClusterPropagationPolicy from the typed request, the YAML request of earlier
+// releases is still accepted.
 func handlePostClusterPropagationPolicy(c *gin.Context) {
-	ctx := context.Context(c)
-	propagationpolicyRequest := new(v1.PostPropagationPolicyRequest)
-	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
+	manifestRequest := new(v1.PostPropagationPolicyRequest)
+	if err := c.ShouldBindBodyWith(manifestRequest, binding.JSON); err == nil {
+		handlePostPropagationPolicyManifest(c, manifestRequest)
+		return
+	}
+	propagationpolicyRequest := new(v1.PostClusterPropagationPolicyRequest)
+	if err := c.ShouldBindBodyWith(propagationpolicyRequest, binding.JSON); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusterpropagationpolicy.CreateClusterPropagationPolicy(c, karmadaClient, &v1alpha1.ClusterPropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        propagationpolicyRequest.Name,
+			Labels:      propagationpolicyRequest.Labels,
+			Annotations: propagationpolicyRequest.Annotations,
+		},
+		Spec: propagationpolicyRequest.Spec,
+	}, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to create ClusterPropagationPolicy", "name", propagationpolicyRequest.Name)
 		common.Fail(c, err)
 		return
 	}
+	common.Success(c, result)
+}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	if propagationpolicyRequest.IsClusterScope {
+// handlePostPropagationPolicyManifest creates the PropagationPolicy or ClusterPropagationPolicy in the YAML manifest of request.
+func handlePostPropagationPolicyManifest(c *gin.Context, request *v1.PostPropagationPolicyRequest) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	kind := v1alpha1.ResourceKindPropagationPolicy
+	if request.IsClusterScope {
+		kind = v1alpha1.ResourceKindClusterPropagationPolicy
+	}
+	warnings, err := client.ValidateManifestSchema(c.Request, []byte(request.PropagationData), v1alpha1.SchemeGroupVersion.WithKind(kind))
+	if err != nil {
+		klog.ErrorS(err, "Invalid "+kind)
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
+	dryRun := common.ParseDryRunParameter(c)
+	if request.IsClusterScope {
 		clusterPropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterPropagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
-			common.Fail(c, err)
-			return
+		if err = yaml.Unmarshal([]byte(request.PropagationData), &clusterPropagationPolicy); err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(c, &clusterPropagationPolicy, metav1.CreateOptions{DryRun: dryRun})
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterPropagationPolicy, metav1.CreateOptions{})
 	} else {
+		if len(request.Namespace) == 0 {
+			request.Namespace = "default"
+		}
 		propagationPolicy := v1alpha1.PropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal PropagationPolicy")
-			common.Fail(c, err)
-			return
+		if err = yaml.Unmarshal([]byte(request.PropagationData), &propagationPolicy); err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(request.Namespace).Create(c, &propagationPolicy, metav1.CreateOptions{DryRun: dryRun})
 		}
-		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
-		klog.ErrorS(err, "Failed to create PropagationPolicy")
+		klog.ErrorS(err, "Failed to create "+kind)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+func handlePutClusterPropagationPolicy(c *gin.Context) {
+	name := c.Param("clusterPropagationPolicyName")
+	propagationpolicyRequest := new(v1.PutClusterPropagationPolicyRequest)
+	if err := c.ShouldBindJSON(propagationpolicyRequest); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusterpropagationpolicy.UpdateClusterPropagationPolicy(c, karmadaClient, &v1alpha1.ClusterPropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: propagationpolicyRequest.ResourceVersion,
+			Labels:          propagationpolicyRequest.Labels,
+			Annotations:     propagationpolicyRequest.Annotations,
+		},
+		Spec: propagationpolicyRequest.Spec,
+	}, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to update ClusterPropagationPolicy", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handlePatchClusterPropagationPolicy(c *gin.Context) {
+	name := c.Param("clusterPropagationPolicyName")
+	patch, err := c.GetRawData()
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusterpropagationpolicy.PatchClusterPropagationPolicy(c, karmadaClient, name, patch, common.ParseDryRunParameter(c))
+	if err != nil {
+		klog.ErrorS(err, "Failed to patch ClusterPropagationPolicy", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleDeleteClusterPropagationPolicy(c *gin.Context) {
+	name := c.Param("clusterPropagationPolicyName")
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = clusterpropagationpolicy.DeleteClusterPropagationPolicy(c, karmadaClient, name, c.Query("resourceVersion")); err != nil {
+		klog.ErrorS(err, "Failed to delete ClusterPropagationPolicy", "name", name)
 		common.Fail(c, err)
 		return
 	}
@@ -96,4 +208,7 @@ func init() {
 	r.GET("/clusterpropagationpolicy", handleGetClusterPropagationPolicyList)
 	r.GET("/clusterpropagationpolicy/:clusterPropagationPolicyName", handleGetClusterPropagationPolicyDetail)
 	r.POST("/clusterpropagationpolicy", handlePostClusterPropagationPolicy)
+	r.PUT("/clusterpropagationpolicy/:clusterPropagationPolicyName", handlePutClusterPropagationPolicy)
+	r.PATCH("/clusterpropagationpolicy/:clusterPropagationPolicyName", handlePatchClusterPropagationPolicy)
+	r.DELETE("/clusterpropagationpolicy/:clusterPropagationPolicyName", handleDeleteClusterPropagationPolicy)
 }
@@ -26,7 +26,11 @@ import (
 )
 
 func handleGetConfigMap(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := configmap.GetConfigMapList(k8sClient, nsQuery, dataSelect)
@@ -38,7 +42,11 @@ func handleGetConfigMap(c *gin.Context) {
 }
 
 func handleGetConfigMapDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 	result, err := configmap.GetConfigMapDetail(k8sClient, namespace, name)
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"github.com/gin-gonic/gin"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/resource/csr"
+)
+
+func handleGetCSRs(c *gin.Context) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	result, err := csr.GetCSRList(kubeClient, dataSelect)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list certificate signing requests")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleApproveCSR(c *gin.Context) {
+	handleApproval(c, csr.Approve)
+}
+
+func handleDenyCSR(c *gin.Context) {
+	handleApproval(c, csr.Deny)
+}
+
+func handleApproval(c *gin.Context, approve func(kubeClient kubeclient.Interface, name, message string) (*csr.CertificateSigningRequest, error)) {
+	approveRequest := new(v1.ApproveCSRRequest)
+	if err := c.ShouldBind(approveRequest); err != nil {
+		klog.ErrorS(err, "Could not read ApproveCSRRequest")
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	name := c.Param("name")
+	result, err := approve(kubeClient, name, approveRequest.Message)
+	if err != nil {
+		klog.ErrorS(err, "Failed to update approval of certificate signing request", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func init() {
+	r := router.V1()
+	r.GET("/csr", handleGetCSRs)
+	r.POST("/csr/:name/approve", handleApproveCSR)
+	r.POST("/csr/:name/deny", handleDenyCSR)
+}
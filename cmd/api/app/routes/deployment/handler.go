@@ -22,7 +22,6 @@ import (
 	"github.com/gin-gonic/gin"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
@@ -44,22 +43,25 @@ func handlerCreateDeployment(c *gin.Context) {
 		createDeploymentRequest.Namespace = "default"
 	}
 
-	restConfig, _, err := client.GetKarmadaConfig()
+	clientset, err := client.GetKarmadaKubeClientFromRequest(c.Request)
 	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	clientset, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
+	deployment := appsv1.Deployment{}
+	if err = yaml.Unmarshal([]byte(createDeploymentRequest.Content), &deployment); err != nil {
 		common.Fail(c, err)
 		return
 	}
-	deployment := appsv1.Deployment{}
-	if err = yaml.Unmarshal([]byte(createDeploymentRequest.Content), &deployment); err != nil {
+	warnings, err := client.ValidateManifestSchema(c.Request, []byte(createDeploymentRequest.Content), appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	result, err := clientset.AppsV1().Deployments(createDeploymentRequest.Namespace).Create(ctx, &deployment, metav1.CreateOptions{})
+	common.Warn(c, warnings)
+	result, err := clientset.AppsV1().Deployments(createDeploymentRequest.Namespace).Create(ctx, &deployment, metav1.CreateOptions{
+		DryRun: common.ParseDryRunParameter(c),
+	})
 	if err != nil {
 		common.Fail(c, err)
 		return
@@ -70,7 +72,11 @@ func handlerCreateDeployment(c *gin.Context) {
 func handleGetDeployments(c *gin.Context) {
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
@@ -82,7 +88,11 @@ func handleGetDeployments(c *gin.Context) {
 func handleGetDeploymentDetail(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
 	if err != nil {
 		common.Fail(c, err)
@@ -94,7 +104,11 @@ func handleGetDeploymentDetail(c *gin.Context) {
 func handleGetDeploymentEvents(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
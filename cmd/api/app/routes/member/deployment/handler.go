@@ -27,7 +27,11 @@ import (
 )
 
 func handleGetMemberDeployments(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := deployment.GetDeploymentList(memberClient, namespace, dataSelect)
@@ -39,7 +43,11 @@ func handleGetMemberDeployments(c *gin.Context) {
 }
 
 func handleGetMemberDeploymentDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
 	result, err := deployment.GetDeploymentDetail(memberClient, namespace, name)
@@ -51,7 +59,11 @@ func handleGetMemberDeploymentDetail(c *gin.Context) {
 }
 
 func handleGetMemberDeploymentEvents(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
 	dataSelect := common.ParseDataSelectPathParameter(c)
@@ -27,7 +27,11 @@ import (
 )
 
 func handleGetMemberNamespace(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := ns.GetNamespaceList(memberClient, dataSelect)
@@ -39,7 +43,11 @@ func handleGetMemberNamespace(c *gin.Context) {
 }
 
 func handleGetMemberNamespaceDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	name := c.Param("name")
 	result, err := ns.GetNamespaceDetail(memberClient, name)
@@ -51,7 +59,11 @@ func handleGetMemberNamespaceDetail(c *gin.Context) {
 }
 
 func handleGetMemberNamespaceEvents(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	name := c.Param("name")
 	dataSelect := common.ParseDataSelectPathParameter(c)
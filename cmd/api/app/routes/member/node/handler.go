@@ -26,7 +26,11 @@ import (
 )
 
 func handleGetClusterNode(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := node.GetNodeList(memberClient, dataSelect)
 	if err != nil {
@@ -27,7 +27,11 @@ import (
 
 // return a pods list
 func handleGetMemberPod(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := pod.GetPodList(memberClient, nsQuery, dataSelect)
@@ -40,7 +44,11 @@ func handleGetMemberPod(c *gin.Context) {
 
 // return a pod detail
 func handleGetMemberPodDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 	result, err := pod.GetPodDetail(memberClient, namespace, name)
@@ -28,7 +28,11 @@ import (
 )
 
 func handleCreateNamespace(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	createNamespaceRequest := new(v1.CreateNamesapceRequest)
 	if err := c.ShouldBind(&createNamespaceRequest); err != nil {
 		common.Fail(c, err)
@@ -38,14 +42,18 @@ func handleCreateNamespace(c *gin.Context) {
 		Name:                createNamespaceRequest.Name,
 		SkipAutoPropagation: createNamespaceRequest.SkipAutoPropagation,
 	}
-	if err := ns.CreateNamespace(spec, k8sClient); err != nil {
+	if err := ns.CreateNamespace(spec, k8sClient, common.ParseDryRunParameter(c)); err != nil {
 		common.Fail(c, err)
 		return
 	}
 	common.Success(c, "ok")
 }
 func handleGetNamespaces(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := ns.GetNamespaceList(k8sClient, dataSelect)
 	if err != nil {
@@ -55,7 +63,11 @@ func handleGetNamespaces(c *gin.Context) {
 	common.Success(c, result)
 }
 func handleGetNamespaceDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("name")
 	result, err := ns.GetNamespaceDetail(k8sClient, name)
 	if err != nil {
@@ -65,7 +77,11 @@ func handleGetNamespaceDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 func handleGetNamespaceEvents(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("name")
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetNamespaceEvents(k8sClient, dataSelect, name)
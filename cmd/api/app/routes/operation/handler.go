@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	goerrors "errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/auth/identity"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/operation"
+)
+
+// getOperation returns the operation of request, operations started by other users are reported as not found.
+// The user is verified by karmada apiserver since the name claimed by the token of request can be forged.
+func getOperation(c *gin.Context) (*operation.Operation, error) {
+	user, err := identity.Verify(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	result, err := operation.Get(c.Param("id"))
+	if err != nil || len(result.User) == 0 || result.User != user {
+		return nil, errors.NewNotFound("operation " + c.Param("id") + " not found")
+	}
+	return result, nil
+}
+
+func handleGetOperations(c *gin.Context) {
+	user, err := identity.Verify(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to verify user")
+		common.Fail(c, err)
+		return
+	}
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	common.Success(c, operation.GetOperationList(user, dataSelect))
+}
+
+func handleGetOperation(c *gin.Context) {
+	result, err := getOperation(c)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// handleWatchOperation streams the operation as server-sent events every time it changes, the stream ends
+// once the operation finished.
+func handleWatchOperation(c *gin.Context) {
+	if _, err := getOperation(c); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	updates, err := operation.Watch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	// disable buffering of nginx based proxies so that events are delivered immediately
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		update, ok := <-updates
+		if !ok {
+			return false
+		}
+		c.SSEvent("operation", update)
+		return true
+	})
+}
+
+func handleCancelOperation(c *gin.Context) {
+	if _, err := getOperation(c); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := operation.Cancel(c.Param("id"))
+	if goerrors.Is(err, operation.ErrFinished) {
+		common.Fail(c, errors.NewBadRequest(err.Error()))
+		return
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to cancel operation", "id", c.Param("id"))
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func init() {
+	r := router.V1()
+	r.GET("/operation", handleGetOperations)
+	r.GET("/operation/:id", handleGetOperation)
+	r.GET("/operation/:id/watch", handleWatchOperation)
+	r.POST("/operation/:id/cancel", handleCancelOperation)
+}
@@ -18,10 +18,14 @@ package overridepolicy
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
@@ -31,14 +35,24 @@ import (
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/clusteroverridepolicy"
 	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/policy"
 )
 
 func handleGetOverridePolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	namespace := common.ParseNamespacePathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	overrideList, err := overridepolicy.GetOverridePolicyList(karmadaClient, k8sClient, namespace, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetOverridePolicyList")
@@ -48,7 +62,11 @@ func handleGetOverridePolicyList(c *gin.Context) {
 	common.Success(c, overrideList)
 }
 func handleGetOverridePolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("overridePolicyName")
 	result, err := overridepolicy.GetOverridePolicyDetail(karmadaClient, namespace, name)
@@ -72,7 +90,19 @@ func handlePostOverridePolicy(c *gin.Context) {
 	}
 
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dryRun := common.ParseDryRunParameter(c)
+	warnings, err := validatePolicy(c.Request, overridepolicyRequest.IsClusterScope, overridepolicyRequest.OverrideData)
+	if err != nil {
+		klog.ErrorS(err, "Invalid OverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
 	if overridepolicyRequest.IsClusterScope {
 		clusteroverridePolicy := v1alpha1.ClusterOverridePolicy{}
 		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusteroverridePolicy); err != nil {
@@ -80,7 +110,7 @@ func handlePostOverridePolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusteroverridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusteroverridePolicy, metav1.CreateOptions{DryRun: dryRun})
 	} else {
 		overridePolicy := v1alpha1.OverridePolicy{}
 		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
@@ -88,7 +118,7 @@ func handlePostOverridePolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, &overridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, &overridePolicy, metav1.CreateOptions{DryRun: dryRun})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create OverridePolicies")
@@ -97,6 +127,96 @@ func handlePostOverridePolicy(c *gin.Context) {
 	}
 	common.Success(c, "ok")
 }
+func handlePreviewOverridePolicy(c *gin.Context) {
+	overridepolicyRequest := new(v1.PreviewOverridePolicyRequest)
+	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if overridepolicyRequest.Namespace == "" {
+		overridepolicyRequest.Namespace = "default"
+	}
+	policy := new(overridepolicy.PreviewPolicy)
+	if overridepolicyRequest.IsClusterScope {
+		clusteroverridePolicy := v1alpha1.ClusterOverridePolicy{}
+		if err := yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusteroverridePolicy); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
+			common.Fail(c, err)
+			return
+		}
+		policy.Name = clusteroverridePolicy.Name
+		policy.Spec = clusteroverridePolicy.Spec
+	} else {
+		overridePolicy := v1alpha1.OverridePolicy{}
+		if err := yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal OverridePolicy")
+			common.Fail(c, err)
+			return
+		}
+		policy.Namespace = overridepolicyRequest.Namespace
+		policy.Name = overridePolicy.Name
+		policy.Spec = overridePolicy.Spec
+	}
+
+	resource, err := getPreviewResource(c, overridepolicyRequest)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the target resource of preview")
+		common.Fail(c, err)
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := overridepolicy.Preview(c, karmadaClient, policy, resource)
+	if err != nil {
+		klog.ErrorS(err, "Failed to preview OverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// getPreviewResource returns the target resource of the preview, it's parsed from the manifest of the request
+// or fetched from karmada apiserver.
+func getPreviewResource(c *gin.Context, request *v1.PreviewOverridePolicyRequest) (*unstructured.Unstructured, error) {
+	if len(request.ResourceData) > 0 {
+		resource := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(request.ResourceData), &resource.Object); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		if len(resource.GetAPIVersion()) == 0 || len(resource.GetKind()) == 0 || len(resource.GetName()) == 0 {
+			return nil, errors.NewBadRequest("apiVersion, kind and name of the resource are required")
+		}
+		return resource, nil
+	}
+	if request.Resource == nil {
+		return nil, errors.NewBadRequest("either resourceData or resource is required")
+	}
+
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(request.Resource.APIVersion, request.Resource.Kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource).Get(c, request.Resource.Name, metav1.GetOptions{})
+	}
+	namespace := request.Resource.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	return dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(c, request.Resource.Name, metav1.GetOptions{})
+}
 func handlePutOverridePolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	overridepolicyRequest := new(v1.PutOverridePolicyRequest)
@@ -105,16 +225,29 @@ func handlePutOverridePolicy(c *gin.Context) {
 		return
 	}
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	// todo check pp exist
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dryRun := common.ParseDryRunParameter(c)
+	warnings, err := validatePolicy(c.Request, overridepolicyRequest.IsClusterScope, overridepolicyRequest.OverrideData)
+	if err != nil {
+		klog.ErrorS(err, "Invalid OverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
+	// the policy to update is the one named in the request, only its spec, labels and annotations are updated
 	if overridepolicyRequest.IsClusterScope {
-		clusteroverridePolicy := v1alpha1.ClusterOverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusteroverridePolicy); err != nil {
+		clusterOverridePolicy := v1alpha1.ClusterOverridePolicy{}
+		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusterOverridePolicy); err != nil {
 			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Update(ctx, &clusteroverridePolicy, metav1.UpdateOptions{})
+		clusterOverridePolicy.Name = overridepolicyRequest.Name
+		_, err = clusteroverridepolicy.UpdateClusterOverridePolicy(ctx, karmadaClient, &clusterOverridePolicy, dryRun)
 	} else {
 		overridePolicy := v1alpha1.OverridePolicy{}
 		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
@@ -122,14 +255,11 @@ func handlePutOverridePolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		var oldOverridePolicy *v1alpha1.OverridePolicy
-		oldOverridePolicy, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Get(ctx, overridepolicyRequest.Name, metav1.GetOptions{})
-		if err == nil {
-			// only spec can be updated
-			overridePolicy.TypeMeta = oldOverridePolicy.TypeMeta
-			overridePolicy.ObjectMeta = oldOverridePolicy.ObjectMeta
-			_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Update(ctx, &overridePolicy, metav1.UpdateOptions{})
-		}
+		overridePolicy.Namespace, overridePolicy.Name = overridepolicyRequest.Namespace, overridepolicyRequest.Name
+		_, err = policy.Update(ctx, karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace), v1alpha1.Resource(v1alpha1.ResourcePluralOverridePolicy),
+			&overridePolicy, func(current, overridePolicy *v1alpha1.OverridePolicy) {
+				current.Spec = overridePolicy.Spec
+			}, dryRun)
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to update OverridePolicy")
@@ -146,7 +276,11 @@ func handleDeleteOverridePolicy(c *gin.Context) {
 		return
 	}
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if overridepolicyRequest.IsClusterScope {
 		err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Delete(ctx, overridepolicyRequest.Name, metav1.DeleteOptions{})
 		if err != nil {
@@ -175,12 +309,23 @@ func handleDeleteOverridePolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
+// validatePolicy validates the manifest against the schema of OverridePolicy or ClusterOverridePolicy published by karmada apiserver,
+// the unknown fields are returned as warnings.
+func validatePolicy(request *http.Request, isClusterScope bool, manifest string) ([]string, error) {
+	kind := v1alpha1.ResourceKindOverridePolicy
+	if isClusterScope {
+		kind = v1alpha1.ResourceKindClusterOverridePolicy
+	}
+	return client.ValidateManifestSchema(request, []byte(manifest), v1alpha1.SchemeGroupVersion.WithKind(kind))
+}
+
 func init() {
 	r := router.V1()
 	r.GET("/overridepolicy", handleGetOverridePolicyList)
 	r.GET("/overridepolicy/:namespace", handleGetOverridePolicyList)
 	r.GET("/overridepolicy/namespace/:namespace/:overridePolicyName", handleGetOverridePolicyDetail)
 	r.POST("/overridepolicy", handlePostOverridePolicy)
+	r.POST("/overridepolicy/preview", handlePreviewOverridePolicy)
 	r.PUT("/overridepolicy", handlePutOverridePolicy)
 	r.DELETE("/overridepolicy", handleDeleteOverridePolicy)
 }
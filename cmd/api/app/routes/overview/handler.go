@@ -22,6 +22,7 @@ import (
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
 )
 
 func handleGetOverview(c *gin.Context) {
@@ -31,13 +32,23 @@ func handleGetOverview(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
-	memberClusterStatus, err := GetMemberClusterInfo(dataSelect)
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	memberClusterStatus, err := GetMemberClusterInfo(karmadaClient, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
 		return
 	}
 
-	clusterResourceStatus, err := GetClusterResourceStatus()
+	clusterResourceStatus, err := GetClusterResourceStatus(karmadaClient, kubeClient)
 	if err != nil {
 		common.Fail(c, err)
 		return
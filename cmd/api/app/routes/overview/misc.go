@@ -24,15 +24,19 @@ import (
 	"math/big"
 	"strings"
 
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	"github.com/karmada-io/karmada/pkg/version"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
 
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/clusterhistory"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 	"github.com/karmada-io/dashboard/pkg/resource/cluster"
 )
@@ -161,8 +165,7 @@ func GetControllerManagerInfo() (*v1.KarmadaInfo, error) {
 }
 
 // GetMemberClusterInfo returns the status of member clusters.
-func GetMemberClusterInfo(ds *dataselect.DataSelectQuery) (*v1.MemberClusterStatus, error) {
-	karmadaClient := client.InClusterKarmadaClient()
+func GetMemberClusterInfo(karmadaClient karmadaclientset.Interface, ds *dataselect.DataSelectQuery) (*v1.MemberClusterStatus, error) {
 	result, err := cluster.GetClusterList(karmadaClient, ds)
 	if err != nil {
 		return nil, err
@@ -173,31 +176,47 @@ func GetMemberClusterInfo(ds *dataselect.DataSelectQuery) (*v1.MemberClusterStat
 		MemorySummary: &v1.MemorySummary{},
 		PodSummary:    &v1.PodSummary{},
 	}
+	clusterNames := make([]string, 0, len(result.Clusters))
+	clusterResources := make([][]cluster.ResourceUsage, 0, len(result.Clusters))
 	for _, clusterItem := range result.Clusters {
 		// handle node summary
 		memberClusterStatus.NodeSummary.ReadyNum += clusterItem.NodeSummary.ReadyNum
 		memberClusterStatus.NodeSummary.TotalNum += clusterItem.NodeSummary.TotalNum
 
-		// handle cpu summary
-		memberClusterStatus.CPUSummary.TotalCPU += clusterItem.AllocatedResources.CPUCapacity
-		memberClusterStatus.CPUSummary.AllocatedCPU += float64(clusterItem.AllocatedResources.CPUCapacity) * clusterItem.AllocatedResources.CPUFraction / 100
+		clusterResources = append(clusterResources, clusterItem.AllocatedResources.Resources)
+		clusterNames = append(clusterNames, clusterItem.ObjectMeta.Name)
+	}
+	resources := cluster.AggregateResourceUsages(clusterResources...)
+	memberClusterStatus.Resources = resources
+
+	// handle cpu summary
+	cpu := cluster.FindResourceUsage(resources, corev1.ResourceCPU)
+	memberClusterStatus.CPUSummary.TotalCPU = cpu.Allocatable.Value()
+	memberClusterStatus.CPUSummary.AllocatedCPU = cpu.Allocated.AsApproximateFloat64()
+
+	// handle memory summary
+	memory := cluster.FindResourceUsage(resources, corev1.ResourceMemory)
+	memberClusterStatus.MemorySummary.TotalMemory = memory.Allocatable.Value()
+	memberClusterStatus.MemorySummary.AllocatedMemory = memory.Allocated.AsApproximateFloat64()
 
-		// handle memory summary
-		memberClusterStatus.MemorySummary.TotalMemory += clusterItem.AllocatedResources.MemoryCapacity
-		memberClusterStatus.MemorySummary.AllocatedMemory += float64(clusterItem.AllocatedResources.MemoryCapacity) * clusterItem.AllocatedResources.MemoryFraction / 100
+	// handle pod summary
+	pods := cluster.FindResourceUsage(resources, corev1.ResourcePods)
+	memberClusterStatus.PodSummary.TotalPod = pods.Allocatable.Value()
+	memberClusterStatus.PodSummary.AllocatedPod = pods.Allocated.Value()
 
-		// handle pod summary
-		memberClusterStatus.PodSummary.TotalPod += clusterItem.AllocatedResources.PodCapacity
-		memberClusterStatus.PodSummary.AllocatedPod += clusterItem.AllocatedResources.AllocatedPods
+	// the availability is informative, the overview is served without it if the history is unavailable
+	availability, err := clusterhistory.GetSummary(clusterNames)
+	if err != nil && !errors.Is(err, clusterhistory.ErrDisabled) {
+		klog.ErrorS(err, "Get availability of clusters failed")
 	}
+	memberClusterStatus.Availability = availability
 	return memberClusterStatus, nil
 }
 
 // GetClusterResourceStatus returns the status of cluster resources.
-func GetClusterResourceStatus() (*v1.ClusterResourceStatus, error) {
+func GetClusterResourceStatus(karmadaClient karmadaclientset.Interface, kubeClient kubeclient.Interface) (*v1.ClusterResourceStatus, error) {
 	clusterResourceStatus := &v1.ClusterResourceStatus{}
 	ctx := context.TODO()
-	karmadaClient := client.InClusterKarmadaClient()
 	// handle pp num
 	clusterPPRet, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -226,7 +245,6 @@ func GetClusterResourceStatus() (*v1.ClusterResourceStatus, error) {
 
 	// handle cluster resources
 	// handler namespace num
-	kubeClient := client.InClusterClientForKarmadaAPIServer()
 	nsRet, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/policy"
+)
+
+// defaultConflictLimit is the number of conflicts and overlaps reported if the request doesn't set a limit.
+const defaultConflictLimit = 500
+
+func handleGetPolicyConflicts(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultConflictLimit)))
+	if err != nil || limit <= 0 {
+		common.Fail(c, errors.NewBadRequest("limit must be a positive integer"))
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to discover resources of karmada apiserver")
+		common.Fail(c, err)
+		return
+	}
+	namespace := c.Query("namespace")
+	result, err := policy.AnalyzeConflicts(c, karmadaClient, dynamicClient, mapper, namespace, limit)
+	if err != nil {
+		klog.ErrorS(err, "Failed to analyze policy conflicts", "namespace", namespace)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func init() {
+	r := router.V1()
+	r.GET("/policies/conflicts", handleGetPolicyConflicts)
+}
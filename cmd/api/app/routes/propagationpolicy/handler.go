@@ -18,6 +18,7 @@ package propagationpolicy
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
@@ -31,15 +32,25 @@ import (
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/clusterpropagationpolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/policy"
 	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
 )
 
 func handleGetPropagationPolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	namespace := common.ParseNamespacePathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
-	propagationList, err := propagationpolicy.GetPropagationPolicyList(karmadaClient, k8sClient, namespace, dataSelect)
+	verber, err := client.VerberClient(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	propagationList, err := propagationpolicy.GetPropagationPolicyList(karmadaClient, verber, namespace, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetPropagationPolicyList")
 		common.Fail(c, err)
@@ -48,7 +59,11 @@ func handleGetPropagationPolicyList(c *gin.Context) {
 	common.Success(c, propagationList)
 }
 func handleGetPropagationPolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("propagationPolicyName")
 	result, err := propagationpolicy.GetPropagationPolicyDetail(karmadaClient, namespace, name)
@@ -72,7 +87,19 @@ func handlePostPropagationPolicy(c *gin.Context) {
 	}
 
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dryRun := common.ParseDryRunParameter(c)
+	warnings, err := validatePolicy(c.Request, propagationpolicyRequest.IsClusterScope, propagationpolicyRequest.PropagationData)
+	if err != nil {
+		klog.ErrorS(err, "Invalid PropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
 	if propagationpolicyRequest.IsClusterScope {
 		clusterpropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
 		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterpropagationPolicy); err != nil {
@@ -80,7 +107,7 @@ func handlePostPropagationPolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterpropagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterpropagationPolicy, metav1.CreateOptions{DryRun: dryRun})
 	} else {
 		propagationPolicy := v1alpha1.PropagationPolicy{}
 		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
@@ -88,7 +115,7 @@ func handlePostPropagationPolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{DryRun: dryRun})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create PropagationPolicy")
@@ -97,6 +124,61 @@ func handlePostPropagationPolicy(c *gin.Context) {
 	}
 	common.Success(c, "ok")
 }
+func handlePreviewPropagationPolicy(c *gin.Context) {
+	propagationpolicyRequest := new(v1.PostPropagationPolicyRequest)
+	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if propagationpolicyRequest.Namespace == "" {
+		propagationpolicyRequest.Namespace = "default"
+	}
+	policy := new(propagationpolicy.PreviewPolicy)
+	if propagationpolicyRequest.IsClusterScope {
+		clusterpropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
+		if err := yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterpropagationPolicy); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
+			common.Fail(c, err)
+			return
+		}
+		policy.Name = clusterpropagationPolicy.Name
+		policy.Spec = clusterpropagationPolicy.Spec
+	} else {
+		propagationPolicy := v1alpha1.PropagationPolicy{}
+		if err := yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal PropagationPolicy")
+			common.Fail(c, err)
+			return
+		}
+		policy.Namespace = propagationpolicyRequest.Namespace
+		policy.Name = propagationPolicy.Name
+		policy.Spec = propagationPolicy.Spec
+	}
+
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Failed to discover resources of karmada apiserver")
+		common.Fail(c, err)
+		return
+	}
+	result, err := propagationpolicy.Preview(c, karmadaClient, dynamicClient, mapper, policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to preview PropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
 func handlePutPropagationPolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	propagationpolicyRequest := new(v1.PutPropagationPolicyRequest)
@@ -105,16 +187,29 @@ func handlePutPropagationPolicy(c *gin.Context) {
 		return
 	}
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	// todo check pp exist
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dryRun := common.ParseDryRunParameter(c)
+	warnings, err := validatePolicy(c.Request, propagationpolicyRequest.IsClusterScope, propagationpolicyRequest.PropagationData)
+	if err != nil {
+		klog.ErrorS(err, "Invalid PropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
+	// the policy to update is the one named in the request, only its spec, labels and annotations are updated
 	if propagationpolicyRequest.IsClusterScope {
-		clusterpropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterpropagationPolicy); err != nil {
+		clusterPropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
+		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterPropagationPolicy); err != nil {
 			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(ctx, &clusterpropagationPolicy, metav1.UpdateOptions{})
+		clusterPropagationPolicy.Name = propagationpolicyRequest.Name
+		_, err = clusterpropagationpolicy.UpdateClusterPropagationPolicy(ctx, karmadaClient, &clusterPropagationPolicy, dryRun)
 	} else {
 		propagationPolicy := v1alpha1.PropagationPolicy{}
 		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
@@ -122,14 +217,11 @@ func handlePutPropagationPolicy(c *gin.Context) {
 			common.Fail(c, err)
 			return
 		}
-		var oldPropagationPolicy *v1alpha1.PropagationPolicy
-		oldPropagationPolicy, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Get(ctx, propagationpolicyRequest.Name, metav1.GetOptions{})
-		if err == nil {
-			// only spec can be updated
-			propagationPolicy.TypeMeta = oldPropagationPolicy.TypeMeta
-			propagationPolicy.ObjectMeta = oldPropagationPolicy.ObjectMeta
-			_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Update(ctx, &propagationPolicy, metav1.UpdateOptions{})
-		}
+		propagationPolicy.Namespace, propagationPolicy.Name = propagationpolicyRequest.Namespace, propagationpolicyRequest.Name
+		_, err = policy.Update(ctx, karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace), v1alpha1.Resource(v1alpha1.ResourcePluralPropagationPolicy),
+			&propagationPolicy, func(current, propagationPolicy *v1alpha1.PropagationPolicy) {
+				current.Spec = propagationPolicy.Spec
+			}, dryRun)
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to update PropagationPolicy")
@@ -146,7 +238,11 @@ func handleDeletePropagationPolicy(c *gin.Context) {
 		return
 	}
 	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if propagationpolicyRequest.IsClusterScope {
 		err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Delete(ctx, propagationpolicyRequest.Name, metav1.DeleteOptions{})
 		if err != nil {
@@ -175,11 +271,22 @@ func handleDeletePropagationPolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
+// validatePolicy validates the manifest against the schema of PropagationPolicy or ClusterPropagationPolicy published by karmada apiserver,
+// the unknown fields are returned as warnings.
+func validatePolicy(request *http.Request, isClusterScope bool, manifest string) ([]string, error) {
+	kind := v1alpha1.ResourceKindPropagationPolicy
+	if isClusterScope {
+		kind = v1alpha1.ResourceKindClusterPropagationPolicy
+	}
+	return client.ValidateManifestSchema(request, []byte(manifest), v1alpha1.SchemeGroupVersion.WithKind(kind))
+}
+
 func init() {
 	r := router.V1()
 	r.GET("/propagationpolicy", handleGetPropagationPolicyList)
 	r.GET("/propagationpolicy/namespace/:namespace/:propagationPolicyName", handleGetPropagationPolicyDetail)
 	r.POST("/propagationpolicy", handlePostPropagationPolicy)
+	r.POST("/propagationpolicy/preview", handlePreviewPropagationPolicy)
 	r.PUT("/propagationpolicy", handlePutPropagationPolicy)
 	r.DELETE("/propagationpolicy", handleDeletePropagationPolicy)
 }
@@ -26,7 +26,11 @@ import (
 )
 
 func handleGetSecrets(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := secret.GetSecretList(k8sClient, nsQuery, dataSelect)
@@ -38,7 +42,11 @@ func handleGetSecrets(c *gin.Context) {
 }
 
 func handleGetSecretDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("service")
 	result, err := secret.GetSecretDetail(k8sClient, namespace, name)
@@ -104,7 +104,14 @@ func handlePutResource(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
-	if err = verber.Update(raw); err != nil {
+	warnings, err := client.ValidateSchema(c.Request, raw)
+	if err != nil {
+		klog.ErrorS(err, "Invalid resource", "kind", raw.GetKind(), "name", raw.GetName())
+		common.Fail(c, err)
+		return
+	}
+	common.Warn(c, warnings)
+	if err = verber.Update(raw, common.ParseDryRunParameter(c)); err != nil {
 		klog.ErrorS(err, "Failed to update resource")
 		common.Fail(c, err)
 		return
@@ -132,8 +139,16 @@ func handleCreateResource(c *gin.Context) {
 	if err != nil {
 		klog.ErrorS(err, "Failed to unmarshal request body")
 		common.Fail(c, err)
+		return
+	}
+	warnings, err := client.ValidateSchema(c.Request, raw)
+	if err != nil {
+		klog.ErrorS(err, "Invalid resource", "kind", raw.GetKind(), "name", raw.GetName())
+		common.Fail(c, err)
+		return
 	}
-	if _, err = verber.Create(raw); err != nil {
+	common.Warn(c, warnings)
+	if _, err = verber.Create(raw, common.ParseDryRunParameter(c)); err != nil {
 		klog.ErrorS(err, "Failed to create resource")
 		common.Fail(c, err)
 		return
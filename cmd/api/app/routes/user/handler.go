@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/resource/user"
+)
+
+// usersPath is the non-resource path of karmada apiserver which authorizes the management of dashboard users,
+// e.g. a ClusterRole granting nonResourceURLs: ["/karmada-dashboard/users"] and verbs: ["get", "create", "delete"].
+// Users are provisioned with the caller's own credentials, so karmada apiserver also requires the caller to be
+// allowed to create the ServiceAccounts, tokens and bindings, and to hold or be allowed to bind the granted roles.
+const usersPath = "/karmada-dashboard/users"
+
+// namespace is the namespace of karmada control plane the ServiceAccounts of dashboard users are created in.
+var namespace = user.DefaultNamespace
+
+// SetNamespace sets the namespace the ServiceAccounts of dashboard users are created in.
+func SetNamespace(ns string) {
+	if len(ns) > 0 {
+		namespace = ns
+	}
+}
+
+func handleCreateUser(c *gin.Context) {
+	createUserRequest := new(v1.CreateUserRequest)
+	if err := c.ShouldBind(createUserRequest); err != nil {
+		klog.ErrorS(err, "Could not read CreateUserRequest")
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := user.CreateUser(kubeClient, namespace, &user.Spec{
+		Name:       createUserRequest.Name,
+		Role:       createUserRequest.Role,
+		Namespaces: createUserRequest.Namespaces,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to create user", "name", createUserRequest.Name)
+		common.Fail(c, err)
+		return
+	}
+	token, err := user.CreateToken(kubeClient, namespace, createUserRequest.Name,
+		time.Duration(createUserRequest.ExpirationSeconds)*time.Second)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create token of user", "name", createUserRequest.Name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, v1.CreateUserResponse{User: result, Token: token})
+}
+
+func handleGetUsers(c *gin.Context) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	result, err := user.GetUserList(kubeClient, namespace, dataSelect)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list users")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleGetUser(c *gin.Context) {
+	name := c.Param("name")
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := user.GetUser(kubeClient, namespace, name)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get user", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+func handleCreateUserToken(c *gin.Context) {
+	name := c.Param("name")
+	createUserTokenRequest := new(v1.CreateUserTokenRequest)
+	if err := c.ShouldBind(createUserTokenRequest); err != nil {
+		klog.ErrorS(err, "Could not read CreateUserTokenRequest")
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	token, err := user.CreateToken(kubeClient, namespace, name,
+		time.Duration(createUserTokenRequest.ExpirationSeconds)*time.Second)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create token of user", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, token)
+}
+
+func handleDeleteUser(c *gin.Context) {
+	name := c.Param("name")
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// make sure only users created by dashboard can be revoked
+	if _, err = user.GetUser(kubeClient, namespace, name); err != nil {
+		klog.ErrorS(err, "Failed to get user", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	if err = user.DeleteUser(kubeClient, namespace, name); err != nil {
+		klog.ErrorS(err, "Failed to delete user", "name", name)
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+func init() {
+	r := router.V1()
+	r.POST("/users", router.NonResourceAccessMiddleware(usersPath, "create"), handleCreateUser)
+	r.GET("/users", router.NonResourceAccessMiddleware(usersPath, "get"), handleGetUsers)
+	r.GET("/users/:name", router.NonResourceAccessMiddleware(usersPath, "get"), handleGetUser)
+	r.POST("/users/:name/token", router.NonResourceAccessMiddleware(usersPath, "create"), handleCreateUserToken)
+	r.DELETE("/users/:name", router.NonResourceAccessMiddleware(usersPath, "delete"), handleDeleteUser)
+}
@@ -16,13 +16,43 @@ limitations under the License.
 
 package v1
 
-// LoginRequest is the request for login.
+import (
+	"time"
+
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
+)
+
+// LoginRequest is the request for login, either a token or a kubeconfig must be given.
 type LoginRequest struct {
 	Token string `json:"token"`
+	// Kubeconfig is the content of a kubeconfig whose embedded token or client certificate is used to sign in,
+	// the credentials are kept in a server side session instead of a dashboard token.
+	Kubeconfig string `json:"kubeconfig"`
+	// Context is the context of Kubeconfig to sign in with, defaults to the current context.
+	Context string `json:"context"`
+}
+
+// KubeconfigContextsRequest is the request for listing the contexts of a kubeconfig.
+type KubeconfigContextsRequest struct {
+	Kubeconfig string `json:"kubeconfig" binding:"required"`
+}
+
+// KubeconfigContextsResponse is the response for listing the contexts of a kubeconfig.
+type KubeconfigContextsResponse struct {
+	Contexts       []string `json:"contexts"`
+	CurrentContext string   `json:"currentContext"`
 }
 
-// LoginResponse is the response for login.
+// LoginResponse is the response for login and token refresh.
 type LoginResponse struct {
+	// Token is the encrypted dashboard token which wraps the karmada token of user.
+	Token string `json:"token"`
+	// ExpiresAt is when the token lapses unless it's refreshed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CSRFToken is the response for issuing a csrf token.
+type CSRFToken struct {
 	Token string `json:"token"`
 }
 
@@ -37,3 +67,9 @@ type ServiceAccount struct {
 	Name string `json:"name"`
 	UID  string `json:"uid"`
 }
+
+// PermissionsResponse is the response for querying the permissions of the current user.
+type PermissionsResponse struct {
+	User *permission.UserInfo `json:"user"`
+	*permission.Permissions
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// CreateBootstrapTokenRequest is the request body for creating a bootstrap token.
+type CreateBootstrapTokenRequest struct {
+	// TTLSeconds is the lifetime of the token, defaults to 24 hours.
+	TTLSeconds  int64  `json:"ttlSeconds"`
+	Description string `json:"description"`
+	// APIServerEndpoint is the address of karmada apiserver reachable from member clusters, which is used in the
+	// register command. It defaults to the address karmada-dashboard connects to.
+	APIServerEndpoint string `json:"apiServerEndpoint"`
+}
+
+// ApproveCSRRequest is the request body for approving or denying a certificate signing request.
+type ApproveCSRRequest struct {
+	Message string `json:"message"`
+}
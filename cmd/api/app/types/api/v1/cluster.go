@@ -19,6 +19,8 @@ package v1
 import (
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/karmada-io/dashboard/pkg/operation"
 )
 
 // PostClusterRequest is the request body for creating a cluster.
@@ -31,6 +33,62 @@ type PostClusterRequest struct {
 	ClusterProvider         string                   `json:"clusterProvider"`
 	ClusterRegion           string                   `json:"clusterRegion"`
 	ClusterZones            []string                 `json:"clusterZones"`
+	ClusterLabels           map[string]string        `json:"clusterLabels"`
+	// KarmadaAgent customizes the karmada-agent deployed into member cluster, it only applies to Pull mode.
+	KarmadaAgent *KarmadaAgentSpec `json:"karmadaAgent"`
+}
+
+// KarmadaAgentSpec is the spec of karmada-agent deployed into member cluster when joining it in Pull mode.
+type KarmadaAgentSpec struct {
+	// Registry is the name of the docker registry in dashboard config which the image is pulled from, an
+	// ImagePullSecret is generated in member cluster if the registry has credentials.
+	Registry string `json:"registry"`
+	// Image is the repository of karmada-agent image, defaults to karmada/karmada-agent.
+	Image string `json:"image"`
+	// Tag is the tag of karmada-agent image, defaults to the version of karmada control plane.
+	Tag          string                      `json:"tag"`
+	Replicas     *int32                      `json:"replicas"`
+	Resources    corev1.ResourceRequirements `json:"resources"`
+	NodeSelector map[string]string           `json:"nodeSelector"`
+	Tolerations  []corev1.Toleration         `json:"tolerations"`
+	// ExtraArgs are appended to the command of karmada-agent, e.g. --cluster-region=cn-north-1.
+	ExtraArgs []string `json:"extraArgs"`
+}
+
+// PostClusterBatchRequest is the request body for joining several clusters from the contexts of one kubeconfig.
+type PostClusterBatchRequest struct {
+	Kubeconfig string                    `json:"kubeconfig" binding:"required"`
+	Clusters   []PostClusterBatchCluster `json:"clusters" binding:"required,min=1,dive"`
+	// Concurrency is the number of clusters joined at the same time, defaults to 4.
+	Concurrency int `json:"concurrency" binding:"gte=0,lte=16"`
+}
+
+// PostClusterBatchCluster is the cluster joined from a context of the kubeconfig in PostClusterBatchRequest.
+type PostClusterBatchCluster struct {
+	Context                string                   `json:"context" binding:"required"`
+	SyncMode               v1alpha1.ClusterSyncMode `json:"syncMode" binding:"required"`
+	MemberClusterName      string                   `json:"memberClusterName" binding:"required"`
+	MemberClusterNamespace string                   `json:"memberClusterNamespace"`
+	ClusterProvider        string                   `json:"clusterProvider"`
+	ClusterRegion          string                   `json:"clusterRegion"`
+	ClusterZones           []string                 `json:"clusterZones"`
+	ClusterLabels          map[string]string        `json:"clusterLabels"`
+	KarmadaAgent           *KarmadaAgentSpec        `json:"karmadaAgent"`
+}
+
+// PostClusterBatchResult is the result of joining one cluster of PostClusterBatchRequest, either the join
+// operation was started or the error tells why it could not be.
+type PostClusterBatchResult struct {
+	Context           string               `json:"context"`
+	MemberClusterName string               `json:"memberClusterName"`
+	Operation         *operation.Operation `json:"operation,omitempty"`
+	Error             string               `json:"error,omitempty"`
+}
+
+// PostClusterBatchResponse is the response body for joining several clusters, the results are in the order
+// of the requested clusters.
+type PostClusterBatchResponse struct {
+	Results []PostClusterBatchResult `json:"results"`
 }
 
 // PostClusterResponse is the response body for creating a cluster.
@@ -50,10 +108,23 @@ type TaintRequest struct {
 	Value  string             `json:"value"`
 }
 
-// PutClusterRequest is the request body for updating a cluster.
+// PutClusterRequest is the request body for updating a cluster, fields which are not set are left unchanged.
 type PutClusterRequest struct {
 	Labels *[]LabelRequest `json:"labels"`
 	Taints *[]TaintRequest `json:"taints"`
+
+	ID       *string   `json:"id"`
+	Provider *string   `json:"provider"`
+	Region   *string   `json:"region"`
+	Zones    *[]string `json:"zones"`
+
+	ProxyURL    *string            `json:"proxyURL"`
+	ProxyHeader *map[string]string `json:"proxyHeader"`
+
+	SecretRef             *v1alpha1.LocalSecretReference `json:"secretRef"`
+	ImpersonatorSecretRef *v1alpha1.LocalSecretReference `json:"impersonatorSecretRef"`
+
+	ResourceModels *[]v1alpha1.ResourceModel `json:"resourceModels"`
 }
 
 // PutClusterResponse is the response body for updating a cluster.
@@ -62,8 +133,52 @@ type PutClusterResponse struct{}
 // DeleteClusterRequest is the request body for deleting a cluster.
 type DeleteClusterRequest struct {
 	MemberClusterName string `uri:"name" binding:"required"`
+	// MemberClusterKubeConfig is optional, the objects created in member cluster when joining it are removed
+	// if it's given.
+	MemberClusterKubeConfig string `json:"memberClusterKubeconfig"`
+	// MemberClusterNamespace is the namespace karmada-agent was deployed into, it only applies to Pull mode.
+	MemberClusterNamespace string `json:"memberClusterNamespace"`
+	// Force removes the finalizers blocking the deletion of cluster once it times out, and keeps cleaning up
+	// member cluster even though some of the steps failed.
+	Force bool `json:"force"`
 }
 
 // DeleteClusterResponse is the response body for deleting a cluster.
 type DeleteClusterResponse struct {
 }
+
+// PreflightCheckStatus is the result of a preflight check.
+type PreflightCheckStatus string
+
+const (
+	// PreflightCheckPass means the check passed.
+	PreflightCheckPass PreflightCheckStatus = "pass"
+	// PreflightCheckWarn means joining may still succeed but something deserves attention.
+	PreflightCheckWarn PreflightCheckStatus = "warn"
+	// PreflightCheckFail means joining is going to fail.
+	PreflightCheckFail PreflightCheckStatus = "fail"
+)
+
+// PreflightCheck is the result of one check before joining a cluster.
+type PreflightCheck struct {
+	Name    string               `json:"name"`
+	Status  PreflightCheckStatus `json:"status"`
+	Message string               `json:"message"`
+	// Remediation tells how to fix the problem, it's empty if the check passed.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// PreflightResponse is the response body for checking whether a cluster can be joined.
+type PreflightResponse struct {
+	// Passed is true if none of the checks failed.
+	Passed bool             `json:"passed"`
+	Checks []PreflightCheck `json:"checks"`
+}
+
+// EvacuateClusterRequest is the request body for evacuating a cluster.
+type EvacuateClusterRequest struct {
+	// Confirm taints the cluster for evacuation, only the impact is previewed without it.
+	Confirm bool `json:"confirm"`
+	// TimeoutSeconds bounds how long the evacuation is waited for, defaults to 30 minutes and is clamped to 24 hours.
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+)
+
+// PostClusterPropagationPolicyRequest is the typed request body for creating a cluster propagation policy.
+type PostClusterPropagationPolicyRequest struct {
+	Name        string                   `json:"name" binding:"required"`
+	Labels      map[string]string        `json:"labels"`
+	Annotations map[string]string        `json:"annotations"`
+	Spec        v1alpha1.PropagationSpec `json:"spec"`
+}
+
+// PutClusterPropagationPolicyRequest is the typed request body for updating a cluster propagation policy, the name is
+// taken from the path.
+type PutClusterPropagationPolicyRequest struct {
+	// ResourceVersion is the version of the policy the changes are based on, the update fails with a
+	// conflict if the policy was changed since.
+	ResourceVersion string `json:"resourceVersion" binding:"required"`
+	// Labels and Annotations replace the existing ones if set.
+	Labels      map[string]string        `json:"labels"`
+	Annotations map[string]string        `json:"annotations"`
+	Spec        v1alpha1.PropagationSpec `json:"spec"`
+}
@@ -49,3 +49,22 @@ type DeleteOverridePolicyRequest struct {
 // DeleteOverridePolicyResponse is the response body for deleting an override policy.
 type DeleteOverridePolicyResponse struct {
 }
+
+// PreviewOverridePolicyRequest is the request body for previewing the manifests an override policy renders
+// for each cluster. The target resource is given either as a manifest or as a reference to an existing one.
+type PreviewOverridePolicyRequest struct {
+	OverrideData   string `json:"overrideData" binding:"required"`
+	IsClusterScope bool   `json:"isClusterScope"`
+	Namespace      string `json:"namespace"`
+	// ResourceData is the target resource in YAML, it takes precedence over Resource.
+	ResourceData string                   `json:"resourceData"`
+	Resource     *OverridePreviewResource `json:"resource"`
+}
+
+// OverridePreviewResource refers to an existing resource in karmada apiserver.
+type OverridePreviewResource struct {
+	APIVersion string `json:"apiVersion" binding:"required"`
+	Kind       string `json:"kind" binding:"required"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name" binding:"required"`
+}
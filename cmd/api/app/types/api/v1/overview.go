@@ -19,6 +19,9 @@ package v1
 import (
 	"github.com/karmada-io/karmada/pkg/version"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/clusterhistory"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
 )
 
 // OverviewResponse represents the response structure for the overview API.
@@ -49,7 +52,7 @@ type CPUSummary struct {
 
 // MemorySummary provides a summary of memory resource usage.
 type MemorySummary struct {
-	TotalMemory     int64   `json:"totalMemory"` // Kib => 8 * KiB
+	TotalMemory     int64   `json:"totalMemory"` // bytes
 	AllocatedMemory float64 `json:"allocatedMemory"`
 }
 
@@ -65,6 +68,12 @@ type MemberClusterStatus struct {
 	CPUSummary    *CPUSummary    `json:"cpuSummary"`
 	MemorySummary *MemorySummary `json:"memorySummary"`
 	PodSummary    *PodSummary    `json:"podSummary"`
+	// Resources is the total usage of every resource reported by the member clusters, including ephemeral storage
+	// and extended resources.
+	Resources []cluster.ResourceUsage `json:"resources"`
+	// Availability is the share of time the member clusters were Ready in recent windows, it's omitted if the
+	// cluster history is disabled.
+	Availability []clusterhistory.Availability `json:"availability,omitempty"`
 }
 
 // ClusterResourceStatus represents the status of various resources in the cluster.
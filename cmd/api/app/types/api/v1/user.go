@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/karmada-io/dashboard/pkg/resource/user"
+)
+
+// CreateUserRequest is the request body for creating a dashboard user.
+type CreateUserRequest struct {
+	Name string    `json:"name" binding:"required"`
+	Role user.Role `json:"role" binding:"required"`
+	// Namespaces are the namespaces a namespace-editor may manage.
+	Namespaces []string `json:"namespaces"`
+	// ExpirationSeconds is the lifetime of the token issued for the new user, defaults to one hour.
+	ExpirationSeconds int64 `json:"expirationSeconds"`
+}
+
+// CreateUserResponse is the response body for creating a dashboard user.
+type CreateUserResponse struct {
+	User  *user.User  `json:"user"`
+	Token *user.Token `json:"token"`
+}
+
+// CreateUserTokenRequest is the request body for issuing a token of a dashboard user.
+type CreateUserTokenRequest struct {
+	// ExpirationSeconds is the lifetime of the token, defaults to one hour.
+	ExpirationSeconds int64 `json:"expirationSeconds"`
+}
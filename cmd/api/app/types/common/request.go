@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 	"github.com/karmada-io/dashboard/pkg/resource/common"
@@ -73,3 +74,12 @@ func ParseNamespacePathParameter(request *gin.Context) *common.NamespaceQuery {
 	}
 	return common.NewNamespaceQuery(nonEmptyNamespaces)
 }
+
+// ParseDryRunParameter parses the dryRun query parameter of the request, it returns the DryRun option
+// which makes the apiserver run admission and validation without persisting the object.
+func ParseDryRunParameter(request *gin.Context) []string {
+	if dryRun, _ := strconv.ParseBool(request.Query("dryRun")); dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
@@ -17,9 +17,20 @@ limitations under the License.
 package common
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ResponseCodeKey is the key of gin context which keeps the biz status code of the response.
+	ResponseCodeKey = "responseCode"
+	// ResponseMessageKey is the key of gin context which keeps the biz status message of the response.
+	ResponseMessageKey = "responseMessage"
 )
 
 // BaseResponse is the base response
@@ -27,6 +38,8 @@ type BaseResponse struct {
 	Code int         `json:"code"`
 	Msg  string      `json:"message"`
 	Data interface{} `json:"data"`
+	// Causes are the field level details of a failed request, e.g. the fields rejected by validation.
+	Causes []metav1.StatusCause `json:"causes,omitempty"`
 }
 
 // Success generate success response
@@ -43,13 +56,53 @@ func Fail(c *gin.Context, err error) {
 func Response(c *gin.Context, err error, data interface{}) {
 	code := 200          // biz status code
 	message := "success" // biz status message
+	var causes []metav1.StatusCause
 	if err != nil {
-		code = 500
+		code = statusCode(err)
 		message = err.Error()
+		var status apierrors.APIStatus
+		if errors.As(err, &status) && status.Status().Details != nil {
+			causes = status.Status().Details.Causes
+		}
 	}
+	c.Set(ResponseCodeKey, code)
+	c.Set(ResponseMessageKey, message)
 	c.JSON(http.StatusOK, BaseResponse{
+		Code:   code,
+		Msg:    message,
+		Data:   data,
+		Causes: causes,
+	})
+}
+
+// Warn adds the warnings to the response as Warning headers, in the format the apiserver uses.
+func Warn(c *gin.Context, warnings []string) {
+	for _, warning := range warnings {
+		c.Writer.Header().Add("Warning", fmt.Sprintf("299 - %q", warning))
+	}
+}
+
+// statusCode returns the biz status code of a failed request, the status of apiserver errors the client can
+// act on is kept.
+func statusCode(err error) int {
+	switch {
+	case apierrors.IsBadRequest(err):
+		return http.StatusBadRequest
+	case apierrors.IsConflict(err):
+		return http.StatusConflict
+	case apierrors.IsInvalid(err):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Abort generate fail response with the given biz status code and stops the pending handlers
+func Abort(c *gin.Context, code int, message string) {
+	c.Set(ResponseCodeKey, code)
+	c.Set(ResponseMessageKey, message)
+	c.AbortWithStatusJSON(http.StatusOK, BaseResponse{
 		Code: code,
 		Msg:  message,
-		Data: data,
 	})
 }
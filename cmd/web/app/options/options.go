@@ -32,6 +32,7 @@ type Options struct {
 	I18nDir             string
 	EnableAPIProxy      bool
 	APIProxyEndpoint    string
+	APIProxyCAFile      string
 	DashboardConfigPath string
 }
 
@@ -53,5 +54,6 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.I18nDir, "i18n-dir", "./i18n", "directory to serve i18n files")
 	fs.BoolVar(&o.EnableAPIProxy, "enable-api-proxy", true, "whether enable proxy to karmada-dashboard-api, if set true, all requests with /api prefix will be proxyed to karmada-dashboard-api.karmada-system.svc.cluster.local")
 	fs.StringVar(&o.APIProxyEndpoint, "api-proxy-endpoint", "http://karmada-dashboard-api.karmada-system.svc.cluster.local:8000", "karmada-dashboard-api endpoint")
+	fs.StringVar(&o.APIProxyCAFile, "api-proxy-ca-file", "", "file containing the ca bundle to verify the certificate of karmada-dashboard-api when --api-proxy-endpoint is https, e.g. ca.crt of the karmada-dashboard-api-cert secret")
 	fs.StringVar(&o.DashboardConfigPath, "dashboard-config-path", "./config/dashboard-config.yaml", "path to dashboard config file")
 }
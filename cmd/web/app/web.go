@@ -18,6 +18,8 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
@@ -84,17 +86,23 @@ func run(ctx context.Context, opts *options.Options) error {
 	if err != nil {
 		return err
 	}
-	serve(opts)
+	if err = serve(opts); err != nil {
+		return err
+	}
 	<-ctx.Done()
 	os.Exit(0)
 	return nil
 }
 
-func serve(opts *options.Options) {
+func serve(opts *options.Options) error {
 	insecureAddress := fmt.Sprintf("%s:%d", opts.InsecureBindAddress, opts.InsecurePort)
 	klog.V(1).InfoS("Listening and serving on", "address", insecureAddress)
 	pathPrefix := config.GetDashboardConfig().PathPrefix
 	klog.V(1).Infof("PathPrefix is:%s", pathPrefix)
+	transport, err := apiProxyTransport(opts.APIProxyCAFile)
+	if err != nil {
+		return err
+	}
 	go func() {
 		r := router.Router()
 		g := r.Group(pathPrefix)
@@ -104,6 +112,7 @@ func serve(opts *options.Options) {
 			g.Any("/api/*path", func(c *gin.Context) {
 				remote, _ := url.Parse(opts.APIProxyEndpoint)
 				proxy := httputil.NewSingleHostReverseProxy(remote)
+				proxy.Transport = transport
 				proxy.Director = func(req *http.Request) {
 					req.Header = c.Request.Header
 					req.Host = remote.Host
@@ -136,4 +145,27 @@ func serve(opts *options.Options) {
 		})
 		klog.Fatal(router.Router().Run(insecureAddress))
 	}()
+	return nil
+}
+
+// apiProxyTransport returns the transport used to proxy requests to karmada-dashboard-api,
+// the certificate of api is verified against the ca bundle in caFile if it's set.
+func apiProxyTransport(caFile string) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caFile) == 0 {
+		return transport, nil
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read api proxy ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificate found in api proxy ca file %s", caFile)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+	}
+	return transport, nil
 }
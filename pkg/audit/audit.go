@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// SinkFile writes events as json lines into a rotating file.
+	SinkFile = "file"
+	// SinkStdout writes events as json lines to stdout.
+	SinkStdout = "stdout"
+	// SinkWebhook posts events to an http endpoint.
+	SinkWebhook = "webhook"
+
+	// OutcomeSuccess is the outcome of requests which were served successfully.
+	OutcomeSuccess = "success"
+	// OutcomeFailure is the outcome of requests which failed.
+	OutcomeFailure = "failure"
+
+	// maxRecentEvents is the number of events kept in memory for querying.
+	maxRecentEvents = 5000
+)
+
+var defaultRecorder = newRecorder()
+
+// Event is the audit record of a mutating dashboard operation.
+type Event struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	// User is the name of user karmada apiserver authenticated the request as, it's empty if the request
+	// could not be authenticated.
+	User string `json:"user"`
+	// UnverifiedUser is the name claimed by the credentials of a request which could not be authenticated,
+	// it's only a hint since it may be forged.
+	UnverifiedUser string `json:"unverifiedUser,omitempty"`
+	// CredentialDigest is the sha256 digest of the token the request was sent with.
+	CredentialDigest string `json:"credentialDigest,omitempty"`
+	SourceIP         string `json:"sourceIP"`
+	Method           string `json:"method"`
+	// Route is the registered route pattern, e.g. /api/v1/cluster/:name.
+	Route     string `json:"route"`
+	Path      string `json:"path"`
+	Cluster   string `json:"cluster,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// RequestDigest is the sha256 digest of the request body, the body itself is not recorded since it may carry secrets.
+	RequestDigest string `json:"requestDigest,omitempty"`
+	Outcome       string `json:"outcome"`
+	Code          int    `json:"code"`
+	Message       string `json:"message,omitempty"`
+	LatencyMs     int64  `json:"latencyMs"`
+}
+
+// Sink is the destination audit events are written to.
+type Sink interface {
+	Write(event *Event) error
+	Close() error
+}
+
+// Config contains the settings of audit sinks.
+type Config struct {
+	// Sinks are the names of enabled sinks, events are only kept in memory if it's empty.
+	Sinks []string
+	// LogPath is the path of audit log written by the file sink.
+	LogPath string
+	// LogMaxSize is the size in megabytes the audit log is rotated at.
+	LogMaxSize int
+	// LogMaxBackups is the number of rotated audit logs kept.
+	LogMaxBackups int
+	// WebhookURL is the endpoint the webhook sink posts events to.
+	WebhookURL string
+}
+
+// Init creates the sinks of the config, recent events in the audit log are loaded so that they can be queried.
+func Init(config Config) error {
+	sinks := make([]Sink, 0, len(config.Sinks))
+	for _, name := range config.Sinks {
+		switch name {
+		case SinkFile:
+			sink, err := newFileSink(config.LogPath, config.LogMaxSize, config.LogMaxBackups)
+			if err != nil {
+				return err
+			}
+			events, err := sink.recentEvents(maxRecentEvents)
+			if err != nil {
+				klog.ErrorS(err, "Could not load recent audit events", "path", config.LogPath)
+			}
+			for _, event := range events {
+				defaultRecorder.remember(event)
+			}
+			sinks = append(sinks, sink)
+		case SinkStdout:
+			sinks = append(sinks, newStdoutSink())
+		case SinkWebhook:
+			sink, err := newWebhookSink(config.WebhookURL)
+			if err != nil {
+				return err
+			}
+			sinks = append(sinks, sink)
+		default:
+			return fmt.Errorf("unknown audit sink %q, supported sinks are %s, %s and %s", name, SinkFile, SinkStdout, SinkWebhook)
+		}
+	}
+	defaultRecorder.setSinks(sinks)
+	klog.InfoS("Audit initialized", "sinks", config.Sinks)
+	return nil
+}
+
+// Record writes the event to all sinks and keeps it in memory for querying.
+func Record(event *Event) {
+	defaultRecorder.remember(event)
+	for _, sink := range defaultRecorder.getSinks() {
+		if err := sink.Write(event); err != nil {
+			klog.ErrorS(err, "Could not write audit event", "sink", fmt.Sprintf("%T", sink), "id", event.ID)
+		}
+	}
+}
+
+// recorder keeps the sinks and a ring buffer of recent events.
+type recorder struct {
+	lock   sync.RWMutex
+	sinks  []Sink
+	events []*Event
+	next   int
+}
+
+func newRecorder() *recorder {
+	return &recorder{events: make([]*Event, 0, maxRecentEvents)}
+}
+
+func (r *recorder) setSinks(sinks []Sink) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, sink := range r.sinks {
+		_ = sink.Close()
+	}
+	r.sinks = sinks
+}
+
+func (r *recorder) getSinks() []Sink {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.sinks
+}
+
+func (r *recorder) remember(event *Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.events) < maxRecentEvents {
+		r.events = append(r.events, event)
+		return
+	}
+	r.events[r.next] = event
+	r.next = (r.next + 1) % maxRecentEvents
+}
+
+// recent returns the events kept in memory, oldest first.
+func (r *recorder) recent() []*Event {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	events := make([]*Event, 0, len(r.events))
+	events = append(events, r.events[r.next:]...)
+	events = append(events, r.events[:r.next]...)
+	return events
+}
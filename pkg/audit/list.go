@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// EventList contains a list of audit events.
+type EventList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+	Events   []Event        `json:"events"`
+}
+
+// GetEventList returns the recent audit events selected by dsQuery, the latest events come first unless
+// another sort is requested.
+func GetEventList(dsQuery *dataselect.DataSelectQuery) *EventList {
+	if dsQuery.SortQuery == nil || len(dsQuery.SortQuery.SortByList) == 0 {
+		dsQuery = dataselect.NewDataSelectQuery(dsQuery.PaginationQuery,
+			dataselect.NewSortQuery([]string{"d", dataselect.CreationTimestampProperty}), dsQuery.FilterQuery)
+	}
+	recent := defaultRecorder.recent()
+	cells := make([]dataselect.DataCell, len(recent))
+	for i := range recent {
+		cells[i] = EventCell(*recent[i])
+	}
+
+	selected, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+	list := &EventList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Events:   make([]Event, len(selected)),
+	}
+	for i := range selected {
+		list.Events[i] = Event(selected[i].(EventCell))
+	}
+	return list
+}
+
+// EventCell is a cell representation of audit Event.
+type EventCell Event
+
+// GetProperty returns value of a given property.
+func (e EventCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(e.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(e.Timestamp)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(e.Namespace)
+	case dataselect.UserProperty:
+		return dataselect.StdComparableString(e.User)
+	case dataselect.KindProperty:
+		return dataselect.StdComparableString(e.Kind)
+	case dataselect.MethodProperty:
+		return dataselect.StdComparableString(e.Method)
+	case dataselect.OutcomeProperty:
+		return dataselect.StdComparableString(e.Outcome)
+	case dataselect.ClusterProperty:
+		return dataselect.StdComparableString(e.Cluster)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultLogMaxSize = 100
+	megabyte          = 1024 * 1024
+	// webhookQueueSize is the number of events waiting to be posted, events are dropped once the queue is full
+	// so that a slow webhook never blocks the requests being audited.
+	webhookQueueSize = 1000
+	webhookTimeout   = 10 * time.Second
+)
+
+// stdoutSink writes events as json lines to stdout.
+type stdoutSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+func newStdoutSink() *stdoutSink {
+	return &stdoutSink{encoder: json.NewEncoder(os.Stdout)}
+}
+
+func (s *stdoutSink) Write(event *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.encoder.Encode(event)
+}
+
+func (s *stdoutSink) Close() error {
+	return nil
+}
+
+// fileSink writes events as json lines into a file which is rotated once it exceeds maxSize,
+// rotated files are suffixed with .1, .2 and so on, the oldest ones beyond maxBackups are removed.
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newFileSink(path string, maxSizeMB, maxBackups int) (*fileSink, error) {
+	if len(path) == 0 {
+		return nil, errors.New("audit log path must be specified for the file sink")
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultLogMaxSize
+	}
+	s := &fileSink{path: path, maxSize: int64(maxSizeMB) * megabyte, maxBackups: maxBackups}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) Write(event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if s.maxBackups > 0 {
+		_ = os.Remove(s.backupPath(s.maxBackups))
+		for i := s.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+func (s *fileSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// recentEvents reads at most limit of the latest events from the backups and the current file, oldest first.
+func (s *fileSink) recentEvents(limit int) ([]*Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	paths := make([]string, 0, s.maxBackups+1)
+	for i := s.maxBackups; i >= 1; i-- {
+		paths = append(paths, s.backupPath(i))
+	}
+	paths = append(paths, s.path)
+
+	events := make([]*Event, 0)
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return events, err
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), megabyte)
+		for scanner.Scan() {
+			event := &Event{}
+			if err = json.Unmarshal(scanner.Bytes(), event); err != nil {
+				continue
+			}
+			events = append(events, event)
+			if len(events) > limit {
+				events = events[1:]
+			}
+		}
+		_ = file.Close()
+	}
+	return events, nil
+}
+
+func (s *fileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
+
+// webhookSink posts each event as json to an http endpoint in background.
+type webhookSink struct {
+	url    string
+	client *http.Client
+	queue  chan *Event
+	done   chan struct{}
+}
+
+func newWebhookSink(endpoint string) (*webhookSink, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid audit webhook url %q: %w", endpoint, err)
+	}
+	s := &webhookSink{
+		url:    endpoint,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan *Event, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *webhookSink) Write(event *Event) error {
+	select {
+	case s.queue <- event:
+		return nil
+	default:
+		return errors.New("audit webhook queue is full, event dropped")
+	}
+}
+
+func (s *webhookSink) run() {
+	defer close(s.done)
+	for event := range s.queue {
+		if err := s.post(event); err != nil {
+			klog.ErrorS(err, "Could not post audit event", "url", s.url, "id", event.ID)
+		}
+	}
+}
+
+func (s *webhookSink) post(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until the queued ones are posted.
+func (s *webhookSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
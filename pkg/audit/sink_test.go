@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := newFileSink(path, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// shrink the limit so that every few events trigger a rotation
+	sink.maxSize = 512
+
+	total := 50
+	for i := 0; i < total; i++ {
+		if err = sink.Write(&Event{ID: fmt.Sprintf("%d", i), Path: "/api/v1/cluster"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, statErr := os.Stat(p)
+		if statErr != nil {
+			t.Fatalf("expected %s to exist: %v", p, statErr)
+		}
+		if info.Size() > sink.maxSize {
+			t.Errorf("%s has size %d, exceeds %d", p, info.Size(), sink.maxSize)
+		}
+	}
+	if _, err = os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, got %v", err)
+	}
+
+	events, err := sink.recentEvents(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 recent events, got %d", len(events))
+	}
+	for i, event := range events {
+		if want := fmt.Sprintf("%d", total-5+i); event.ID != want {
+			t.Errorf("event %d: expected id %s, got %s", i, want, event.ID)
+		}
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var lock sync.Mutex
+	received := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &Event{}
+		if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, event.ID)
+	}))
+	defer server.Close()
+
+	sink, err := newWebhookSink(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = sink.Write(&Event{ID: fmt.Sprintf("%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Close waits until the queued events are posted
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(received) != 3 {
+		t.Fatalf("expected 3 events posted, got %v", received)
+	}
+	for i, id := range received {
+		if want := fmt.Sprintf("%d", i); id != want {
+			t.Errorf("event %d: expected id %s, got %s", i, want, id)
+		}
+	}
+}
+
+func TestNewWebhookSinkRejectsInvalidURL(t *testing.T) {
+	if _, err := newWebhookSink("not a url"); err == nil {
+		t.Error("expected an error for an invalid url")
+	}
+}
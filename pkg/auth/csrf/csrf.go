@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csrf
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/xsrftoken"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// HeaderName is the header which carries the csrf token of mutating requests.
+	HeaderName = "X-CSRF-TOKEN"
+	// SecretName is the name of the secret in host cluster which holds the key csrf tokens are signed with.
+	SecretName = "karmada-dashboard-csrf"
+	secretKey  = "private.key"
+	keySize    = 256
+	// userID is fixed since tokens are not bound to a user, the browser can only read them from same origin responses.
+	userID        = "none"
+	apiPathPrefix = "/api/v1/"
+)
+
+var (
+	lock     sync.RWMutex
+	key      string
+	disabled bool
+)
+
+// Init loads the key from the secret in host cluster so that tokens issued by one replica are accepted by others,
+// the secret will be created with a random key if it does not exist.
+func Init(ctx context.Context, kubeClient kubeclient.Interface, namespace string) error {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret, err = createKeySecret(ctx, kubeClient, namespace)
+	}
+	if err != nil {
+		return err
+	}
+	if len(secret.Data[secretKey]) == 0 {
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		if secret.Data[secretKey], err = newKey(); err != nil {
+			return err
+		}
+		if secret, err = kubeClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	key = string(secret.Data[secretKey])
+	klog.InfoS("CSRF protection enabled", "namespace", namespace, "secret", SecretName)
+	return nil
+}
+
+// Disable turns off the validation of csrf tokens.
+func Disable() {
+	lock.Lock()
+	defer lock.Unlock()
+	disabled = true
+	klog.InfoS("CSRF protection disabled")
+}
+
+// Enabled reports whether csrf tokens are validated.
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return !disabled
+}
+
+// Generate returns a token for the action which is valid for xsrftoken.Timeout.
+func Generate(action string) string {
+	return xsrftoken.Generate(getKey(), userID, action)
+}
+
+// Validate reports whether the token was issued for the action and has not timed out.
+func Validate(token, action string) bool {
+	return xsrftoken.Valid(token, getKey(), userID, action)
+}
+
+// getKey returns the signing key, an ephemeral key is generated if Init has not been called.
+func getKey() string {
+	lock.RLock()
+	if len(key) > 0 {
+		defer lock.RUnlock()
+		return key
+	}
+	lock.RUnlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(key) == 0 {
+		buf, err := newKey()
+		if err != nil {
+			klog.Fatalf("Could not generate csrf key: %v", err)
+		}
+		key = string(buf)
+	}
+	return key
+}
+
+func createKeySecret(ctx context.Context, kubeClient kubeclient.Interface, namespace string) (*corev1.Secret, error) {
+	buf, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{secretKey: buf},
+	}
+	created, err := kubeClient.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// another replica won the race, use its key
+		return kubeClient.CoreV1().Secrets(namespace).Get(ctx, SecretName, metav1.GetOptions{})
+	}
+	return created, err
+}
+
+func newKey() ([]byte, error) {
+	buf := make([]byte, keySize)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// ActionFromPath returns the action a request path belongs to, it's the first segment after the api version,
+// e.g. "cluster" for /api/v1/cluster/member1.
+func ActionFromPath(path string) string {
+	if i := strings.Index(path, apiPathPrefix); i >= 0 {
+		path = path[i+len(apiPathPrefix):]
+	}
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csrf
+
+import (
+	"testing"
+)
+
+func TestActionFromPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/cluster", "cluster"},
+		{"/api/v1/cluster/member1", "cluster"},
+		{"/dashboard/api/v1/_raw/deployment/namespace/default/name/nginx", "_raw"},
+		{"/api/v1/member/member1/deployment", "member"},
+	}
+	for _, c := range cases {
+		if actual := ActionFromPath(c.path); actual != c.expected {
+			t.Errorf("ActionFromPath(%q) == %q, expected %q", c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	token := Generate("cluster")
+	if !Validate(token, "cluster") {
+		t.Error("Validate() should accept a token issued for the same action")
+	}
+	if Validate(token, "config") {
+		t.Error("Validate() should reject a token issued for another action")
+	}
+	if Validate("", "cluster") {
+		t.Error("Validate() should reject an empty token")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/client"
+)
+
+const (
+	tokenServiceAccountKey = "serviceaccount"
+)
+
+// oidcUsernameClaims are the claims of an oidc id token that may carry the name of user, in order of preference.
+var oidcUsernameClaims = []string{"preferred_username", "email", "sub"}
+
+type serviceAccount struct {
+	Name string `json:"name"`
+	UID  string `json:"uid"`
+}
+
+// UsernameFromToken returns the name of user carried in the claims of a jwt token, it's the name of service account
+// for service account tokens or the username claim for oidc id tokens. The token is not verified, an empty string is
+// returned if the token is not a jwt token.
+func UsernameFromToken(token string) string {
+	parsed, _ := jwt.Parse(token, nil)
+	if parsed == nil {
+		return ""
+	}
+
+	claims := parsed.Claims.(jwt.MapClaims)
+
+	found, value := traverse(tokenServiceAccountKey, claims)
+	if !found {
+		return getOIDCUsername(claims)
+	}
+
+	var sa serviceAccount
+	ok := transcode(value, &sa)
+	if !ok {
+		return ""
+	}
+
+	return sa.Name
+}
+
+// FromRequest returns the name of user the request is served as, for display purpose only: the name in the token of
+// request, the common name of the verified client certificate or of the client certificate in the session of request,
+// or the impersonated user in service account mode.
+func FromRequest(request *http.Request) string {
+	karmadaToken, err := client.GetTokenFromRequest(request)
+	if err == nil && len(karmadaToken) > 0 {
+		return UsernameFromToken(karmadaToken)
+	}
+	if request.TLS != nil && len(request.TLS.VerifiedChains) > 0 && len(request.TLS.VerifiedChains[0]) > 0 {
+		return request.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+	if name := usernameFromSession(request); len(name) > 0 {
+		return name
+	}
+	return request.Header.Get(client.ImpersonateUserHeader)
+}
+
+// usernameFromSession returns the common name of the client certificate the session of request was signed in with.
+func usernameFromSession(request *http.Request) string {
+	s, err := session.FromRequest(request)
+	if err != nil {
+		return ""
+	}
+	credentials, err := s.GetCredentials()
+	if err != nil || credentials == nil {
+		return ""
+	}
+	block, _ := pem.Decode(credentials.ClientCertificateData)
+	if block == nil {
+		return ""
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	return certificate.Subject.CommonName
+}
+
+func getOIDCUsername(claims jwt.MapClaims) string {
+	for _, key := range oidcUsernameClaims {
+		if name, ok := claims[key].(string); ok && len(name) > 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+func traverse(key string, m map[string]interface{}) (found bool, value interface{}) {
+	for k, v := range m {
+		if k == key {
+			return true, v
+		}
+
+		if innerMap, ok := v.(map[string]interface{}); ok {
+			return traverse(key, innerMap)
+		}
+	}
+
+	return false, ""
+}
+
+func transcode(in, out interface{}) bool {
+	buf := new(bytes.Buffer)
+	err := json.NewEncoder(buf).Encode(in)
+	if err != nil {
+		return false
+	}
+
+	err = json.NewDecoder(buf).Decode(out)
+	return err == nil
+}
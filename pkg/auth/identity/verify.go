@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"net/http"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/resource/permission"
+)
+
+// Review authenticates the credentials of request with karmada apiserver and returns the user they belong to.
+// The token of request is reviewed with a TokenReview sent by dashboard itself, if the request carries no token
+// or dashboard is not allowed to review tokens, the user reviews itself with kubeClient instead.
+func Review(request *http.Request, kubeClient kubeclient.Interface) (*permission.UserInfo, error) {
+	karmadaToken, err := client.GetTokenFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(karmadaToken) > 0 {
+		userInfo, err := permission.ReviewToken(client.InClusterClientForKarmadaAPIServer(), karmadaToken)
+		if err == nil || !errors.IsForbidden(err) {
+			return userInfo, err
+		}
+		klog.V(2).InfoS("Dashboard is not allowed to review tokens, falling back to SelfSubjectReview", "err", err)
+	}
+	return permission.ReviewSelf(kubeClient)
+}
+
+// Verify returns the name of user karmada apiserver authenticates the request as. Unlike FromRequest, the name
+// can be relied upon to authorize the access to state kept by dashboard itself, e.g. operations. Anonymous
+// requests are rejected as unauthorized.
+func Verify(request *http.Request) (string, error) {
+	kubeClient, err := client.GetKarmadaKubeClientFromRequest(request)
+	if err != nil {
+		return "", err
+	}
+	userInfo, err := Review(request, kubeClient)
+	if err != nil {
+		return "", err
+	}
+	if len(userInfo.Username) == 0 || userInfo.Username == user.Anonymous {
+		return "", errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+	}
+	return userInfo.Username, nil
+}
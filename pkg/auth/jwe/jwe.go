@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jwe
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	// algorithm is the key management algorithm, the content encryption key is used directly.
+	algorithm = "dir"
+	// encryption is the content encryption algorithm.
+	encryption = "A256GCM"
+)
+
+// ErrInvalidToken is returned when a token can not be decrypted with any of the known keys.
+var ErrInvalidToken = errors.New("invalid jwe token")
+
+type header struct {
+	Algorithm  string `json:"alg"`
+	Encryption string `json:"enc"`
+	KeyID      string `json:"kid"`
+}
+
+// IsJWE reports whether the token looks like a JWE in compact serialization.
+func IsJWE(token string) bool {
+	return strings.Count(token, ".") == 4
+}
+
+// Encrypt encrypts the payload with the current key and returns it as a JWE in compact serialization.
+func Encrypt(payload []byte) (string, error) {
+	k := holder.current()
+	rawHeader, err := json.Marshal(header{Algorithm: algorithm, Encryption: encryption, KeyID: k.ID})
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(k.Key)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	protected := encode(rawHeader)
+	sealed := gcm.Seal(nil, iv, payload, []byte(protected))
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	// the encrypted key is empty for direct encryption
+	return strings.Join([]string{protected, "", encode(iv), encode(ciphertext), encode(tag)}, "."), nil
+}
+
+// Decrypt decrypts a JWE in compact serialization which was issued by Encrypt.
+func Decrypt(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || len(parts[1]) != 0 {
+		return nil, ErrInvalidToken
+	}
+	rawHeader, err := decode(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	h := header{}
+	if err = json.Unmarshal(rawHeader, &h); err != nil || h.Algorithm != algorithm || h.Encryption != encryption {
+		return nil, ErrInvalidToken
+	}
+	key, ok := holder.get(h.KeyID)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := decode(parts[2])
+	if err != nil || len(iv) != gcm.NonceSize() {
+		return nil, ErrInvalidToken
+	}
+	ciphertext, err := decode(parts[3])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	tag, err := decode(parts[4])
+	if err != nil || len(tag) != gcm.Overhead() {
+		return nil, ErrInvalidToken
+	}
+	payload, err := gcm.Open(nil, iv, append(ciphertext, tag...), []byte(parts[0]))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return payload, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encode(buf []byte) string {
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
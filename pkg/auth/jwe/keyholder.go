@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jwe
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// SecretName is the name of the secret in host cluster which holds the encryption keys, the ids of revoked
+	// dashboard tokens are kept in it as well.
+	SecretName = "karmada-dashboard-encryption-key"
+	secretKey  = "keys"
+	// maxKeys is the number of keys kept, the previous key stays valid for one more rotation interval
+	// so that tokens issued right before a rotation can still be decrypted.
+	maxKeys = 2
+	// keySyncPeriod is how often the secret will be read again to pick up keys rotated by other replicas.
+	keySyncPeriod = time.Minute
+)
+
+var holder = &keyHolder{}
+
+type key struct {
+	ID        string    `json:"id"`
+	Key       []byte    `json:"key"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// keyHolder holds the encryption keys, newest first. Before Init is called an ephemeral key is used,
+// tokens encrypted with it can not be decrypted by other replicas or after a restart.
+type keyHolder struct {
+	lock sync.RWMutex
+	keys []key
+}
+
+func (h *keyHolder) current() key {
+	h.lock.RLock()
+	if len(h.keys) > 0 {
+		defer h.lock.RUnlock()
+		return h.keys[0]
+	}
+	h.lock.RUnlock()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if len(h.keys) == 0 {
+		k, err := newKey()
+		if err != nil {
+			klog.Fatalf("Could not generate encryption key: %v", err)
+		}
+		h.keys = []key{k}
+	}
+	return h.keys[0]
+}
+
+func (h *keyHolder) get(id string) ([]byte, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for _, k := range h.keys {
+		if k.ID == id {
+			return k.Key, true
+		}
+	}
+	return nil, false
+}
+
+func (h *keyHolder) set(keys []key) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.keys = keys
+}
+
+// Init loads the encryption keys from the secret in host cluster, the secret will be created if it does not exist.
+// The current key is rotated once it's older than rotationInterval, all replicas share the keys through the secret.
+func Init(ctx context.Context, kubeClient kubeclient.Interface, namespace string, rotationInterval time.Duration) error {
+	// a conflict means another replica rotated the keys at the same time, just read them again
+	if err := retry.OnError(retry.DefaultBackoff, apierrors.IsConflict, func() error {
+		return syncKeys(ctx, kubeClient, namespace, rotationInterval)
+	}); err != nil {
+		return err
+	}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := syncKeys(ctx, kubeClient, namespace, rotationInterval); err != nil {
+			klog.ErrorS(err, "Could not sync encryption keys", "namespace", namespace, "secret", SecretName)
+		}
+	}, keySyncPeriod)
+	return nil
+}
+
+func syncKeys(ctx context.Context, kubeClient kubeclient.Interface, namespace string, rotationInterval time.Duration) error {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret, err = createKeySecret(ctx, kubeClient, namespace)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys := make([]key, 0, maxKeys)
+	if err = json.Unmarshal(secret.Data[secretKey], &keys); err != nil || len(keys) == 0 {
+		klog.ErrorS(err, "Encryption keys in secret are corrupted, generating new ones", "namespace", namespace, "secret", SecretName)
+		keys = nil
+	}
+	if len(keys) == 0 || time.Since(keys[0].CreatedAt) >= rotationInterval {
+		if keys, err = rotateKeys(ctx, kubeClient, secret, keys); err != nil {
+			return err
+		}
+	}
+	holder.set(keys)
+	return nil
+}
+
+func createKeySecret(ctx context.Context, kubeClient kubeclient.Interface, namespace string) (*corev1.Secret, error) {
+	k, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal([]key{k})
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{secretKey: data},
+	}
+	created, err := kubeClient.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// another replica won the race, use its keys
+		return kubeClient.CoreV1().Secrets(namespace).Get(ctx, SecretName, metav1.GetOptions{})
+	}
+	return created, err
+}
+
+// rotateKeys prepends a new key and writes the keys back, the update is guarded by the resourceVersion
+// of secret so that only one replica rotates the keys.
+func rotateKeys(ctx context.Context, kubeClient kubeclient.Interface, secret *corev1.Secret, keys []key) ([]key, error) {
+	k, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	keys = append([]key{k}, keys...)
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+	}
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[secretKey] = data
+	if _, err = kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to rotate encryption keys: %w", err)
+	}
+	klog.InfoS("Rotated encryption keys", "namespace", secret.Namespace, "secret", SecretName, "kid", k.ID)
+	return keys, nil
+}
+
+func newKey() (key, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return key{}, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return key{}, err
+	}
+	return key{ID: hex.EncodeToString(id), Key: buf, CreatedAt: time.Now()}, nil
+}
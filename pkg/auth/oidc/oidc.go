@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+)
+
+const (
+	// ProviderName is the name of provider recorded in sessions issued by oidc login.
+	ProviderName = "oidc"
+	// StateCookieName is the name of the cookie which ties an authorization request to the browser that started it.
+	StateCookieName = "karmada-dashboard-oidc-state"
+	// pendingAuthTTL is how long an authorization request can wait for its callback.
+	pendingAuthTTL = 10 * time.Minute
+	wellKnownPath  = "/.well-known/openid-configuration"
+)
+
+var defaultProvider *Provider
+
+// Config contains the settings of the OIDC provider used for login.
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// JWKSURL overrides the jwks_uri advertised by the discovery document.
+	JWKSURL string
+	// HTTPClient is used to talk to the issuer, http.DefaultClient is used if not set.
+	HTTPClient *http.Client
+}
+
+// Token is the result of a successful authorization code exchange or refresh.
+type Token struct {
+	IDToken      string
+	RefreshToken string
+	Expiry       time.Time
+	Claims       *Claims
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// pendingAuth is an authorization request waiting for its callback, it's kept encrypted in the state cookie.
+type pendingAuth struct {
+	State    string    `json:"state"`
+	Verifier string    `json:"verifier"`
+	Nonce    string    `json:"nonce"`
+	Expiry   time.Time `json:"expiry"`
+}
+
+// Provider drives the authorization code flow with PKCE against an OIDC issuer.
+type Provider struct {
+	issuer       string
+	oauth2Config oauth2.Config
+	keys         *keySet
+	httpClient   *http.Client
+}
+
+// Init initializes the default provider used by login handlers and registers it as session refresher.
+func Init(ctx context.Context, config Config) error {
+	provider, err := NewProvider(ctx, config)
+	if err != nil {
+		return err
+	}
+	defaultProvider = provider
+	session.RegisterRefresher(ProviderName, func(ctx context.Context, s *session.Session) error {
+		token, err := provider.Refresh(ctx, s.RefreshToken)
+		if err != nil {
+			return err
+		}
+		s.Token = token.IDToken
+		s.RefreshToken = token.RefreshToken
+		s.TokenExpiry = token.Expiry
+		return nil
+	})
+	klog.InfoS("OIDC login enabled", "issuer", config.IssuerURL)
+	return nil
+}
+
+// GetProvider returns the default provider, nil will be returned if oidc login is not enabled.
+func GetProvider() *Provider {
+	return defaultProvider
+}
+
+// NewProvider discovers the endpoints of the issuer and returns a Provider.
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	if len(config.IssuerURL) == 0 || len(config.ClientID) == 0 {
+		return nil, errors.New("issuer url and client id of oidc provider must be specified")
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	doc, err := discover(ctx, httpClient, config.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(config.IssuerURL, "/") {
+		return nil, fmt.Errorf("issuer %q of discovery document does not match configured issuer %q", doc.Issuer, config.IssuerURL)
+	}
+	jwksURL := doc.JWKSURI
+	if len(config.JWKSURL) > 0 {
+		jwksURL = config.JWKSURL
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email", "offline_access"}
+	}
+	return &Provider{
+		issuer: doc.Issuer,
+		oauth2Config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  doc.AuthorizationEndpoint,
+				TokenURL: doc.TokenEndpoint,
+			},
+		},
+		keys:       newKeySet(jwksURL, httpClient),
+		httpClient: httpClient,
+	}, nil
+}
+
+func discover(ctx context.Context, httpClient *http.Client, issuerURL string) (*discoveryDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuerURL, "/")+wellKnownPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch discovery document: unexpected status %s", resp.Status)
+	}
+	doc := &discoveryDocument{}
+	if err = json.NewDecoder(resp.Body).Decode(doc); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+	return doc, nil
+}
+
+// AuthCodeURL starts a new authorization request and returns the url the browser should be redirected to,
+// along with the state cookie which must be set in the browser for the callback to be accepted.
+func (p *Provider) AuthCodeURL() (string, *http.Cookie, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", nil, err
+	}
+	pending := pendingAuth{
+		State:    state,
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    nonce,
+		Expiry:   time.Now().Add(pendingAuthTTL),
+	}
+	rawPending, err := json.Marshal(pending)
+	if err != nil {
+		return "", nil, err
+	}
+	value, err := jwe.Encrypt(rawPending)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cookie := &http.Cookie{
+		Name:     StateCookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(pendingAuthTTL.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return p.oauth2Config.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(pending.Verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), cookie, nil
+}
+
+// Exchange finishes the authorization request identified by state, which must be the one recorded in the
+// state cookie of the browser. The returned id token has been verified.
+func (p *Provider) Exchange(ctx context.Context, stateCookie *http.Cookie, state, code string) (*Token, error) {
+	if stateCookie == nil || len(stateCookie.Value) == 0 {
+		return nil, errors.New("oidc login was not started by this browser")
+	}
+	rawPending, err := jwe.Decrypt(stateCookie.Value)
+	if err != nil {
+		return nil, errors.New("invalid oidc login state")
+	}
+	pending := pendingAuth{}
+	if err = json.Unmarshal(rawPending, &pending); err != nil {
+		return nil, errors.New("invalid oidc login state")
+	}
+	if subtle.ConstantTimeCompare([]byte(pending.State), []byte(state)) != 1 {
+		return nil, errors.New("oidc login state does not match the one started by this browser")
+	}
+	if time.Now().After(pending.Expiry) {
+		return nil, errors.New("oidc login state expired")
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(pending.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return p.toToken(ctx, token, pending.Nonce)
+}
+
+// ClearStateCookie returns a cookie which clears the state cookie in browser.
+func ClearStateCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     StateCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// Refresh exchanges the refresh token for a new id token.
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if len(token.RefreshToken) == 0 {
+		token.RefreshToken = refreshToken
+	}
+	return p.toToken(ctx, token, "")
+}
+
+func (p *Provider) toToken(ctx context.Context, token *oauth2.Token, nonce string) (*Token, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || len(rawIDToken) == 0 {
+		return nil, errors.New("no id_token in token response")
+	}
+	claims, err := p.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		IDToken:      rawIDToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       claims.ExpiresAt.Time,
+		Claims:       claims,
+	}, nil
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
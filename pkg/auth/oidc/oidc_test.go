@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "karmada-dashboard"
+	testKid      = "test-key"
+)
+
+// stubIssuer is a minimal OIDC issuer which supports the authorization code flow with PKCE and refresh tokens.
+type stubIssuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	audience  string
+	challenge string
+	nonce     string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &stubIssuer{key: key, audience: testClientID}
+	mux := http.NewServeMux()
+	mux.HandleFunc(wellKnownPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                s.server.URL,
+			AuthorizationEndpoint: s.server.URL + "/authorize",
+			TokenEndpoint:         s.server.URL + "/token",
+			JWKSURI:               s.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []jsonWebKey{{
+				Kid: testKid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		nonce := ""
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != s.challenge {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			nonce = s.nonce
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"refresh_token": "refresh-1",
+			"id_token":      s.sign(t, nonce),
+		})
+	})
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *stubIssuer) sign(t *testing.T, nonce string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.server.URL,
+			Subject:   "alice",
+			Audience:  jwt.ClaimStrings{s.audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		Nonce:             nonce,
+		PreferredUsername: "alice",
+		Groups:            []string{"system:authenticated", "karmada-admins"},
+	})
+	token.Header["kid"] = testKid
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// authorize records the PKCE challenge and nonce of the auth code url like the authorization endpoint would.
+func (s *stubIssuer) authorize(t *testing.T, authCodeURL string) string {
+	u, err := url.Parse(authCodeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected S256 code challenge, got %q", query.Get("code_challenge_method"))
+	}
+	s.challenge = query.Get("code_challenge")
+	s.nonce = query.Get("nonce")
+	return query.Get("state")
+}
+
+func newTestProvider(t *testing.T, issuer *stubIssuer) *Provider {
+	provider, err := NewProvider(context.TODO(), Config{
+		IssuerURL:   issuer.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/api/v1/login/oidc/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestExchange(t *testing.T) {
+	issuer := newStubIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	authCodeURL, stateCookie, err := provider.AuthCodeURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stateCookie.HttpOnly || stateCookie.SameSite != http.SameSiteLaxMode || stateCookie.MaxAge <= 0 {
+		t.Errorf("AuthCodeURL() returned unexpected state cookie %+v", stateCookie)
+	}
+	state := issuer.authorize(t, authCodeURL)
+
+	token, err := provider.Exchange(context.TODO(), stateCookie, state, "code")
+	if err != nil {
+		t.Fatalf("Exchange() returned error: %v", err)
+	}
+	if token.Claims.PreferredUsername != "alice" || token.RefreshToken != "refresh-1" {
+		t.Errorf("Exchange() returned unexpected token %+v", token)
+	}
+}
+
+func TestExchangeRejectsState(t *testing.T) {
+	issuer := newStubIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	authCodeURL, stateCookie, err := provider.AuthCodeURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := issuer.authorize(t, authCodeURL)
+	// a login started by another browser
+	_, otherStateCookie, err := provider.AuthCodeURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		stateCookie *http.Cookie
+	}{
+		{name: "no state cookie", stateCookie: nil},
+		{name: "state cookie of another login", stateCookie: otherStateCookie},
+		{name: "forged state cookie", stateCookie: &http.Cookie{Name: StateCookieName, Value: state}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := provider.Exchange(context.TODO(), c.stateCookie, state, "code"); err == nil {
+				t.Error("Exchange() should fail")
+			}
+		})
+	}
+	if _, err = provider.Exchange(context.TODO(), stateCookie, state, "code"); err != nil {
+		t.Errorf("Exchange() with the state cookie of the login returned error: %v", err)
+	}
+}
+
+func TestExchangeRejectsNonceMismatch(t *testing.T) {
+	issuer := newStubIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	authCodeURL, stateCookie, err := provider.AuthCodeURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := issuer.authorize(t, authCodeURL)
+	issuer.nonce = "forged"
+
+	if _, err = provider.Exchange(context.TODO(), stateCookie, state, "code"); err == nil {
+		t.Error("Exchange() should fail when nonce of id token does not match")
+	}
+}
+
+func TestVerifyIDTokenRejectsOtherAudience(t *testing.T) {
+	issuer := newStubIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	issuer.audience = "another-client"
+	if _, err := provider.VerifyIDToken(context.TODO(), issuer.sign(t, ""), ""); err == nil {
+		t.Error("VerifyIDToken() should fail for tokens issued to another client")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	issuer := newStubIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	token, err := provider.Refresh(context.TODO(), "refresh-1")
+	if err != nil {
+		t.Fatalf("Refresh() returned error: %v", err)
+	}
+	if len(token.IDToken) == 0 || token.Expiry.Before(time.Now()) {
+		t.Errorf("Refresh() returned unexpected token %+v", token)
+	}
+
+	if _, err = provider.Refresh(context.TODO(), "revoked"); err == nil {
+		t.Error("Refresh() with an invalid refresh token should fail")
+	}
+}
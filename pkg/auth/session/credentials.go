@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"encoding/json"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+)
+
+// Credentials are the karmada credentials a user signed in with, e.g. the ones extracted from an uploaded kubeconfig.
+type Credentials struct {
+	Token                 string `json:"token,omitempty"`
+	ClientCertificateData []byte `json:"clientCertificateData,omitempty"`
+	ClientKeyData         []byte `json:"clientKeyData,omitempty"`
+}
+
+// SetCredentials encrypts the credentials and keeps them in the session, so that private keys are never held
+// in memory in plain text longer than a request.
+func (s *Session) SetCredentials(credentials *Credentials) error {
+	payload, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	encrypted, err := jwe.Encrypt(payload)
+	if err != nil {
+		return err
+	}
+	s.EncryptedCredentials = encrypted
+	return nil
+}
+
+// GetCredentials decrypts the credentials kept in the session, nil is returned if the session carries none.
+func (s *Session) GetCredentials() (*Credentials, error) {
+	if len(s.EncryptedCredentials) == 0 {
+		return nil, nil
+	}
+	payload, err := jwe.Decrypt(s.EncryptedCredentials)
+	if err != nil {
+		return nil, err
+	}
+	credentials := &Credentials{}
+	if err = json.Unmarshal(payload, credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+)
+
+const (
+	// sessionSecretPrefix prefixes the names of the secrets which hold sessions.
+	sessionSecretPrefix = "karmada-dashboard-session-"
+	// sessionLabel marks the secrets which hold sessions.
+	sessionLabel = "dashboard.karmada.io/session"
+	// expiresAtAnnotation records when the session lapses so that it can be swept without being decrypted.
+	expiresAtAnnotation = "dashboard.karmada.io/session-expires-at"
+	sessionKey          = "session"
+)
+
+// secretStore keeps every session encrypted in a secret of host cluster, so that sessions are shared between
+// replicas and survive restarts of the api.
+type secretStore struct {
+	kubeClient kubeclient.Interface
+	namespace  string
+}
+
+func newSecretStore(kubeClient kubeclient.Interface, namespace string) *secretStore {
+	return &secretStore{kubeClient: kubeClient, namespace: namespace}
+}
+
+// secretName derives the name of the secret from the session id, the id itself must not be readable
+// by whoever may list secrets.
+func secretName(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return sessionSecretPrefix + hex.EncodeToString(sum[:])
+}
+
+func (st *secretStore) get(ctx context.Context, id string) (*Session, error) {
+	secret, err := st.kubeClient.CoreV1().Secrets(st.namespace).Get(ctx, secretName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	// sessions encrypted with a key that has been rotated out can not be read anymore
+	payload, err := jwe.Decrypt(string(secret.Data[sessionKey]))
+	if err != nil {
+		return nil, ErrNoSession
+	}
+	s := &Session{}
+	if err = json.Unmarshal(payload, s); err != nil || s.ID != id {
+		return nil, ErrNoSession
+	}
+	return s, nil
+}
+
+func (st *secretStore) put(ctx context.Context, s *Session) error {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	encrypted, err := jwe.Encrypt(payload)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName(s.ID),
+			Namespace:   st.namespace,
+			Labels:      map[string]string{sessionLabel: "true"},
+			Annotations: map[string]string{expiresAtAnnotation: s.ExpiresAt.UTC().Format(time.RFC3339)},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{sessionKey: []byte(encrypted)},
+	}
+	_, err = st.kubeClient.CoreV1().Secrets(st.namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = st.kubeClient.CoreV1().Secrets(st.namespace).Create(ctx, secret, metav1.CreateOptions{})
+	}
+	return err
+}
+
+func (st *secretStore) delete(ctx context.Context, id string) error {
+	err := st.kubeClient.CoreV1().Secrets(st.namespace).Delete(ctx, secretName(id), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (st *secretStore) sweep(ctx context.Context, now time.Time) error {
+	secrets, err := st.kubeClient.CoreV1().Secrets(st.namespace).List(ctx, metav1.ListOptions{LabelSelector: sessionLabel + "=true"})
+	if err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[expiresAtAnnotation])
+		if err == nil && !now.After(expiresAt) {
+			continue
+		}
+		// another replica may have swept it already
+		err = st.kubeClient.CoreV1().Secrets(st.namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &secret.ResourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return nil
+}
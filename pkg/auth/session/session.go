@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+)
+
+const (
+	// CookieName is the name of the cookie which carries the encrypted session id.
+	CookieName = "karmada-dashboard-session"
+	// refreshSkew is how long before the expiry of the token a refresh will be attempted.
+	refreshSkew = 30 * time.Second
+	// touchPeriod is how far the idle ttl of a session must have moved before it's written back to the store,
+	// so that not every request writes the store.
+	touchPeriod = time.Minute
+)
+
+var (
+	// ErrNoSession is returned when the request does not belong to any valid session.
+	ErrNoSession = errors.New("no valid session found")
+
+	store      sessionStore = newMemoryStore()
+	refreshers sync.Map
+)
+
+// Session holds the credentials of a logged-in user on the server side,
+// the browser only gets an encrypted reference to it.
+type Session struct {
+	ID           string
+	Provider     string
+	Token        string
+	RefreshToken string
+	TokenExpiry  time.Time
+	CreatedAt    time.Time
+	// ExpiresAt is when the session lapses, it's extended on every use within the ttl of dashboard tokens.
+	ExpiresAt time.Time
+	// EncryptedCredentials are the credentials set by SetCredentials, encrypted with the dashboard keys.
+	EncryptedCredentials string
+}
+
+// Init keeps sessions in secrets of the given namespace in host cluster, so that all replicas share them,
+// and starts removing the sessions which lapsed until ctx is done.
+func Init(ctx context.Context, kubeClient kubeclient.Interface, namespace string) {
+	store = newSecretStore(kubeClient, namespace)
+	go sweepPeriodically(ctx, store)
+}
+
+// Refresher renews the token of a session, it's registered by the login provider which issued the session.
+type Refresher func(ctx context.Context, s *Session) error
+
+// RegisterRefresher registers the refresher for sessions issued by the given provider.
+func RegisterRefresher(provider string, refresher Refresher) {
+	refreshers.Store(provider, refresher)
+}
+
+// New stores the session on the server side and returns the cookie that refers to it.
+func New(ctx context.Context, s *Session) (*http.Cookie, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	s.ID = id
+	s.CreatedAt = time.Now()
+	s.ExpiresAt = token.ExpiresAt(s.CreatedAt)
+	value, err := jwe.Encrypt([]byte(id))
+	if err != nil {
+		return nil, err
+	}
+	if err = store.put(ctx, s); err != nil {
+		return nil, err
+	}
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}, nil
+}
+
+// FromRequest returns the session the request belongs to and extends its idle ttl, token.ErrExpired is returned
+// if the session lapsed. The token of the session will be refreshed if it's about to expire and the provider
+// supports refreshing.
+func FromRequest(request *http.Request) (*Session, error) {
+	s, err := lookup(request)
+	if err != nil {
+		return nil, err
+	}
+	ctx := request.Context()
+	if time.Now().After(s.ExpiresAt) {
+		deleteSession(ctx, s.ID)
+		return nil, token.ErrExpired
+	}
+	if expiresAt := token.ExpiresAt(s.CreatedAt); expiresAt.Sub(s.ExpiresAt) >= touchPeriod {
+		s.ExpiresAt = expiresAt
+		if err = store.put(ctx, s); err != nil {
+			klog.ErrorS(err, "Could not extend session")
+		}
+	}
+
+	if s.TokenExpiry.IsZero() || time.Now().Add(refreshSkew).Before(s.TokenExpiry) {
+		return s, nil
+	}
+	if err = refresh(ctx, s); err != nil {
+		klog.ErrorS(err, "Could not refresh session token", "provider", s.Provider)
+		deleteSession(ctx, s.ID)
+		return nil, ErrNoSession
+	}
+	return s, nil
+}
+
+// Delete removes the session the request belongs to, it returns a cookie which clears the session cookie in browser.
+func Delete(request *http.Request) *http.Cookie {
+	if s, err := lookup(request); err == nil {
+		deleteSession(request.Context(), s.ID)
+	}
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func lookup(request *http.Request) (*Session, error) {
+	if request == nil {
+		return nil, ErrNoSession
+	}
+	cookie, err := request.Cookie(CookieName)
+	if err != nil || len(cookie.Value) == 0 {
+		return nil, ErrNoSession
+	}
+	id, err := jwe.Decrypt(cookie.Value)
+	if err != nil {
+		return nil, ErrNoSession
+	}
+	s, err := store.get(request.Context(), string(id))
+	if err != nil {
+		if !errors.Is(err, ErrNoSession) {
+			klog.ErrorS(err, "Could not read session")
+		}
+		return nil, ErrNoSession
+	}
+	return s, nil
+}
+
+func deleteSession(ctx context.Context, id string) {
+	if err := store.delete(ctx, id); err != nil {
+		klog.ErrorS(err, "Could not delete session")
+	}
+}
+
+func refresh(ctx context.Context, s *Session) error {
+	if len(s.RefreshToken) == 0 {
+		return fmt.Errorf("token of session expired and no refresh token is available")
+	}
+	value, ok := refreshers.Load(s.Provider)
+	if !ok {
+		return fmt.Errorf("no refresher registered for provider %s", s.Provider)
+	}
+	refreshed := *s
+	if err := value.(Refresher)(ctx, &refreshed); err != nil {
+		return err
+	}
+	if err := store.put(ctx, &refreshed); err != nil {
+		return err
+	}
+	*s = refreshed
+	return nil
+}
+
+func newSessionID() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
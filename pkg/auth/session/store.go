@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// sweepPeriod is how often the sessions which lapsed are removed from the store.
+const sweepPeriod = time.Minute
+
+// sessionStore keeps the sessions on the server side, get returns ErrNoSession for unknown sessions.
+type sessionStore interface {
+	get(ctx context.Context, id string) (*Session, error)
+	put(ctx context.Context, s *Session) error
+	delete(ctx context.Context, id string) error
+	// sweep removes the sessions which lapsed before now.
+	sweep(ctx context.Context, now time.Time) error
+}
+
+// memoryStore keeps sessions in memory of the api process. It's only used until Init is called, sessions
+// kept in it are neither shared between replicas nor kept across restarts.
+type memoryStore struct {
+	lock     sync.RWMutex
+	sessions map[string]*Session
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		sessions: make(map[string]*Session),
+	}
+}
+
+func (m *memoryStore) get(_ context.Context, id string) (*Session, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrNoSession
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (m *memoryStore) put(_ context.Context, s *Session) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	copied := *s
+	m.sessions[s.ID] = &copied
+	return nil
+}
+
+func (m *memoryStore) delete(_ context.Context, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *memoryStore) sweep(_ context.Context, now time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for id, existing := range m.sessions {
+		if now.After(existing.ExpiresAt) {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
+
+// sweepPeriodically sweeps the store every sweepPeriod until ctx is done.
+func sweepPeriodically(ctx context.Context, s sessionStore) {
+	ticker := time.NewTicker(sweepPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.sweep(ctx, now); err != nil {
+				klog.ErrorS(err, "Could not remove lapsed sessions")
+			}
+		}
+	}
+}
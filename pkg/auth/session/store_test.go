@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStoreSweep(t *testing.T) {
+	cases := []struct {
+		name  string
+		store sessionStore
+	}{
+		{name: "memory store", store: newMemoryStore()},
+		{name: "secret store", store: newSecretStore(fake.NewSimpleClientset(), "karmada-system")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.TODO()
+			now := time.Now()
+			for _, s := range []*Session{
+				{ID: "lapsed", Token: "lapsed-token", ExpiresAt: now.Add(-time.Minute)},
+				{ID: "active", Token: "active-token", ExpiresAt: now.Add(time.Minute)},
+			} {
+				if err := c.store.put(ctx, s); err != nil {
+					t.Fatalf("put() returned error %v", err)
+				}
+			}
+			if s, err := c.store.get(ctx, "lapsed"); err != nil || s.Token != "lapsed-token" {
+				t.Fatalf("expected put not to sweep the store, got %+v, %v", s, err)
+			}
+
+			if err := c.store.sweep(ctx, now); err != nil {
+				t.Fatalf("sweep() returned error %v", err)
+			}
+			if _, err := c.store.get(ctx, "lapsed"); !errors.Is(err, ErrNoSession) {
+				t.Errorf("expected the lapsed session to be swept, got %v", err)
+			}
+			if _, err := c.store.get(ctx, "active"); err != nil {
+				t.Errorf("expected the active session to be kept, got %v", err)
+			}
+
+			if err := c.store.delete(ctx, "active"); err != nil {
+				t.Fatalf("delete() returned error %v", err)
+			}
+			if _, err := c.store.get(ctx, "active"); !errors.Is(err, ErrNoSession) {
+				t.Errorf("expected the deleted session to be gone, got %v", err)
+			}
+		})
+	}
+}
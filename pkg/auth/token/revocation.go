@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+)
+
+const (
+	// revocationSecretKey is the key of the revoked token ids in the secret of encryption keys.
+	revocationSecretKey = "revoked"
+	// revocationSyncPeriod is how often the secret will be read again to pick up tokens revoked by other replicas.
+	revocationSyncPeriod = 10 * time.Second
+)
+
+// Init loads the revoked token ids from the secret of encryption keys in host cluster, which must have been
+// created by jwe.Init, and keeps them in sync with the revocations of other replicas.
+func Init(ctx context.Context, kubeClient kubeclient.Interface, namespace string) error {
+	revoked.lock.Lock()
+	revoked.kubeClient = kubeClient
+	revoked.namespace = namespace
+	revoked.lock.Unlock()
+	if err := revoked.sync(ctx); err != nil {
+		return err
+	}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := revoked.sync(ctx); err != nil {
+			klog.ErrorS(err, "Could not sync revoked tokens", "namespace", namespace, "secret", jwe.SecretName)
+		}
+	}, revocationSyncPeriod)
+	return nil
+}
+
+// revocationList remembers revoked token ids until the tokens expire. Before Init is called it's only kept in memory.
+type revocationList struct {
+	lock       sync.Mutex
+	ids        map[string]time.Time
+	kubeClient kubeclient.Interface
+	namespace  string
+}
+
+func newRevocationList() *revocationList {
+	return &revocationList{ids: make(map[string]time.Time)}
+}
+
+func (r *revocationList) add(ctx context.Context, id string, expiry time.Time) error {
+	r.lock.Lock()
+	r.ids[id] = expiry
+	pruneRevocations(r.ids)
+	kubeClient, namespace := r.kubeClient, r.namespace
+	r.lock.Unlock()
+	if kubeClient == nil {
+		return nil
+	}
+
+	// the secret is also updated by the rotation of encryption keys and by other replicas
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, jwe.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ids := readRevocations(secret.Data[revocationSecretKey])
+		ids[id] = expiry
+		pruneRevocations(ids)
+		data, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[revocationSecretKey] = data
+		if _, err = kubeClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to persist revoked token: %w", err)
+		}
+		r.merge(ids)
+		return nil
+	})
+}
+
+func (r *revocationList) contains(id string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, ok := r.ids[id]
+	return ok
+}
+
+// sync merges the revoked token ids persisted in the secret into the list.
+func (r *revocationList) sync(ctx context.Context) error {
+	r.lock.Lock()
+	kubeClient, namespace := r.kubeClient, r.namespace
+	r.lock.Unlock()
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, jwe.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r.merge(readRevocations(secret.Data[revocationSecretKey]))
+	return nil
+}
+
+func (r *revocationList) merge(ids map[string]time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for id, expiry := range ids {
+		r.ids[id] = expiry
+	}
+	pruneRevocations(r.ids)
+}
+
+func readRevocations(data []byte) map[string]time.Time {
+	ids := make(map[string]time.Time)
+	if len(data) == 0 {
+		return ids
+	}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		klog.ErrorS(err, "Revoked tokens in secret are corrupted, dropping them", "secret", jwe.SecretName)
+		return make(map[string]time.Time)
+	}
+	return ids
+}
+
+// pruneRevocations drops the ids of tokens which expired by themselves.
+func pruneRevocations(ids map[string]time.Time) {
+	now := time.Now()
+	for id, expiry := range ids {
+		if now.After(expiry) {
+			delete(ids, id)
+		}
+	}
+}
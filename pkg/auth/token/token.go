@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+)
+
+const (
+	// DefaultIdleTTL is the default duration a dashboard token stays valid without being refreshed.
+	DefaultIdleTTL = 30 * time.Minute
+	// DefaultAbsoluteTTL is the default duration after login that a dashboard token can be refreshed.
+	DefaultAbsoluteTTL = 12 * time.Hour
+)
+
+var (
+	// ErrExpired is returned when the idle or absolute ttl of a dashboard token lapsed or the token was revoked.
+	ErrExpired = errors.New("dashboard token expired")
+	// ErrInvalid is returned when the token was not issued by dashboard.
+	ErrInvalid = errors.New("invalid dashboard token")
+
+	ttlLock     sync.RWMutex
+	idleTTL     = DefaultIdleTTL
+	absoluteTTL = DefaultAbsoluteTTL
+
+	revoked = newRevocationList()
+)
+
+// Claims is the payload of a dashboard token, it wraps the karmada token of the user.
+type Claims struct {
+	ID string `json:"jti"`
+	// Token is the karmada token the dashboard token was issued for.
+	Token string `json:"token"`
+	// IssuedAt is the login time, refreshing a token keeps it so that the absolute ttl can be enforced.
+	IssuedAt  time.Time `json:"iat"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// SetTTL sets the idle and absolute ttl of dashboard tokens and sessions, zero values keep the current settings.
+func SetTTL(idle, absolute time.Duration) {
+	ttlLock.Lock()
+	defer ttlLock.Unlock()
+	if idle > 0 {
+		idleTTL = idle
+	}
+	if absolute > 0 {
+		absoluteTTL = absolute
+	}
+}
+
+// ExpiresAt returns the expiry of a token or session which was issued at issuedAt and is used now,
+// it's the end of the idle ttl capped by the absolute ttl.
+func ExpiresAt(issuedAt time.Time) time.Time {
+	ttlLock.RLock()
+	defer ttlLock.RUnlock()
+	expiry := time.Now().Add(idleTTL)
+	if absolute := issuedAt.Add(absoluteTTL); absolute.Before(expiry) {
+		return absolute
+	}
+	return expiry
+}
+
+// IsDashboardToken reports whether the token was issued by dashboard rather than being a raw karmada token.
+func IsDashboardToken(token string) bool {
+	return jwe.IsJWE(token)
+}
+
+// Generate wraps the karmada token into an encrypted dashboard token.
+func Generate(karmadaToken string) (string, *Claims, error) {
+	return generate(&Claims{Token: karmadaToken, IssuedAt: time.Now()})
+}
+
+// Parse decrypts the dashboard token and returns its claims, ErrExpired is returned if the token lapsed.
+func Parse(token string) (*Claims, error) {
+	if !IsDashboardToken(token) {
+		return nil, ErrInvalid
+	}
+	payload, err := jwe.Decrypt(token)
+	if err != nil {
+		// tokens encrypted with a key that has been rotated out can not be told apart from forged ones,
+		// treat them as expired so that the user is asked to login again.
+		if errors.Is(err, jwe.ErrInvalidToken) {
+			return nil, ErrExpired
+		}
+		return nil, err
+	}
+	claims := &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil || len(claims.Token) == 0 {
+		return nil, ErrInvalid
+	}
+	if time.Now().After(claims.ExpiresAt) || revoked.contains(claims.ID) {
+		return nil, ErrExpired
+	}
+	return claims, nil
+}
+
+// Refresh returns a new dashboard token for the same karmada token with its idle ttl renewed,
+// the absolute ttl still counts from the login.
+func Refresh(token string) (string, *Claims, error) {
+	claims, err := Parse(token)
+	if err != nil {
+		return "", nil, err
+	}
+	return generate(&Claims{Token: claims.Token, IssuedAt: claims.IssuedAt})
+}
+
+// Revoke invalidates the dashboard token before it expires. Once Init is called the revocation is persisted
+// in the secret of encryption keys, other replicas pick it up when they sync the revocation list.
+func Revoke(token string) error {
+	claims, err := Parse(token)
+	if err != nil {
+		if errors.Is(err, ErrExpired) {
+			return nil
+		}
+		return err
+	}
+	return revoked.add(context.TODO(), claims.ID, claims.ExpiresAt)
+}
+
+func generate(claims *Claims) (string, *Claims, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+	claims.ID = id
+	claims.ExpiresAt = ExpiresAt(claims.IssuedAt)
+	if !claims.ExpiresAt.After(time.Now()) {
+		return "", nil, ErrExpired
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	token, err := jwe.Encrypt(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
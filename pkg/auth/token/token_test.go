@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/karmada-io/dashboard/pkg/auth/jwe"
+)
+
+func TestGenerateAndParse(t *testing.T) {
+	dashboardToken, _, err := Generate("karmada-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(dashboardToken, "karmada-token") || !IsDashboardToken(dashboardToken) {
+		t.Fatalf("Generate() returned a token which is not encrypted: %s", dashboardToken)
+	}
+
+	claims, err := Parse(dashboardToken)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if claims.Token != "karmada-token" {
+		t.Errorf("Parse() returned karmada token %q, expected %q", claims.Token, "karmada-token")
+	}
+
+	parts := strings.Split(dashboardToken, ".")
+	if strings.HasPrefix(parts[3], "A") {
+		parts[3] = "B" + parts[3][1:]
+	} else {
+		parts[3] = "A" + parts[3][1:]
+	}
+	if _, err = Parse(strings.Join(parts, ".")); err == nil {
+		t.Error("Parse() should fail for a tampered token")
+	}
+	if _, err = Parse("raw.karmada.token"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Parse() of a raw token returned %v, expected %v", err, ErrInvalid)
+	}
+}
+
+func TestRefreshKeepsAbsoluteTTL(t *testing.T) {
+	issuedAt := time.Now().Add(-time.Hour)
+	dashboardToken, _, err := generate(&Claims{Token: "karmada-token", IssuedAt: issuedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, claims, err := Refresh(dashboardToken)
+	if err != nil {
+		t.Fatalf("Refresh() returned error: %v", err)
+	}
+	if refreshed == dashboardToken || !claims.IssuedAt.Equal(issuedAt) {
+		t.Errorf("Refresh() should issue a new token with the original login time, got %+v", claims)
+	}
+
+	// a session which started before the absolute ttl can not be refreshed anymore
+	expired, _, err := generate(&Claims{Token: "karmada-token", IssuedAt: time.Now().Add(-DefaultAbsoluteTTL).Add(time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+	if _, _, err = Refresh(expired); !errors.Is(err, ErrExpired) {
+		t.Errorf("Refresh() after absolute ttl returned %v, expected %v", err, ErrExpired)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	dashboardToken, _, err := Generate("karmada-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Revoke(dashboardToken); err != nil {
+		t.Fatalf("Revoke() returned error: %v", err)
+	}
+	if _, err = Parse(dashboardToken); !errors.Is(err, ErrExpired) {
+		t.Errorf("Parse() of a revoked token returned %v, expected %v", err, ErrExpired)
+	}
+}
+
+func TestRevokeIsSharedThroughSecret(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	t.Cleanup(func() { revoked = newRevocationList() })
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: jwe.SecretName, Namespace: "karmada-system"},
+		Data:       map[string][]byte{"keys": []byte("[]")},
+	})
+	if err := Init(ctx, kubeClient, "karmada-system"); err != nil {
+		t.Fatal(err)
+	}
+
+	dashboardToken, claims, err := Generate("karmada-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Revoke(dashboardToken); err != nil {
+		t.Fatalf("Revoke() returned error: %v", err)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("karmada-system").Get(ctx, jwe.SecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["keys"]) != "[]" {
+		t.Errorf("Revoke() should keep the encryption keys, got %s", secret.Data["keys"])
+	}
+
+	// another replica picks the revocation up from the secret
+	other := newRevocationList()
+	other.kubeClient, other.namespace = kubeClient, "karmada-system"
+	if err = other.sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !other.contains(claims.ID) {
+		t.Errorf("expected token %s to be revoked on other replicas, secret holds %s", claims.ID, secret.Data[revocationSecretKey])
+	}
+}
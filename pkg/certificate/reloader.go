@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// reloadPeriod is how often the certificate files are checked for changes.
+const reloadPeriod = 10 * time.Second
+
+// Reloader serves the certificate and client ca bundle loaded from files, and loads them again once the files change.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	lock        sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	modTimes    map[string]time.Time
+}
+
+// NewReloader loads the certificate and the client ca bundle, certFile and keyFile can be empty if the
+// certificate is set with SetCertificate, clientCAFile can be empty if client certificates are not verified.
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		modTimes:     make(map[string]time.Time),
+	}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetCertificate sets a certificate which is not backed by files, e.g. a generated self-signed one.
+func (r *Reloader) SetCertificate(certificate tls.Certificate) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.certificate = &certificate
+}
+
+// Run checks the files for changes until ctx is done.
+func (r *Reloader) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, func(_ context.Context) {
+		changed, err := r.reload()
+		if err != nil {
+			klog.ErrorS(err, "Could not reload certificates, keep serving the previous ones")
+			return
+		}
+		if changed {
+			klog.InfoS("Reloaded certificates", "certFile", r.certFile, "clientCAFile", r.clientCAFile)
+		}
+	}, reloadPeriod)
+}
+
+// TLSConfig returns a tls config which always serves the latest certificate and verifies client certificates,
+// if they are presented, against the latest client ca bundle.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.lock.RLock()
+			defer r.lock.RUnlock()
+			if r.certificate == nil {
+				return nil, fmt.Errorf("no serving certificate available")
+			}
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.certificate},
+			}
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+				config.ClientCAs = r.clientCAs
+			}
+			return config, nil
+		},
+	}
+}
+
+// reload loads the files again if any of them has been modified since the last load.
+func (r *Reloader) reload() (bool, error) {
+	modTimes := make(map[string]time.Time)
+	changed := false
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if len(file) == 0 {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	var certificate *tls.Certificate
+	if len(r.certFile) > 0 {
+		loaded, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to load serving certificate: %w", err)
+		}
+		certificate = &loaded
+	}
+	var clientCAs *x509.CertPool
+	if len(r.clientCAFile) > 0 {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("no certificate found in client ca file %s", r.clientCAFile)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if certificate != nil {
+		r.certificate = certificate
+	}
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return true, nil
+}
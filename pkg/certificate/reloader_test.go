@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// writeKeyPair writes a certificate for host and its key, their modification time is set to modTime so that
+// rotations within the same second are noticed.
+func writeKeyPair(t *testing.T, certFile, keyFile, host string, modTime time.Time) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(host, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, data := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+		if err = os.WriteFile(file, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// servedHost returns the host of the certificate the reloader serves to a client.
+func servedHost(t *testing.T, r *Reloader) string {
+	config, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("GetConfigForClient() error = %v", err)
+	}
+	leaf, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.DNSNames[0]
+}
+
+func TestReloaderRotation(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	now := time.Now()
+	writeKeyPair(t, certFile, keyFile, "old.example.com", now)
+	r, err := NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+	if host := servedHost(t, r); host != "old.example.com" {
+		t.Fatalf("expected the certificate of old.example.com to be served, got %s", host)
+	}
+
+	cases := []struct {
+		name    string
+		rotate  func(modTime time.Time)
+		changed bool
+		wantErr bool
+		host    string
+	}{
+		{
+			name:   "unchanged files",
+			rotate: func(time.Time) {},
+			host:   "old.example.com",
+		},
+		{
+			name: "rotated key pair",
+			rotate: func(modTime time.Time) {
+				writeKeyPair(t, certFile, keyFile, "new.example.com", modTime)
+			},
+			changed: true,
+			host:    "new.example.com",
+		},
+		{
+			name: "corrupted certificate keeps the previous one",
+			rotate: func(modTime time.Time) {
+				if err := os.WriteFile(certFile, []byte("not a certificate"), 0600); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Chtimes(certFile, modTime, modTime); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: true,
+			host:    "new.example.com",
+		},
+	}
+	for i, c := range cases {
+		c.rotate(now.Add(time.Duration(i+1) * time.Minute))
+		changed, err := r.reload()
+		if changed != c.changed || (err != nil) != c.wantErr {
+			t.Errorf("%s: reload() == %v, %v, expected changed %v and error %v", c.name, changed, err, c.changed, c.wantErr)
+		}
+		if host := servedHost(t, r); host != c.host {
+			t.Errorf("%s: expected the certificate of %s to be served, got %s", c.name, c.host, host)
+		}
+	}
+}
+
+func TestReloaderSelfSignedFallback(t *testing.T) {
+	r, err := NewReloader("", "", "")
+	if err != nil {
+		t.Fatalf("NewReloader() without files error = %v", err)
+	}
+	if _, err = r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{}); err == nil {
+		t.Fatal("expected no certificate to be served before one is set")
+	}
+
+	kubeClient := fake.NewSimpleClientset()
+	selfSigned, err := LoadOrCreateSelfSigned(context.TODO(), kubeClient, "karmada-system", "karmada-dashboard-api", nil)
+	if err != nil {
+		t.Fatalf("LoadOrCreateSelfSigned() error = %v", err)
+	}
+	r.SetCertificate(selfSigned)
+	if changed, err := r.reload(); changed || err != nil {
+		t.Errorf("reload() without files == %v, %v, expected nothing to reload", changed, err)
+	}
+	if host := servedHost(t, r); host != "karmada-dashboard-api" {
+		t.Errorf("expected the self-signed certificate to be served, got %s", host)
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets("karmada-system").Get(context.TODO(), SelfSignedSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the self-signed certificate to be persisted: %v", err)
+	}
+	if len(secret.Data[CAKey]) == 0 || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		t.Errorf("expected the secret to hold the certificate and its ca")
+	}
+	// other replicas and restarts load the persisted certificate instead of generating a new one
+	loaded, err := LoadOrCreateSelfSigned(context.TODO(), kubeClient, "karmada-system", "karmada-dashboard-api", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(loaded.Certificate[0]) != string(selfSigned.Certificate[0]) {
+		t.Error("expected the persisted self-signed certificate to be reused")
+	}
+}
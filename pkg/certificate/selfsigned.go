@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// SelfSignedSecretName is the name of the secret in host cluster which persists the self-signed certificate,
+	// its ca.crt can be mounted by the web proxy to verify the api.
+	SelfSignedSecretName = "karmada-dashboard-api-cert"
+	// CAKey is the key of the ca bundle in the secret.
+	CAKey = "ca.crt"
+	// renewBefore is how long before its expiry the self-signed certificate will be generated again.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// LoadOrCreateSelfSigned returns the self-signed certificate persisted in the secret of host cluster, a new
+// certificate for the given host and alternate names is generated if the secret does not exist or the
+// certificate is about to expire. The certificate is signed by a generated ca which is stored as ca.crt.
+func LoadOrCreateSelfSigned(ctx context.Context, kubeClient kubeclient.Interface, namespace, host string, alternateDNS []string) (tls.Certificate, error) {
+	secrets := kubeClient.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, SelfSignedSecretName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return tls.Certificate{}, err
+	}
+	exists := err == nil
+	if exists {
+		certificate, loadErr := loadValidCertificate(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if loadErr == nil {
+			return certificate, nil
+		}
+		klog.InfoS("Self-signed certificate is invalid or about to expire, generating a new one", "namespace", namespace, "secret", SelfSignedSecretName, "reason", loadErr.Error())
+	}
+
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(host, []net.IP{net.ParseIP("127.0.0.1")}, alternateDNS)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil || len(certs) < 2 {
+		return tls.Certificate{}, fmt.Errorf("failed to parse generated certificate: %v", err)
+	}
+	// the generated bundle is the serving certificate followed by its ca
+	caPEM, err := certutil.EncodeCertificates(certs[1:]...)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		CAKey:                   caPEM,
+	}
+
+	if exists {
+		secret = secret.DeepCopy()
+		secret.Data = data
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	} else {
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SelfSignedSecretName,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}, metav1.CreateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		// another replica won the race, use its certificate
+		return LoadOrCreateSelfSigned(ctx, kubeClient, namespace, host, alternateDNS)
+	}
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to persist self-signed certificate: %w", err)
+	}
+	klog.InfoS("Generated self-signed certificate", "namespace", namespace, "secret", SelfSignedSecretName, "host", host)
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// loadValidCertificate parses the key pair and makes sure the certificate is not about to expire.
+func loadValidCertificate(certPEM, keyPEM []byte) (tls.Certificate, error) {
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if time.Until(leaf.NotAfter) < renewBefore {
+		return tls.Certificate{}, fmt.Errorf("certificate expires at %s", leaf.NotAfter)
+	}
+	return certificate, nil
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package client
 
 import (
+	goerrors "errors"
 	"net/http"
 	"strings"
 
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
 
 const (
@@ -34,6 +38,25 @@ const (
 )
 
 func karmadaConfigFromRequest(request *http.Request) (*rest.Config, error) {
+	karmadaToken, err := GetTokenFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(karmadaToken) == 0 {
+		credentials, err := sessionCredentials(request)
+		if err != nil {
+			return nil, err
+		}
+		if credentials == nil {
+			if config, ok := clientCertificateConfig(request); ok {
+				return config, nil
+			}
+			if serviceAccountMode {
+				return serviceAccountConfig(request), nil
+			}
+		}
+	}
+
 	authInfo, err := buildAuthInfo(request)
 	if err != nil {
 		return nil, err
@@ -67,16 +90,67 @@ func buildConfigFromAuthInfo(authInfo *clientcmdapi.AuthInfo) (*rest.Config, err
 	).ClientConfig()
 }
 
-func buildAuthInfo(request *http.Request) (*clientcmdapi.AuthInfo, error) {
-	if !HasAuthorizationHeader(request) {
-		return nil, k8serrors.NewUnauthorized("MSG_LOGIN_UNAUTHORIZED_ERROR")
+// serviceAccountConfig returns a copy of the dashboard's own karmada config, impersonation headers
+// of the request are still honored so that an authenticating proxy in front of dashboard keeps working.
+func serviceAccountConfig(request *http.Request) *rest.Config {
+	config := rest.CopyConfig(karmadaRestConfig)
+	if request == nil {
+		return config
+	}
+
+	authInfo := &clientcmdapi.AuthInfo{
+		ImpersonateUserExtra: make(map[string][]string),
+	}
+	handleImpersonation(authInfo, request)
+	if len(authInfo.Impersonate) > 0 {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: authInfo.Impersonate,
+			Groups:   authInfo.ImpersonateGroups,
+			Extra:    authInfo.ImpersonateUserExtra,
+		}
+	}
+	return config
+}
+
+// clientCertificateConfig returns a copy of the dashboard's own karmada config which impersonates the subject of
+// the verified client certificate of the request, the common name is the user and the organizations are the groups.
+// Client certificates are only verified when the secure port is served with a client ca bundle.
+func clientCertificateConfig(request *http.Request) (*rest.Config, bool) {
+	if request == nil || request.TLS == nil || len(request.TLS.VerifiedChains) == 0 || len(request.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	subject := request.TLS.VerifiedChains[0][0].Subject
+	if len(subject.CommonName) == 0 {
+		return nil, false
 	}
+	config := rest.CopyConfig(karmadaRestConfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: subject.CommonName,
+		Groups:   subject.Organization,
+	}
+	return config, true
+}
 
-	token := GetBearerToken(request)
+func buildAuthInfo(request *http.Request) (*clientcmdapi.AuthInfo, error) {
+	karmadaToken, err := GetTokenFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
 	authInfo := &clientcmdapi.AuthInfo{
-		Token:                token,
+		Token:                karmadaToken,
 		ImpersonateUserExtra: make(map[string][]string),
 	}
+	if len(karmadaToken) == 0 {
+		// the user may have signed in with a client certificate which is kept in the session
+		credentials, err := sessionCredentials(request)
+		if err != nil {
+			return nil, err
+		}
+		if credentials == nil {
+			return nil, errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+		}
+		setCredentials(authInfo, credentials)
+	}
 
 	handleImpersonation(authInfo, request)
 	return authInfo, nil
@@ -99,6 +173,45 @@ func GetBearerToken(req *http.Request) string {
 	return extractBearerToken(header)
 }
 
+// GetTokenFromRequest returns the karmada token of the request. Dashboard tokens in the authorization header are
+// decrypted, raw bearer tokens are passed through as is. If the request carries no authorization header the token of
+// the dashboard session which the request belongs to is returned. An error with MsgTokenExpiredError is returned
+// if the dashboard token or session of the request lapsed.
+func GetTokenFromRequest(req *http.Request) (string, error) {
+	if req == nil {
+		return "", nil
+	}
+	if HasAuthorizationHeader(req) {
+		bearerToken := GetBearerToken(req)
+		if !token.IsDashboardToken(bearerToken) {
+			return bearerToken, nil
+		}
+		claims, err := token.Parse(bearerToken)
+		if err != nil {
+			if goerrors.Is(err, token.ErrExpired) {
+				return "", errors.NewTokenExpired(errors.MsgTokenExpiredError)
+			}
+			return "", errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+		}
+		return claims.Token, nil
+	}
+	s, err := session.FromRequest(req)
+	if err != nil {
+		if goerrors.Is(err, token.ErrExpired) {
+			return "", errors.NewTokenExpired(errors.MsgTokenExpiredError)
+		}
+		return "", nil
+	}
+	if len(s.Token) > 0 {
+		return s.Token, nil
+	}
+	credentials, err := s.GetCredentials()
+	if err != nil || credentials == nil {
+		return "", nil
+	}
+	return credentials.Token, nil
+}
+
 // SetAuthorizationHeader sets the authorization header for the given request.
 func SetAuthorizationHeader(req *http.Request, token string) {
 	req.Header.Set(authorizationHeader, authorizationTokenPrefix+token)
@@ -21,8 +21,12 @@ import (
 	"net/http"
 
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
@@ -125,3 +129,74 @@ func karmadaClientFromRequest(request *http.Request) (karmadaclientset.Interface
 
 	return karmadaclientset.NewForConfig(config)
 }
+
+// GetKarmadaConfigFromRequest creates a rest.Config for karmada apiserver carrying the credentials of an HTTP request.
+func GetKarmadaConfigFromRequest(request *http.Request) (*rest.Config, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	return karmadaConfigFromRequest(request)
+}
+
+// GetKarmadaKubeClientFromRequest creates a kubernetes clientset for karmada apiserver from an HTTP request.
+func GetKarmadaKubeClientFromRequest(request *http.Request) (kubeclient.Interface, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubeclient.NewForConfig(config)
+}
+
+// GetDynamicClientFromRequest creates a dynamic client for karmada apiserver from an HTTP request.
+func GetDynamicClientFromRequest(request *http.Request) (dynamic.Interface, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(config)
+}
+
+// GetRESTMapperFromRequest discovers the resources served by karmada apiserver with the credentials of an HTTP
+// request and returns a RESTMapper of them.
+func GetRESTMapperFromRequest(request *http.Request) (meta.RESTMapper, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+// GetMemberClientFromRequest creates a kubernetes clientset for member apiserver from an HTTP request,
+// the member apiserver is accessed through the cluster proxy of karmada apiserver.
+func GetMemberClientFromRequest(request *http.Request, clusterName string) (kubeclient.Interface, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	config.Host = karmadaRestConfig.Host + fmt.Sprintf(proxyURL, clusterName)
+
+	return kubeclient.NewForConfig(config)
+}
@@ -42,13 +42,15 @@ var (
 	inClusterClientForKarmadaAPIServer kubeclient.Interface
 	inClusterClientForMemberAPIServer  kubeclient.Interface
 	memberClients                      sync.Map
+	serviceAccountMode                 bool
 )
 
 type configBuilder struct {
-	kubeconfigPath string
-	kubeContext    string
-	insecure       bool
-	userAgent      string
+	kubeconfigPath     string
+	kubeContext        string
+	insecure           bool
+	userAgent          string
+	serviceAccountMode bool
 }
 
 // Option is a function that configures a configBuilder.
@@ -82,6 +84,14 @@ func WithInsecureTLSSkipVerify(insecure bool) Option {
 	}
 }
 
+// WithServiceAccountMode is an option to fall back to the dashboard's own karmada credentials
+// for requests that carry no bearer token. It should only be enabled for trusted single-tenant installs.
+func WithServiceAccountMode(enabled bool) Option {
+	return func(c *configBuilder) {
+		c.serviceAccountMode = enabled
+	}
+}
+
 func newConfigBuilder(options ...Option) *configBuilder {
 	builder := &configBuilder{}
 
@@ -223,6 +233,7 @@ func InitKarmadaConfig(options ...Option) {
 		os.Exit(1)
 	}
 	karmadaMemberConfig = memberConfig
+	serviceAccountMode = builder.serviceAccountMode
 }
 
 // InClusterKarmadaClient returns a karmada client.
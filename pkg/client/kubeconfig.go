@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/tls"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/karmada-io/dashboard/pkg/auth/session"
+	"github.com/karmada-io/dashboard/pkg/auth/token"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// KubeconfigContexts returns the names of contexts in the kubeconfig and its current context.
+func KubeconfigContexts(kubeconfig []byte) ([]string, string, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, "", errors.NewBadRequest(fmt.Sprintf("invalid kubeconfig: %v", err))
+	}
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, config.CurrentContext, nil
+}
+
+// ContextKubeconfig returns a kubeconfig which only contains the named context of kubeconfig and uses it.
+func ContextKubeconfig(kubeconfig []byte, contextName string) (string, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("invalid kubeconfig: %v", err))
+	}
+	if _, ok := config.Contexts[contextName]; !ok {
+		return "", errors.NewBadRequest(fmt.Sprintf("context %q not found in kubeconfig", contextName))
+	}
+	config.CurrentContext = contextName
+	if err = clientcmdapi.MinifyConfig(config); err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("invalid context %q: %v", contextName, err))
+	}
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CredentialsFromKubeconfig extracts the token or client certificate of the user in the given context of kubeconfig,
+// the current context is used if contextName is empty. Only credentials embedded in the kubeconfig are accepted:
+// exec plugins, auth providers and file references can not be served on behalf of the user and are rejected.
+// The server of the kubeconfig is ignored, credentials are always used against the karmada apiserver of dashboard.
+func CredentialsFromKubeconfig(kubeconfig []byte, contextName string) (*session.Credentials, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid kubeconfig: %v", err))
+	}
+	if len(contextName) == 0 {
+		contextName = config.CurrentContext
+	}
+	if len(contextName) == 0 {
+		return nil, errors.NewBadRequest("kubeconfig has no current context, a context must be chosen")
+	}
+	kubeContext, ok := config.Contexts[contextName]
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("context %q not found in kubeconfig", contextName))
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("user %q of context %q not found in kubeconfig", kubeContext.AuthInfo, contextName))
+	}
+
+	switch {
+	case authInfo.Exec != nil:
+		return nil, errors.NewBadRequest("exec based credentials are not supported, please use a kubeconfig with an embedded token or client certificate")
+	case authInfo.AuthProvider != nil:
+		return nil, errors.NewBadRequest(fmt.Sprintf("auth provider %q is not supported, please use a kubeconfig with an embedded token or client certificate", authInfo.AuthProvider.Name))
+	case len(authInfo.TokenFile) > 0 || len(authInfo.ClientCertificate) > 0 || len(authInfo.ClientKey) > 0:
+		return nil, errors.NewBadRequest("credentials referring to files are not supported, please embed them with client-certificate-data, client-key-data or token")
+	case len(authInfo.ClientCertificateData) > 0 || len(authInfo.ClientKeyData) > 0:
+		if _, err = tls.X509KeyPair(authInfo.ClientCertificateData, authInfo.ClientKeyData); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid client certificate: %v", err))
+		}
+		return &session.Credentials{
+			ClientCertificateData: authInfo.ClientCertificateData,
+			ClientKeyData:         authInfo.ClientKeyData,
+		}, nil
+	case len(authInfo.Token) > 0:
+		return &session.Credentials{Token: authInfo.Token}, nil
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("user %q of context %q has no token or client certificate", kubeContext.AuthInfo, contextName))
+	}
+}
+
+// ConfigFromCredentials returns the karmada config which authenticates with the credentials.
+func ConfigFromCredentials(credentials *session.Credentials) (*rest.Config, error) {
+	authInfo := &clientcmdapi.AuthInfo{ImpersonateUserExtra: make(map[string][]string)}
+	setCredentials(authInfo, credentials)
+	return buildConfigFromAuthInfo(authInfo)
+}
+
+func setCredentials(authInfo *clientcmdapi.AuthInfo, credentials *session.Credentials) {
+	authInfo.Token = credentials.Token
+	authInfo.ClientCertificateData = credentials.ClientCertificateData
+	authInfo.ClientKeyData = credentials.ClientKeyData
+}
+
+// sessionCredentials returns the credentials kept in the session of request, nil is returned if the request
+// belongs to no session or its session carries no credentials.
+func sessionCredentials(request *http.Request) (*session.Credentials, error) {
+	if request == nil || HasAuthorizationHeader(request) {
+		return nil, nil
+	}
+	s, err := session.FromRequest(request)
+	if err != nil {
+		if goerrors.Is(err, token.ErrExpired) {
+			return nil, errors.NewTokenExpired(errors.MsgTokenExpiredError)
+		}
+		return nil, nil
+	}
+	credentials, err := s.GetCredentials()
+	if err != nil {
+		// the key encrypting the credentials has been rotated out
+		return nil, errors.NewTokenExpired(errors.MsgTokenExpiredError)
+	}
+	return credentials, nil
+}
+
+// CallerAuthInfo returns the karmada credentials the request was authenticated with, so that they can be handed
+// out in a kubeconfig. Requests served with the dashboard's own identity carry no credentials of their own.
+func CallerAuthInfo(request *http.Request) (*clientcmdapi.AuthInfo, error) {
+	karmadaToken, err := GetTokenFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	authInfo := &clientcmdapi.AuthInfo{Token: karmadaToken}
+	if len(karmadaToken) > 0 {
+		return authInfo, nil
+	}
+	credentials, err := sessionCredentials(request)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil {
+		return nil, errors.NewBadRequest("the request carries no karmada credentials which can be put into a kubeconfig, sign in with a token or kubeconfig instead")
+	}
+	setCredentials(authInfo, credentials)
+	return authInfo, nil
+}
+
+// ProxyKubeconfig generates a kubeconfig with a context for each of clusters, whose server is the karmada cluster
+// proxy url of the cluster. server is the address of karmada apiserver, it defaults to the one dashboard connects to.
+// The current context is the first cluster.
+func ProxyKubeconfig(clusters []string, server string, authInfo *clientcmdapi.AuthInfo) (*clientcmdapi.Config, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	if len(server) == 0 {
+		server = karmadaRestConfig.Host
+	}
+	caData := karmadaRestConfig.TLSClientConfig.CAData
+	if len(caData) == 0 && len(karmadaRestConfig.TLSClientConfig.CAFile) > 0 {
+		data, err := os.ReadFile(karmadaRestConfig.TLSClientConfig.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		caData = data
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.AuthInfos[DefaultCmdConfigName] = authInfo
+	for _, cluster := range clusters {
+		config.Clusters[cluster] = &clientcmdapi.Cluster{
+			Server:                   strings.TrimSuffix(server, "/") + fmt.Sprintf(proxyURL, cluster),
+			CertificateAuthorityData: caData,
+			InsecureSkipTLSVerify:    karmadaRestConfig.TLSClientConfig.Insecure,
+		}
+		config.Contexts[cluster] = &clientcmdapi.Context{
+			Cluster:  cluster,
+			AuthInfo: DefaultCmdConfigName,
+		}
+	}
+	if len(clusters) > 0 {
+		config.CurrentContext = clusters[0]
+	}
+	return config, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newKubeconfig(t *testing.T, authInfos map[string]*clientcmdapi.AuthInfo, currentContext string) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["karmada"] = &clientcmdapi.Cluster{Server: "https://karmada-apiserver:5443"}
+	for name, authInfo := range authInfos {
+		config.AuthInfos[name] = authInfo
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: "karmada", AuthInfo: name}
+	}
+	config.CurrentContext = currentContext
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kubeconfig
+}
+
+func TestCredentialsFromKubeconfig(t *testing.T) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("alice", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubeconfig := newKubeconfig(t, map[string]*clientcmdapi.AuthInfo{
+		"token":     {Token: "secret"},
+		"cert":      {ClientCertificateData: certPEM, ClientKeyData: keyPEM},
+		"exec":      {Exec: &clientcmdapi.ExecConfig{Command: "get-token"}},
+		"file":      {ClientCertificate: "/etc/passwd", ClientKey: "/etc/shadow"},
+		"tokenfile": {TokenFile: "/var/run/secrets/token"},
+		"mismatch":  {ClientCertificateData: certPEM, ClientKeyData: []byte("invalid")},
+		"empty":     {},
+	}, "token")
+
+	contexts, current, err := KubeconfigContexts(kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contexts) != 7 || current != "token" {
+		t.Errorf("unexpected contexts %v with current context %s", contexts, current)
+	}
+
+	credentials, err := CredentialsFromKubeconfig(kubeconfig, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials.Token != "secret" {
+		t.Errorf("expected token of current context, got %+v", credentials)
+	}
+
+	credentials, err = CredentialsFromKubeconfig(kubeconfig, "cert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(credentials.ClientCertificateData) != string(certPEM) || string(credentials.ClientKeyData) != string(keyPEM) {
+		t.Errorf("expected client certificate of context cert, got %+v", credentials)
+	}
+
+	for _, contextName := range []string{"exec", "file", "tokenfile", "mismatch", "empty", "missing"} {
+		if _, err = CredentialsFromKubeconfig(kubeconfig, contextName); !apierrors.IsBadRequest(err) {
+			t.Errorf("expected bad request for context %s, got %v", contextName, err)
+		}
+	}
+	if _, err = CredentialsFromKubeconfig([]byte("not a kubeconfig"), ""); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected bad request for invalid kubeconfig, got %v", err)
+	}
+}
+
+func TestProxyKubeconfig(t *testing.T) {
+	karmadaRestConfig = &rest.Config{
+		Host:            "https://karmada-apiserver.karmada-system.svc:5443",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")},
+	}
+	karmadaAPIConfig = clientcmdapi.NewConfig()
+	defer func() {
+		karmadaRestConfig, karmadaAPIConfig = nil, nil
+	}()
+
+	config, err := ProxyKubeconfig([]string{"member1", "member2"}, "", &clientcmdapi.AuthInfo{Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.CurrentContext != "member1" || len(config.Contexts) != 2 {
+		t.Errorf("unexpected contexts %v with current context %s", config.Contexts, config.CurrentContext)
+	}
+	cluster := config.Clusters["member2"]
+	if cluster.Server != "https://karmada-apiserver.karmada-system.svc:5443/apis/cluster.karmada.io/v1alpha1/clusters/member2/proxy/" ||
+		string(cluster.CertificateAuthorityData) != "ca" {
+		t.Errorf("unexpected cluster %+v", cluster)
+	}
+	if config.AuthInfos[config.Contexts["member2"].AuthInfo].Token != "secret" {
+		t.Errorf("expected credentials of caller, got %+v", config.AuthInfos)
+	}
+
+	config, err = ProxyKubeconfig([]string{"member1"}, "https://karmada.example.com/", &clientcmdapi.AuthInfo{Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server := config.Clusters["member1"].Server; server != "https://karmada.example.com/apis/cluster.karmada.io/v1alpha1/clusters/member1/proxy/" {
+		t.Errorf("expected overridden server, got %s", server)
+	}
+}
+
+func TestContextKubeconfig(t *testing.T) {
+	kubeconfig := newKubeconfig(t, map[string]*clientcmdapi.AuthInfo{
+		"member1": {Token: "member1"},
+		"member2": {Token: "member2"},
+	}, "member1")
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Contexts["dangling"] = &clientcmdapi.Context{Cluster: "missing", AuthInfo: "member1"}
+	dangling, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		kubeconfig  []byte
+		context     string
+		wantToken   string
+		wantInvalid bool
+	}{
+		{name: "current context", kubeconfig: kubeconfig, context: "member1", wantToken: "member1"},
+		{name: "other context", kubeconfig: kubeconfig, context: "member2", wantToken: "member2"},
+		{name: "missing context", kubeconfig: kubeconfig, context: "member3", wantInvalid: true},
+		{name: "context without cluster", kubeconfig: dangling, context: "dangling", wantInvalid: true},
+		{name: "malformed kubeconfig", kubeconfig: []byte("clusters: ["), context: "member1", wantInvalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ContextKubeconfig(tt.kubeconfig, tt.context)
+			if tt.wantInvalid {
+				if !apierrors.IsBadRequest(err) {
+					t.Fatalf("expected a bad request, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContextKubeconfig() error = %v", err)
+			}
+			minified, err := clientcmd.Load([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if minified.CurrentContext != tt.context || len(minified.Contexts) != 1 || len(minified.AuthInfos) != 1 {
+				t.Errorf("expected only context %s, got %+v", tt.context, minified.Contexts)
+			}
+			if token := minified.AuthInfos[minified.Contexts[tt.context].AuthInfo].Token; token != tt.wantToken {
+				t.Errorf("token = %q, want %q", token, tt.wantToken)
+			}
+		})
+	}
+}
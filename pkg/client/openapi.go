@@ -0,0 +1,296 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/spec3"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// openAPISpecTTL is how long a fetched OpenAPI v3 document is reused, so that schemas of
+	// CRDs installed or upgraded later are picked up eventually.
+	openAPISpecTTL = 5 * time.Minute
+	// schemaRefPrefix is the prefix of the references to component schemas in OpenAPI v3 documents.
+	schemaRefPrefix = "#/components/schemas/"
+
+	extensionGroupVersionKind      = "x-kubernetes-group-version-kind"
+	extensionPreserveUnknownFields = "x-kubernetes-preserve-unknown-fields"
+	extensionIntOrString           = "x-kubernetes-int-or-string"
+	extensionEmbeddedResource      = "x-kubernetes-embedded-resource"
+)
+
+type cachedOpenAPISpec struct {
+	spec      *spec3.OpenAPI
+	fetchedAt time.Time
+}
+
+var (
+	openAPISpecLock  sync.Mutex
+	openAPISpecCache = map[schema.GroupVersion]cachedOpenAPISpec{}
+)
+
+// ValidateSchema validates the object against the structural schema of its kind published in the OpenAPI v3
+// document of karmada apiserver. The returned error is an Invalid StatusError whose causes carry the path,
+// reason and message of every rejected field. Fields not declared in the schema are returned as warnings,
+// like the apiserver does by default. Kinds without a published schema are not validated.
+func ValidateSchema(request *http.Request, object *unstructured.Unstructured) ([]string, error) {
+	gvk := object.GroupVersionKind()
+	if gvk.Empty() {
+		return nil, nil
+	}
+	doc, err := openAPISpec(request, gvk.GroupVersion())
+	if err != nil {
+		var notFound *openapi3.GroupVersionNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	errs, warnings := validateSchema(doc, object)
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(gvk.GroupKind(), object.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+// ValidateManifestSchema parses the YAML or JSON manifest and validates it with ValidateSchema, gvk is used
+// as the kind of manifests which don't declare their apiVersion and kind.
+func ValidateManifestSchema(request *http.Request, manifest []byte, gvk schema.GroupVersionKind) ([]string, error) {
+	object := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(manifest, &object.Object); err != nil {
+		return nil, err
+	}
+	if object.Object == nil {
+		return nil, nil
+	}
+	if len(object.GetAPIVersion()) == 0 || len(object.GetKind()) == 0 {
+		object.SetGroupVersionKind(gvk)
+	}
+	return ValidateSchema(request, object)
+}
+
+func openAPISpec(request *http.Request, gv schema.GroupVersion) (*spec3.OpenAPI, error) {
+	openAPISpecLock.Lock()
+	cached, ok := openAPISpecCache[gv]
+	openAPISpecLock.Unlock()
+	if ok && time.Since(cached.fetchedAt) < openAPISpecTTL {
+		return cached.spec, nil
+	}
+
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := openapi3.NewRoot(discoveryClient.OpenAPIV3()).GVSpec(gv)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(3).InfoS("Fetched OpenAPI v3 document", "groupVersion", gv)
+
+	openAPISpecLock.Lock()
+	openAPISpecCache[gv] = cachedOpenAPISpec{spec: doc, fetchedAt: time.Now()}
+	openAPISpecLock.Unlock()
+	return doc, nil
+}
+
+// validateSchema validates the object against the schema of its kind in the OpenAPI v3 document of its group
+// version, fields which are not declared in the schema are returned as warnings.
+func validateSchema(doc *spec3.OpenAPI, object *unstructured.Unstructured) (field.ErrorList, []string) {
+	if doc == nil || doc.Components == nil {
+		return nil, nil
+	}
+	root := kindSchema(doc.Components.Schemas, object.GroupVersionKind())
+	if root == nil {
+		return nil, nil
+	}
+	resolved := resolveSchema(doc.Components.Schemas, root, sets.New[string]())
+	result := validate.NewSchemaValidator(resolved, nil, "", strfmt.Default).Validate(dropNulls(object.Object))
+	return toFieldErrors(result)
+}
+
+// kindSchema finds the component schema which declares the group, version and kind.
+func kindSchema(schemas map[string]*spec.Schema, gvk schema.GroupVersionKind) *spec.Schema {
+	for _, s := range schemas {
+		gvks, ok := s.Extensions[extensionGroupVersionKind].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range gvks {
+			m, ok := item.(map[string]interface{})
+			if ok && m["group"] == gvk.Group && m["version"] == gvk.Version && m["kind"] == gvk.Kind {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSchema returns a copy of s with the references to component schemas inlined, the validator can't
+// follow them. Objects which declare their properties are closed so that unknown fields are reported, and the
+// kubernetes extensions the validator doesn't know are translated. Recursive references are not followed.
+func resolveSchema(schemas map[string]*spec.Schema, s *spec.Schema, resolving sets.Set[string]) *spec.Schema {
+	if ref := s.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, schemaRefPrefix)
+		// quantities are declared as strings but accept numbers as well
+		if strings.HasSuffix(name, ".api.resource.Quantity") {
+			return &spec.Schema{SchemaProps: spec.SchemaProps{AnyOf: []spec.Schema{
+				{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+				{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"number"}}},
+			}}}
+		}
+		target := schemas[name]
+		if target == nil || resolving.Has(name) {
+			return &spec.Schema{}
+		}
+		resolving.Insert(name)
+		defer resolving.Delete(name)
+		return resolveSchema(schemas, target, resolving)
+	}
+
+	resolved := *s
+	if s.Format == "int-or-string" || s.Extensions[extensionIntOrString] == true {
+		resolved.Type, resolved.Format = nil, ""
+		resolved.AnyOf = []spec.Schema{
+			{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}},
+			{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+		}
+	}
+	if len(s.AllOf) > 0 {
+		resolved.AllOf = make([]spec.Schema, 0, len(s.AllOf))
+		for i := range s.AllOf {
+			resolved.AllOf = append(resolved.AllOf, *resolveSchema(schemas, &s.AllOf[i], resolving))
+		}
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		resolved.Items = &spec.SchemaOrArray{Schema: resolveSchema(schemas, s.Items.Schema, resolving)}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		resolved.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: resolveSchema(schemas, s.AdditionalProperties.Schema, resolving)}
+	}
+	if len(s.Properties) > 0 {
+		resolved.Properties = make(map[string]spec.Schema, len(s.Properties))
+		for name := range s.Properties {
+			property := s.Properties[name]
+			resolved.Properties[name] = *resolveSchema(schemas, &property, resolving)
+		}
+		if s.Extensions[extensionEmbeddedResource] == true {
+			for _, name := range []string{"apiVersion", "kind", "metadata"} {
+				if _, ok := resolved.Properties[name]; !ok {
+					resolved.Properties[name] = spec.Schema{}
+				}
+			}
+		}
+		if s.AdditionalProperties == nil && s.Extensions[extensionPreserveUnknownFields] != true {
+			resolved.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+		}
+	}
+	return &resolved
+}
+
+// dropNulls returns a copy of value without null fields, the apiserver treats them as unset.
+func dropNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if item != nil {
+				copied[key] = dropNulls(item)
+			}
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			copied = append(copied, dropNulls(item))
+		}
+		return copied
+	}
+	return value
+}
+
+// toFieldErrors converts the result of the validator to field errors, unknown fields are returned as warnings
+// in the format of the apiserver.
+func toFieldErrors(result *validate.Result) (field.ErrorList, []string) {
+	var errs, composite field.ErrorList
+	var warnings []string
+	for _, err := range result.Errors {
+		v, ok := err.(*openapierrors.Validation)
+		if !ok {
+			// allOf and anyOf failures are summarized on top of the errors of their schemas
+			var coded openapierrors.Error
+			if errors.As(err, &coded) && coded.Code() == openapierrors.CompositeErrorCode {
+				composite = append(composite, field.Invalid(nil, "", err.Error()))
+			} else {
+				errs = append(errs, field.InternalError(nil, err))
+			}
+			continue
+		}
+		var path *field.Path
+		if name := strings.TrimPrefix(v.Name, "."); len(name) > 0 {
+			path = field.NewPath(name)
+		}
+		switch v.Code() {
+		case openapierrors.UnallowedPropertyCode:
+			warnings = append(warnings, fmt.Sprintf("unknown field %q", path.Child(fmt.Sprint(v.Value)).String()))
+		case openapierrors.RequiredFailCode:
+			errs = append(errs, field.Required(path, ""))
+		case openapierrors.EnumFailCode:
+			supported := make([]string, 0, len(v.Values))
+			for _, value := range v.Values {
+				supported = append(supported, fmt.Sprint(value))
+			}
+			errs = append(errs, field.NotSupported(path, v.Value, supported))
+		case openapierrors.InvalidTypeCode:
+			errs = append(errs, field.TypeInvalid(path, v.Value, v.Error()))
+		default:
+			errs = append(errs, field.Invalid(path, v.Value, v.Error()))
+		}
+	}
+	if len(errs) == 0 && len(warnings) == 0 {
+		errs = composite
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+	sort.Strings(warnings)
+	return errs, warnings
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"sigs.k8s.io/yaml"
+)
+
+const testOpenAPISpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "v1.31.3"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}]}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "required": ["selector"],
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "paused": {"type": "boolean"},
+          "selector": {"type": "object", "additionalProperties": {"type": "string"}},
+          "strategy": {
+            "type": "object",
+            "properties": {
+              "type": {"type": "string", "enum": ["Recreate", "RollingUpdate"]},
+              "maxSurge": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.IntOrString"}]}
+            }
+          },
+          "limits": {
+            "type": "object",
+            "additionalProperties": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.api.resource.Quantity"}]}
+          },
+          "extra": {"type": "object", "x-kubernetes-preserve-unknown-fields": true},
+          "args": {"type": "array", "items": {"type": "string"}}
+        }
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+        }
+      },
+      "io.k8s.apimachinery.pkg.util.intstr.IntOrString": {"type": "string", "format": "int-or-string"},
+      "io.k8s.apimachinery.pkg.api.resource.Quantity": {"type": "string"}
+    }
+  }
+}`
+
+func TestValidateSchema(t *testing.T) {
+	doc := new(spec3.OpenAPI)
+	if err := doc.UnmarshalJSON([]byte(testOpenAPISpec)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		manifest     string
+		want         []string
+		wantWarnings []string
+	}{
+		{
+			name: "valid",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  labels:
+    app: nginx
+spec:
+  replicas: 2
+  selector:
+    app: nginx
+  strategy:
+    type: RollingUpdate
+    maxSurge: 25%
+  limits:
+    cpu: 1
+    memory: 1Gi
+  extra:
+    anything: [1, 2]
+  args: ["--v=2"]
+  revisionHistoryLimit: null
+`,
+		},
+		{
+			name: "invalid",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  labels:
+    app: 1
+spec:
+  replicas: 1.5
+  paused: "yes"
+  strategy:
+    type: BlueGreen
+    maxSurge: true
+  args: --v=2
+`,
+			want: []string{
+				"metadata.labels.app: " + string(field.ErrorTypeTypeInvalid),
+				"spec.args: " + string(field.ErrorTypeTypeInvalid),
+				"spec.paused: " + string(field.ErrorTypeTypeInvalid),
+				"spec.replicas: " + string(field.ErrorTypeTypeInvalid),
+				"spec.selector: " + string(field.ErrorTypeRequired),
+				"spec.strategy.maxSurge: " + string(field.ErrorTypeTypeInvalid),
+				"spec.strategy.type: " + string(field.ErrorTypeNotSupported),
+			},
+		},
+		{
+			name: "unknown fields",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  generation: 2
+spec:
+  replica: 2
+  selector:
+    app: nginx
+`,
+			wantWarnings: []string{`unknown field "metadata.generation"`, `unknown field "spec.replica"`},
+		},
+		{
+			name: "unknown kind",
+			manifest: `
+apiVersion: apps/v1
+kind: StatefulSet
+spec:
+  replica: 2
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(tt.manifest), &object.Object); err != nil {
+				t.Fatal(err)
+			}
+			errs, warnings := validateSchema(doc, object)
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Field+": "+string(err.Type))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("validateSchema() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("validateSchema()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("validateSchema() warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
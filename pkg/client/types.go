@@ -44,8 +44,8 @@ const (
 
 // ResourceVerber is responsible for performing generic CRUD operations on all supported resources.
 type ResourceVerber interface {
-	Update(object *unstructured.Unstructured) error
+	Update(object *unstructured.Unstructured, dryRun []string) error
 	Get(kind string, namespace string, name string) (runtime.Object, error)
 	Delete(kind string, namespace string, name string, deleteNow bool) error
-	Create(object *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	Create(object *unstructured.Unstructured, dryRun []string) (*unstructured.Unstructured, error)
 }
@@ -133,7 +133,8 @@ func (v *resourceVerber) Delete(kind string, namespace string, name string, dele
 }
 
 // Update patches resource of the given kind in the given namespace with the given name.
-func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
+// The patch is only validated by the apiserver if dryRun is set.
+func (v *resourceVerber) Update(object *unstructured.Unstructured, dryRun []string) error {
 	name := object.GetName()
 	namespace := object.GetNamespace()
 	gvr := v.groupVersionResourceFromUnstructured(object)
@@ -163,7 +164,7 @@ func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
 		}
 
 		klog.V(3).InfoS("patching resource", "group", gvr.Group, "version", gvr.Version, "resource", gvr.Resource, "name", name, "namespace", namespace, "patch", string(patchBytes))
-		_, updateErr := v.client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, k8stypes.MergePatchType, patchBytes, metav1.PatchOptions{})
+		_, updateErr := v.client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, k8stypes.MergePatchType, patchBytes, metav1.PatchOptions{DryRun: dryRun})
 		return updateErr
 	})
 }
@@ -178,17 +179,17 @@ func (v *resourceVerber) Get(kind string, namespace string, name string) (runtim
 }
 
 // Create creates the resource of the given kind in the given namespace with the given name.
-func (v *resourceVerber) Create(object *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+// The object is only validated by the apiserver if dryRun is set.
+func (v *resourceVerber) Create(object *unstructured.Unstructured, dryRun []string) (*unstructured.Unstructured, error) {
 	namespace := object.GetNamespace()
 	gvr := v.groupVersionResourceFromUnstructured(object)
 
-	return v.client.Resource(gvr).Namespace(namespace).Create(context.TODO(), object, metav1.CreateOptions{})
+	return v.client.Resource(gvr).Namespace(namespace).Create(context.TODO(), object, metav1.CreateOptions{DryRun: dryRun})
 }
 
-// VerberClient returns a resourceVerber client.
-func VerberClient(_ *http.Request) (ResourceVerber, error) {
-	// todo currently ignore rest.config from http.Request
-	restConfig, _, err := GetKarmadaConfig()
+// VerberClient returns a resourceVerber client which acts with the credentials of the given http.Request.
+func VerberClient(request *http.Request) (ResourceVerber, error) {
+	restConfig, err := GetKarmadaConfigFromRequest(request)
 	if err != nil {
 		return nil, err
 	}
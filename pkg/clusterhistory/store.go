@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhistory
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	_ "github.com/glebarez/sqlite" // Import the SQLite driver
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	createTableSQL = `
+        CREATE TABLE IF NOT EXISTS cluster_conditions (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            cluster TEXT NOT NULL,
+            type TEXT NOT NULL,
+            status TEXT NOT NULL,
+            reason TEXT,
+            message TEXT,
+            transition_time INTEGER NOT NULL
+        )
+    `
+	createIndexSQL = `
+        CREATE INDEX IF NOT EXISTS cluster_conditions_transition
+        ON cluster_conditions (cluster, type, transition_time)
+    `
+	lastTransitionSQL = `
+        SELECT type, status, reason, message, transition_time FROM cluster_conditions
+        WHERE cluster = ? AND type = ? AND transition_time <= ?
+        ORDER BY transition_time DESC, id DESC
+        LIMIT 1
+    `
+	transitionsSinceSQL = `
+        SELECT type, status, reason, message, transition_time FROM cluster_conditions
+        WHERE cluster = ? AND transition_time > ?
+        ORDER BY transition_time, id
+    `
+	// the Ready transitions of all clusters since a time, and the last one of every cluster before it
+	readyTransitionsSQL = `
+        SELECT cluster, type, status, reason, message, transition_time FROM (
+            SELECT id, cluster, type, status, reason, message, transition_time,
+                ROW_NUMBER() OVER (PARTITION BY cluster ORDER BY transition_time DESC, id DESC) AS position
+            FROM cluster_conditions WHERE type = ? AND transition_time <= ?
+        ) WHERE position = 1
+        UNION ALL
+        SELECT cluster, type, status, reason, message, transition_time
+        FROM cluster_conditions WHERE type = ? AND transition_time > ?
+        ORDER BY cluster, transition_time
+    `
+	insertTransitionSQL = `
+        INSERT INTO cluster_conditions (cluster, type, status, reason, message, transition_time)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+	deleteClusterSQL = `DELETE FROM cluster_conditions WHERE cluster = ?`
+	// the last transition of every condition before the cutoff is kept, it's the state at the start of the window
+	pruneSQL = `
+        DELETE FROM cluster_conditions WHERE transition_time < ? AND id NOT IN (
+            SELECT MAX(id) FROM cluster_conditions WHERE transition_time < ? GROUP BY cluster, type
+        )
+    `
+)
+
+// Window is a period availability is computed over.
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows are the periods availability is computed over, the longest one is also the retention of transitions.
+var Windows = []Window{
+	{Name: "24h", Duration: 24 * time.Hour},
+	{Name: "7d", Duration: 7 * 24 * time.Hour},
+	{Name: "30d", Duration: 30 * 24 * time.Hour},
+}
+
+// Transition is a change of a condition of cluster.
+type Transition struct {
+	Type    string                 `json:"type"`
+	Status  metav1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Time    metav1.Time            `json:"time"`
+}
+
+// Availability is the share of a window a cluster was Ready.
+type Availability struct {
+	Window string `json:"window"`
+	// Percentage is nil if the cluster has not been observed in the window.
+	Percentage *float64 `json:"percentage"`
+	// ObservedSeconds is the part of the window the state of the cluster is known for.
+	ObservedSeconds int64 `json:"observedSeconds"`
+	readySeconds    int64
+}
+
+// History is the condition timeline of a cluster.
+type History struct {
+	Cluster      string         `json:"cluster"`
+	Transitions  []Transition   `json:"transitions"`
+	Availability []Availability `json:"availability"`
+}
+
+// Store persists the condition transitions of clusters in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens the SQLite database at path and creates its schema.
+func NewStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?cache=shared&mode=rwc", path))
+	if err != nil {
+		return nil, err
+	}
+	// Restrict to 1 connection to prevent lock conflicts
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	for _, statement := range []string{createTableSQL, createIndexSQL} {
+		if _, err = db.Exec(statement); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record records the conditions of cluster which changed since they were last recorded. now is used as the
+// transition time of conditions without one.
+func (s *Store) Record(cluster *clusterv1alpha1.Cluster, now time.Time) error {
+	for _, condition := range cluster.Status.Conditions {
+		transitionTime := condition.LastTransitionTime.Time
+		if transitionTime.IsZero() {
+			transitionTime = now
+		}
+		last, err := s.lastTransition(cluster.Name, condition.Type, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		// the status may have flapped while nobody was watching if it's unchanged but transitioned later
+		if last != nil && last.Status == condition.Status && !transitionTime.After(last.Time.Time) {
+			continue
+		}
+		if _, err = s.db.Exec(insertTransitionSQL, cluster.Name, condition.Type, string(condition.Status),
+			condition.Reason, condition.Message, transitionTime.Unix()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the history of cluster.
+func (s *Store) Delete(cluster string) error {
+	_, err := s.db.Exec(deleteClusterSQL, cluster)
+	return err
+}
+
+// Prune removes the transitions which are older than the longest window, except those that tell the state
+// of a cluster at the start of it.
+func (s *Store) Prune(now time.Time) error {
+	cutoff := now.Add(-Windows[len(Windows)-1].Duration).Unix()
+	_, err := s.db.Exec(pruneSQL, cutoff, cutoff)
+	return err
+}
+
+// History returns the transitions of cluster within the longest window and its availability in every window.
+func (s *Store) History(cluster string, now time.Time) (*History, error) {
+	since := now.Add(-Windows[len(Windows)-1].Duration)
+	rows, err := s.db.Query(transitionsSinceSQL, cluster, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := &History{Cluster: cluster, Transitions: make([]Transition, 0)}
+	for rows.Next() {
+		transition, err := scanTransition(rows)
+		if err != nil {
+			return nil, err
+		}
+		history.Transitions = append(history.Transitions, *transition)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, window := range Windows {
+		start := now.Add(-window.Duration)
+		initial, err := s.lastTransition(cluster, clusterv1alpha1.ClusterConditionReady, start.Unix())
+		if err != nil {
+			return nil, err
+		}
+		history.Availability = append(history.Availability, availability(window.Name, initial, history.Transitions, start, now))
+	}
+	return history, nil
+}
+
+// Summary returns the availability of all the given clusters together in every window, it's weighted by the
+// time each cluster has been observed. The Ready transitions of all clusters are read in a single query.
+func (s *Store) Summary(clusters []string, now time.Time) ([]Availability, error) {
+	since := now.Add(-Windows[len(Windows)-1].Duration).Unix()
+	rows, err := s.db.Query(readyTransitionsSQL, clusterv1alpha1.ClusterConditionReady, since,
+		clusterv1alpha1.ClusterConditionReady, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	transitions := make(map[string][]Transition, len(clusters))
+	for _, cluster := range clusters {
+		transitions[cluster] = nil
+	}
+	for rows.Next() {
+		var cluster string
+		transition, err := scanTransition(clusterScanner{row: rows, cluster: &cluster})
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := transitions[cluster]; ok {
+			transitions[cluster] = append(transitions[cluster], *transition)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summary := make([]Availability, len(Windows))
+	for i, window := range Windows {
+		summary[i].Window = window.Name
+		start := now.Add(-window.Duration)
+		for _, clusterTransitions := range transitions {
+			a := availability(window.Name, initialTransition(clusterTransitions, start), clusterTransitions, start, now)
+			summary[i].ObservedSeconds += a.ObservedSeconds
+			summary[i].readySeconds += a.readySeconds
+		}
+		summary[i].Percentage = percentage(summary[i].readySeconds, summary[i].ObservedSeconds)
+	}
+	return summary, nil
+}
+
+// initialTransition returns the last of the ordered transitions which is not after start.
+func initialTransition(transitions []Transition, start time.Time) *Transition {
+	var initial *Transition
+	for i := range transitions {
+		if transitions[i].Time.After(start) {
+			break
+		}
+		initial = &transitions[i]
+	}
+	return initial
+}
+
+// lastTransition returns the latest transition of the condition of cluster which is not after the unix time before.
+func (s *Store) lastTransition(cluster, conditionType string, before int64) (*Transition, error) {
+	transition, err := scanTransition(s.db.QueryRow(lastTransitionSQL, cluster, conditionType, before))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return transition, err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// clusterScanner scans the leading cluster column of a row into cluster and the rest as a transition.
+type clusterScanner struct {
+	row     scanner
+	cluster *string
+}
+
+func (s clusterScanner) Scan(dest ...any) error {
+	return s.row.Scan(append([]any{s.cluster}, dest...)...)
+}
+
+func scanTransition(row scanner) (*Transition, error) {
+	var transition Transition
+	var status string
+	var reason, message sql.NullString
+	var transitionTime int64
+	if err := row.Scan(&transition.Type, &status, &reason, &message, &transitionTime); err != nil {
+		return nil, err
+	}
+	transition.Status = metav1.ConditionStatus(status)
+	transition.Reason = reason.String
+	transition.Message = message.String
+	transition.Time = metav1.NewTime(time.Unix(transitionTime, 0))
+	return &transition, nil
+}
+
+// availability computes the share of [start, end) the cluster was Ready. initial is the Ready transition in effect
+// at start, the time before the first known transition is not observed.
+func availability(window string, initial *Transition, transitions []Transition, start, end time.Time) Availability {
+	var observed, ready time.Duration
+	current := initial
+	since := start
+	account := func(until time.Time) {
+		if current == nil || !until.After(since) {
+			return
+		}
+		observed += until.Sub(since)
+		if current.Status == metav1.ConditionTrue {
+			ready += until.Sub(since)
+		}
+	}
+	for i := range transitions {
+		transition := &transitions[i]
+		if transition.Type != clusterv1alpha1.ClusterConditionReady || !transition.Time.After(start) {
+			continue
+		}
+		if !transition.Time.Time.Before(end) {
+			break
+		}
+		account(transition.Time.Time)
+		current = transition
+		since = transition.Time.Time
+	}
+	account(end)
+
+	result := Availability{
+		Window:          window,
+		ObservedSeconds: int64(observed.Seconds()),
+		readySeconds:    int64(ready.Seconds()),
+	}
+	result.Percentage = percentage(result.readySeconds, result.ObservedSeconds)
+	return result
+}
+
+func percentage(ready, observed int64) *float64 {
+	if observed == 0 {
+		return nil
+	}
+	p := math.Round(float64(ready)/float64(observed)*100000) / 1000
+	return &p
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCluster(name string, status metav1.ConditionStatus, transitionTime time.Time) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: clusterv1alpha1.ClusterStatus{
+			Conditions: []metav1.Condition{{
+				Type:               clusterv1alpha1.ClusterConditionReady,
+				Status:             status,
+				Reason:             "ClusterReady",
+				LastTransitionTime: metav1.NewTime(transitionTime),
+			}},
+		},
+	}
+}
+
+func newTestStore(t *testing.T) *Store {
+	store, err := NewStore(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store
+}
+
+func record(t *testing.T, store *Store, clusters ...*clusterv1alpha1.Cluster) {
+	for _, cluster := range clusters {
+		if err := store.Record(cluster, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	// ready since 10 days, not ready for 6 hours 2 days ago and for 12 hours yesterday
+	record(t, store,
+		newCluster("member1", metav1.ConditionTrue, now.Add(-240*time.Hour)),
+		newCluster("member1", metav1.ConditionTrue, now.Add(-240*time.Hour)),
+		newCluster("member1", metav1.ConditionFalse, now.Add(-48*time.Hour)),
+		newCluster("member1", metav1.ConditionTrue, now.Add(-42*time.Hour)),
+		newCluster("member1", metav1.ConditionFalse, now.Add(-18*time.Hour)),
+		newCluster("member1", metav1.ConditionTrue, now.Add(-6*time.Hour)),
+	)
+
+	history, err := store.History("member1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Transitions) != 5 {
+		t.Fatalf("expected unchanged conditions to be recorded once, got %+v", history.Transitions)
+	}
+	expected := map[string]struct {
+		percentage float64
+		observed   time.Duration
+	}{
+		"24h": {50, 24 * time.Hour},
+		"7d":  {100 - 18.0/168*100, 168 * time.Hour},
+		"30d": {100 - 18.0/240*100, 240 * time.Hour},
+	}
+	for _, a := range history.Availability {
+		e := expected[a.Window]
+		if a.Percentage == nil || *a.Percentage-e.percentage > 0.001 || e.percentage-*a.Percentage > 0.001 ||
+			a.ObservedSeconds != int64(e.observed.Seconds()) {
+			t.Errorf("unexpected availability %+v in window %s, expected %+v", a, a.Window, e)
+		}
+	}
+
+	history, err = store.History("member2", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Transitions) != 0 || history.Availability[0].Percentage != nil {
+		t.Errorf("expected no history of unknown cluster, got %+v", history)
+	}
+}
+
+func TestSummaryAndPrune(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+
+	record(t, store,
+		newCluster("member1", metav1.ConditionTrue, now.Add(-60*24*time.Hour)),
+		newCluster("member1", metav1.ConditionFalse, now.Add(-50*24*time.Hour)),
+		newCluster("member1", metav1.ConditionTrue, now.Add(-40*24*time.Hour)),
+		newCluster("member2", metav1.ConditionFalse, now.Add(-12*time.Hour)),
+		newCluster("member3", metav1.ConditionFalse, now.Add(-48*time.Hour)),
+	)
+	if err := store.Prune(now); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM cluster_conditions").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("expected the last transition before retention to be kept, got %d transitions", count)
+	}
+
+	summary, err := store.Summary([]string{"member1", "member2"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// member1 is ready for the whole day and member2 for none of the 12 hours it's known, member3 is not asked for
+	if summary[0].Window != "24h" || summary[0].Percentage == nil || *summary[0].Percentage < 66.66 || *summary[0].Percentage > 66.67 {
+		t.Errorf("unexpected summary %+v", summary[0])
+	}
+	// member1 is ready for the 7 days, member2 is still known for 12 hours only
+	if summary[1].Window != "7d" || summary[1].ObservedSeconds != int64((7*24+12)*time.Hour/time.Second) {
+		t.Errorf("unexpected summary %+v", summary[1])
+	}
+
+	if err = store.Delete("member1"); err != nil {
+		t.Fatal(err)
+	}
+	history, err := store.History("member1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.Availability[0].Percentage != nil {
+		t.Errorf("expected history of deleted cluster to be removed, got %+v", history)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhistory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	informerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// prunePeriod is how often transitions beyond the retention are removed.
+const prunePeriod = time.Hour
+
+// ErrDisabled is returned when the history is queried but no store has been initialized.
+var ErrDisabled = errors.New("cluster history is not enabled")
+
+var defaultStore *Store
+
+// Init opens the store at path and records the condition transitions of all clusters of karmada into it
+// until ctx is done.
+func Init(ctx context.Context, karmadaClient karmadaclientset.Interface, path string) error {
+	store, err := NewStore(path)
+	if err != nil {
+		return err
+	}
+	factory := informerfactory.NewSharedInformerFactory(karmadaClient, 0)
+	informer := factory.Cluster().V1alpha1().Clusters().Informer()
+	if _, err = informer.AddEventHandler(newEventHandler(store)); err != nil {
+		_ = store.Close()
+		return err
+	}
+	factory.Start(ctx.Done())
+	go func() {
+		<-ctx.Done()
+		factory.Shutdown()
+		if err := store.Close(); err != nil {
+			klog.ErrorS(err, "Failed to close cluster history")
+		}
+	}()
+	go prune(ctx, store)
+	defaultStore = store
+	klog.InfoS("Cluster history initialized", "path", path)
+	return nil
+}
+
+// Get returns the history of cluster from the default store.
+func Get(cluster string) (*History, error) {
+	if defaultStore == nil {
+		return nil, ErrDisabled
+	}
+	return defaultStore.History(cluster, time.Now())
+}
+
+// GetSummary returns the availability of clusters from the default store.
+func GetSummary(clusters []string) ([]Availability, error) {
+	if defaultStore == nil {
+		return nil, ErrDisabled
+	}
+	return defaultStore.Summary(clusters, time.Now())
+}
+
+func newEventHandler(store *Store) cache.ResourceEventHandlerFuncs {
+	record := func(obj interface{}) {
+		cluster, ok := obj.(*clusterv1alpha1.Cluster)
+		if !ok {
+			return
+		}
+		if err := store.Record(cluster, time.Now()); err != nil {
+			klog.ErrorS(err, "Failed to record cluster conditions", "cluster", cluster.Name)
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: record,
+		UpdateFunc: func(_, newObj interface{}) {
+			record(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			cluster, ok := obj.(*clusterv1alpha1.Cluster)
+			if !ok {
+				return
+			}
+			if err := store.Delete(cluster.Name); err != nil {
+				klog.ErrorS(err, "Failed to delete cluster history", "cluster", cluster.Name)
+			}
+		},
+	}
+}
+
+func prune(ctx context.Context, store *Store) {
+	ticker := time.NewTicker(prunePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := store.Prune(now); err != nil {
+				klog.ErrorS(err, "Failed to prune cluster history")
+			}
+		}
+	}
+}
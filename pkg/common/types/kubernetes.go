@@ -58,6 +58,10 @@ type ObjectMeta struct {
 	// don't ONLY use UUIDs, this is an alias to string.  Being a type captures
 	// intent and helps make sure that UIDs and names do not get conflated.
 	UID types.UID `json:"uid,omitempty"`
+
+	// ResourceVersion is the version of the object the response was read at, updates based on it fail with
+	// a conflict if the object was changed since.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
 }
 
 // TypeMeta describes an individual object in an API response or request with strings representing
@@ -92,6 +96,7 @@ func NewObjectMeta(k8SObjectMeta metaV1.ObjectMeta) ObjectMeta {
 		CreationTimestamp: k8SObjectMeta.CreationTimestamp,
 		Annotations:       k8SObjectMeta.Annotations,
 		UID:               k8SObjectMeta.UID,
+		ResourceVersion:   k8SObjectMeta.ResourceVersion,
 	}
 }
 
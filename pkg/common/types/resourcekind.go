@@ -23,41 +23,43 @@ type ResourceKind string
 
 // List of all resource kinds supported by the UI.
 const (
-	ResourceKindCluster                  = "cluster"
-	ResourceKindPropagationPolicy        = "propagationpolicy"
-	ResourceKindClusterPropagationPolicy = "clusterpropagationpolicy"
-	ResourceKindOverridePolicy           = "overridepolicy"
-	ResourceKindClusterOverridePolicy    = "clusteroverridepolicy"
-	ResourceKindConfigMap                = "configmap"
-	ResourceKindDaemonSet                = "daemonset"
-	ResourceKindDeployment               = "deployment"
-	ResourceKindEvent                    = "event"
-	ResourceKindHorizontalPodAutoscaler  = "horizontalpodautoscaler"
-	ResourceKindIngress                  = "ingress"
-	ResourceKindServiceAccount           = "serviceaccount"
-	ResourceKindJob                      = "job"
-	ResourceKindCronJob                  = "cronjob"
-	ResourceKindLimitRange               = "limitrange"
-	ResourceKindNamespace                = "namespace"
-	ResourceKindNode                     = "node"
-	ResourceKindPersistentVolumeClaim    = "persistentvolumeclaim"
-	ResourceKindPersistentVolume         = "persistentvolume"
-	ResourceKindCustomResourceDefinition = "customresourcedefinition"
-	ResourceKindPod                      = "pod"
-	ResourceKindReplicaSet               = "replicaset"
-	ResourceKindReplicationController    = "replicationcontroller"
-	ResourceKindResourceQuota            = "resourcequota"
-	ResourceKindSecret                   = "secret"
-	ResourceKindService                  = "service"
-	ResourceKindStatefulSet              = "statefulset"
-	ResourceKindStorageClass             = "storageclass"
-	ResourceKindClusterRole              = "clusterrole"
-	ResourceKindClusterRoleBinding       = "clusterrolebinding"
-	ResourceKindRole                     = "role"
-	ResourceKindRoleBinding              = "rolebinding"
-	ResourceKindEndpoint                 = "endpoint"
-	ResourceKindNetworkPolicy            = "networkpolicy"
-	ResourceKindIngressClass             = "ingressclass"
+	ResourceKindCluster                   = "cluster"
+	ResourceKindPropagationPolicy         = "propagationpolicy"
+	ResourceKindClusterPropagationPolicy  = "clusterpropagationpolicy"
+	ResourceKindOverridePolicy            = "overridepolicy"
+	ResourceKindClusterOverridePolicy     = "clusteroverridepolicy"
+	ResourceKindConfigMap                 = "configmap"
+	ResourceKindDaemonSet                 = "daemonset"
+	ResourceKindDeployment                = "deployment"
+	ResourceKindEvent                     = "event"
+	ResourceKindHorizontalPodAutoscaler   = "horizontalpodautoscaler"
+	ResourceKindIngress                   = "ingress"
+	ResourceKindServiceAccount            = "serviceaccount"
+	ResourceKindJob                       = "job"
+	ResourceKindCronJob                   = "cronjob"
+	ResourceKindLimitRange                = "limitrange"
+	ResourceKindNamespace                 = "namespace"
+	ResourceKindNode                      = "node"
+	ResourceKindPersistentVolumeClaim     = "persistentvolumeclaim"
+	ResourceKindPersistentVolume          = "persistentvolume"
+	ResourceKindCustomResourceDefinition  = "customresourcedefinition"
+	ResourceKindPod                       = "pod"
+	ResourceKindReplicaSet                = "replicaset"
+	ResourceKindReplicationController     = "replicationcontroller"
+	ResourceKindResourceQuota             = "resourcequota"
+	ResourceKindSecret                    = "secret"
+	ResourceKindService                   = "service"
+	ResourceKindStatefulSet               = "statefulset"
+	ResourceKindStorageClass              = "storageclass"
+	ResourceKindClusterRole               = "clusterrole"
+	ResourceKindClusterRoleBinding        = "clusterrolebinding"
+	ResourceKindRole                      = "role"
+	ResourceKindRoleBinding               = "rolebinding"
+	ResourceKindEndpoint                  = "endpoint"
+	ResourceKindNetworkPolicy             = "networkpolicy"
+	ResourceKindIngressClass              = "ingressclass"
+	ResourceKindUser                      = "user"
+	ResourceKindCertificateSigningRequest = "certificatesigningrequest"
 )
 
 // Scalable method return whether ResourceKind is scalable.
@@ -92,3 +94,50 @@ func (k ResourceKind) Restartable() bool {
 
 	return false
 }
+
+// APIResource is the api group and resource name a ResourceKind is served as by the karmada apiserver.
+type APIResource struct {
+	Group      string
+	Resource   string
+	Namespaced bool
+}
+
+// KindToAPIResource maps the resource kinds supported by the UI to their api resources.
+var KindToAPIResource = map[ResourceKind]APIResource{
+	ResourceKindCluster:                   {Group: "cluster.karmada.io", Resource: "clusters"},
+	ResourceKindPropagationPolicy:         {Group: "policy.karmada.io", Resource: "propagationpolicies", Namespaced: true},
+	ResourceKindClusterPropagationPolicy:  {Group: "policy.karmada.io", Resource: "clusterpropagationpolicies"},
+	ResourceKindOverridePolicy:            {Group: "policy.karmada.io", Resource: "overridepolicies", Namespaced: true},
+	ResourceKindClusterOverridePolicy:     {Group: "policy.karmada.io", Resource: "clusteroverridepolicies"},
+	ResourceKindConfigMap:                 {Resource: "configmaps", Namespaced: true},
+	ResourceKindDaemonSet:                 {Group: "apps", Resource: "daemonsets", Namespaced: true},
+	ResourceKindDeployment:                {Group: "apps", Resource: "deployments", Namespaced: true},
+	ResourceKindEvent:                     {Resource: "events", Namespaced: true},
+	ResourceKindHorizontalPodAutoscaler:   {Group: "autoscaling", Resource: "horizontalpodautoscalers", Namespaced: true},
+	ResourceKindIngress:                   {Group: "networking.k8s.io", Resource: "ingresses", Namespaced: true},
+	ResourceKindServiceAccount:            {Resource: "serviceaccounts", Namespaced: true},
+	ResourceKindJob:                       {Group: "batch", Resource: "jobs", Namespaced: true},
+	ResourceKindCronJob:                   {Group: "batch", Resource: "cronjobs", Namespaced: true},
+	ResourceKindLimitRange:                {Resource: "limitranges", Namespaced: true},
+	ResourceKindNamespace:                 {Resource: "namespaces"},
+	ResourceKindNode:                      {Resource: "nodes"},
+	ResourceKindPersistentVolumeClaim:     {Resource: "persistentvolumeclaims", Namespaced: true},
+	ResourceKindPersistentVolume:          {Resource: "persistentvolumes"},
+	ResourceKindCustomResourceDefinition:  {Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	ResourceKindPod:                       {Resource: "pods", Namespaced: true},
+	ResourceKindReplicaSet:                {Group: "apps", Resource: "replicasets", Namespaced: true},
+	ResourceKindReplicationController:     {Resource: "replicationcontrollers", Namespaced: true},
+	ResourceKindResourceQuota:             {Resource: "resourcequotas", Namespaced: true},
+	ResourceKindSecret:                    {Resource: "secrets", Namespaced: true},
+	ResourceKindService:                   {Resource: "services", Namespaced: true},
+	ResourceKindStatefulSet:               {Group: "apps", Resource: "statefulsets", Namespaced: true},
+	ResourceKindStorageClass:              {Group: "storage.k8s.io", Resource: "storageclasses"},
+	ResourceKindClusterRole:               {Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	ResourceKindClusterRoleBinding:        {Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	ResourceKindRole:                      {Group: "rbac.authorization.k8s.io", Resource: "roles", Namespaced: true},
+	ResourceKindRoleBinding:               {Group: "rbac.authorization.k8s.io", Resource: "rolebindings", Namespaced: true},
+	ResourceKindEndpoint:                  {Resource: "endpoints", Namespaced: true},
+	ResourceKindNetworkPolicy:             {Group: "networking.k8s.io", Resource: "networkpolicies", Namespaced: true},
+	ResourceKindIngressClass:              {Group: "networking.k8s.io", Resource: "ingressclasses"},
+	ResourceKindCertificateSigningRequest: {Group: "certificates.k8s.io", Resource: "certificatesigningrequests"},
+}
@@ -28,4 +28,11 @@ const (
 	FirstSeenProperty         = "firstSeen"
 	LastSeenProperty          = "lastSeen"
 	ReasonProperty            = "reason"
+	UserProperty              = "user"
+	KindProperty              = "kind"
+	MethodProperty            = "method"
+	OutcomeProperty           = "outcome"
+	ClusterProperty           = "cluster"
+	GroupProperty             = "group"
+	VersionProperty           = "version"
 )
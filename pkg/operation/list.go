@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// OperationList contains a list of operations.
+type OperationList struct {
+	ListMeta   types.ListMeta `json:"listMeta"`
+	Operations []Operation    `json:"operations"`
+}
+
+// GetOperationList returns the operations of the default manager started by user and selected by dsQuery,
+// the latest operations come first unless another sort is requested. No operations are returned for an empty user.
+func GetOperationList(user string, dsQuery *dataselect.DataSelectQuery) *OperationList {
+	if dsQuery.SortQuery == nil || len(dsQuery.SortQuery.SortByList) == 0 {
+		dsQuery = dataselect.NewDataSelectQuery(dsQuery.PaginationQuery,
+			dataselect.NewSortQuery([]string{"d", dataselect.CreationTimestampProperty}), dsQuery.FilterQuery)
+	}
+	cells := make([]dataselect.DataCell, 0)
+	for _, operation := range List() {
+		if len(user) > 0 && operation.User == user {
+			cells = append(cells, OperationCell(*operation))
+		}
+	}
+	selected, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+	list := &OperationList{
+		ListMeta:   types.ListMeta{TotalItems: filteredTotal},
+		Operations: make([]Operation, len(selected)),
+	}
+	for i := range selected {
+		list.Operations[i] = Operation(selected[i].(OperationCell))
+	}
+	return list
+}
+
+// OperationCell is a cell representation of Operation.
+type OperationCell Operation
+
+// GetProperty returns value of a given property.
+func (o OperationCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(o.Target)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(o.CreatedAt)
+	case dataselect.TypeProperty:
+		return dataselect.StdComparableString(o.Type)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(o.Phase)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
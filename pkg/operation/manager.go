@@ -0,0 +1,465 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// configMapPrefix is the prefix of the ConfigMaps in host cluster which persist operations.
+	configMapPrefix = "karmada-dashboard-operation-"
+	// operationLabel is set on the ConfigMaps which persist operations.
+	operationLabel = "dashboard.karmada.io/operation"
+	operationKey   = "operation"
+	// retention is how long finished operations are kept.
+	retention = 24 * time.Hour
+	gcPeriod  = 10 * time.Minute
+	// persistTimeout bounds the writes of operation state, they must not be cancelled with the operation itself.
+	persistTimeout = 10 * time.Second
+	// interruptedMessage is the message of operations which were in progress when the api stopped.
+	interruptedMessage = "operation was interrupted by a restart of karmada-dashboard-api"
+)
+
+var (
+	// ErrNotFound is returned when no operation with the given id exists.
+	ErrNotFound = errors.New("operation not found")
+	// ErrFinished is returned when cancelling an operation which has already finished.
+	ErrFinished = errors.New("operation has already finished")
+
+	defaultManager = NewManager(nil, "")
+)
+
+// Func performs an operation, it should run its steps with Recorder.Step and return once ctx is done.
+type Func func(ctx context.Context, recorder *Recorder) error
+
+// Manager runs operations in background and persists their progress in ConfigMaps of host cluster.
+type Manager struct {
+	kubeClient kubeclient.Interface
+	namespace  string
+
+	lock        sync.RWMutex
+	operations  map[string]*entry
+	persistLock sync.Mutex
+	// ctx is the parent of all operations, they are cancelled once it's done.
+	ctx context.Context
+}
+
+type entry struct {
+	operation *Operation
+	cancel    context.CancelFunc
+	// changed is closed and replaced every time the operation changes, watchers wait on it.
+	changed chan struct{}
+}
+
+// NewManager returns a manager which persists operations with kubeClient in namespace, operations are only
+// kept in memory if kubeClient is nil.
+func NewManager(kubeClient kubeclient.Interface, namespace string) *Manager {
+	return &Manager{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		operations: make(map[string]*entry),
+		ctx:        context.Background(),
+	}
+}
+
+// Init makes the default manager persist operations in the ConfigMaps of namespace, the operations persisted by
+// previous runs are loaded and the ones that were in progress are marked as failed.
+func Init(ctx context.Context, kubeClient kubeclient.Interface, namespace string) error {
+	manager := NewManager(kubeClient, namespace)
+	if err := manager.Load(ctx); err != nil {
+		return err
+	}
+	go manager.collectGarbage(ctx)
+	defaultManager = manager
+	klog.InfoS("Operations initialized", "namespace", namespace)
+	return nil
+}
+
+// Start starts an operation with the default manager.
+func Start(operationType Type, target, user string, steps []string, fn Func) *Operation {
+	return defaultManager.Start(operationType, target, user, steps, fn)
+}
+
+// Get returns the operation of the default manager.
+func Get(id string) (*Operation, error) {
+	return defaultManager.Get(id)
+}
+
+// List returns the operations of the default manager.
+func List() []*Operation {
+	return defaultManager.List()
+}
+
+// Cancel cancels the operation of the default manager.
+func Cancel(id string) (*Operation, error) {
+	return defaultManager.Cancel(id)
+}
+
+// Watch watches the operation of the default manager.
+func Watch(ctx context.Context, id string) (<-chan *Operation, error) {
+	return defaultManager.Watch(ctx, id)
+}
+
+// Load loads the persisted operations, operations which were in progress can not be resumed since their
+// clients are gone with the previous process, they are marked as failed.
+func (m *Manager) Load(ctx context.Context) error {
+	if m.kubeClient == nil {
+		return nil
+	}
+	m.ctx = ctx
+	configMaps, err := m.kubeClient.CoreV1().ConfigMaps(m.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{operationLabel: "true"}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		operation := &Operation{}
+		if err = json.Unmarshal([]byte(configMaps.Items[i].Data[operationKey]), operation); err != nil {
+			klog.ErrorS(err, "Could not decode persisted operation", "configmap", configMaps.Items[i].Name)
+			continue
+		}
+		interrupted := !operation.Phase.IsFinished()
+		if interrupted {
+			for i := range operation.Steps {
+				if operation.Steps[i].Phase == PhaseRunning {
+					completeStep(&operation.Steps[i], PhaseFailed, interruptedMessage)
+				}
+			}
+			finish(operation, PhaseFailed, interruptedMessage)
+		}
+		m.lock.Lock()
+		m.operations[operation.ID] = &entry{operation: operation, changed: make(chan struct{})}
+		m.lock.Unlock()
+		if interrupted {
+			m.persist(operation.ID)
+		}
+	}
+	return nil
+}
+
+// Start runs fn in background and returns the operation which records its progress, steps are the names of
+// steps fn is going to run so that clients can show the pending ones.
+func (m *Manager) Start(operationType Type, target, user string, steps []string, fn Func) *Operation {
+	operation := &Operation{
+		ID:        string(uuid.NewUUID()),
+		Type:      operationType,
+		Target:    target,
+		User:      user,
+		Phase:     PhaseRunning,
+		Steps:     make([]Step, 0, len(steps)),
+		CreatedAt: time.Now(),
+	}
+	for _, name := range steps {
+		operation.Steps = append(operation.Steps, Step{Name: name, Phase: PhasePending})
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.lock.Lock()
+	m.operations[operation.ID] = &entry{operation: operation, cancel: cancel, changed: make(chan struct{})}
+	snapshot := operation.DeepCopy()
+	m.lock.Unlock()
+	m.persist(operation.ID)
+
+	go func() {
+		defer cancel()
+		err := fn(ctx, &Recorder{manager: m, id: operation.ID})
+		m.update(operation.ID, func(o *Operation) {
+			switch {
+			case o.Phase == PhaseCancelled:
+			case err != nil:
+				finish(o, PhaseFailed, err.Error())
+			default:
+				finish(o, PhaseSucceeded, "")
+			}
+		})
+		if err != nil {
+			klog.ErrorS(err, "Operation failed", "id", operation.ID, "type", operationType, "target", target)
+		}
+	}()
+	return snapshot
+}
+
+// Get returns a copy of the operation.
+func (m *Manager) Get(id string) (*Operation, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	e, ok := m.operations[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return e.operation.DeepCopy(), nil
+}
+
+// List returns copies of all operations, the latest first.
+func (m *Manager) List() []*Operation {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	operations := make([]*Operation, 0, len(m.operations))
+	for _, e := range m.operations {
+		operations = append(operations, e.operation.DeepCopy())
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.After(operations[j].CreatedAt)
+	})
+	return operations
+}
+
+// Cancel cancels the context of the operation and marks it as cancelled, the step in progress is marked as failed.
+func (m *Manager) Cancel(id string) (*Operation, error) {
+	m.lock.RLock()
+	e, ok := m.operations[id]
+	m.lock.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var cancelled bool
+	operation := m.update(id, func(o *Operation) {
+		if o.Phase.IsFinished() {
+			return
+		}
+		cancelled = true
+		for i := range o.Steps {
+			if o.Steps[i].Phase == PhaseRunning {
+				completeStep(&o.Steps[i], PhaseFailed, context.Canceled.Error())
+			}
+		}
+		finish(o, PhaseCancelled, "operation was cancelled")
+	})
+	if !cancelled {
+		return operation, ErrFinished
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	return operation, nil
+}
+
+// Watch returns a channel which receives the operation every time it changes, starting with its current state.
+// The channel is closed once the operation finished or ctx is done.
+func (m *Manager) Watch(ctx context.Context, id string) (<-chan *Operation, error) {
+	if _, err := m.Get(id); err != nil {
+		return nil, err
+	}
+	ch := make(chan *Operation)
+	go func() {
+		defer close(ch)
+		for {
+			m.lock.RLock()
+			e, ok := m.operations[id]
+			if !ok {
+				m.lock.RUnlock()
+				return
+			}
+			operation, changed := e.operation.DeepCopy(), e.changed
+			m.lock.RUnlock()
+
+			select {
+			case ch <- operation:
+			case <-ctx.Done():
+				return
+			}
+			if operation.Phase.IsFinished() {
+				return
+			}
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// update applies fn to the operation, notifies watchers and persists the result.
+func (m *Manager) update(id string, fn func(o *Operation)) *Operation {
+	m.lock.Lock()
+	e, ok := m.operations[id]
+	if !ok {
+		m.lock.Unlock()
+		return nil
+	}
+	fn(e.operation)
+	close(e.changed)
+	e.changed = make(chan struct{})
+	snapshot := e.operation.DeepCopy()
+	m.lock.Unlock()
+
+	m.persist(id)
+	return snapshot
+}
+
+// persist writes the latest state of operation into its ConfigMap, writes are serialized so that an older state
+// never overwrites a newer one. Failures are only logged since the operation itself is not affected.
+func (m *Manager) persist(id string) {
+	if m.kubeClient == nil {
+		return
+	}
+	m.persistLock.Lock()
+	defer m.persistLock.Unlock()
+	operation, err := m.Get(id)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(operation)
+	if err != nil {
+		klog.ErrorS(err, "Could not encode operation", "id", operation.ID)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+	defer cancel()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapPrefix + operation.ID,
+			Namespace: m.namespace,
+			Labels:    map[string]string{operationLabel: "true"},
+		},
+		Data: map[string]string{operationKey: string(data)},
+	}
+	configMaps := m.kubeClient.CoreV1().ConfigMaps(m.namespace)
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	}
+	if err != nil {
+		klog.ErrorS(err, "Could not persist operation", "id", operation.ID, "namespace", m.namespace)
+	}
+}
+
+// collectGarbage removes the operations which finished longer than retention ago.
+func (m *Manager) collectGarbage(ctx context.Context) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		expired := make([]string, 0)
+		m.lock.Lock()
+		for id, e := range m.operations {
+			if e.operation.CompletedAt != nil && time.Since(*e.operation.CompletedAt) > retention {
+				expired = append(expired, id)
+				delete(m.operations, id)
+			}
+		}
+		m.lock.Unlock()
+		for _, id := range expired {
+			err := m.kubeClient.CoreV1().ConfigMaps(m.namespace).Delete(ctx, configMapPrefix+id, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Could not delete expired operation", "id", id)
+			}
+		}
+	}, gcPeriod)
+}
+
+// Recorder records the progress of the steps of an operation.
+type Recorder struct {
+	manager *Manager
+	id      string
+}
+
+// Step runs fn as the named step of the operation and records its progress, the error of fn is returned.
+func (r *Recorder) Step(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.manager.update(r.id, func(o *Operation) {
+		step := findStep(o, name)
+		now := time.Now()
+		step.Phase = PhaseRunning
+		step.StartedAt = &now
+	})
+	err := fn(ctx)
+	r.manager.update(r.id, func(o *Operation) {
+		step := findStep(o, name)
+		if step.Phase.IsFinished() {
+			// the operation has been cancelled meanwhile
+			return
+		}
+		if err != nil {
+			completeStep(step, PhaseFailed, err.Error())
+			return
+		}
+		completeStep(step, PhaseSucceeded, step.Message)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// Progress sets the message of the named step while it's running, e.g. to report how much work is left.
+func (r *Recorder) Progress(name, message string) {
+	r.manager.lock.RLock()
+	e, ok := r.manager.operations[r.id]
+	unchanged := ok && findRunningStepMessage(e.operation, name) == message
+	r.manager.lock.RUnlock()
+	if !ok || unchanged {
+		return
+	}
+	r.manager.update(r.id, func(o *Operation) {
+		step := findStep(o, name)
+		if step.Phase == PhaseRunning {
+			step.Message = message
+		}
+	})
+}
+
+func findRunningStepMessage(o *Operation, name string) string {
+	for i := range o.Steps {
+		if o.Steps[i].Name == name && o.Steps[i].Phase == PhaseRunning {
+			return o.Steps[i].Message
+		}
+	}
+	return ""
+}
+
+// findStep returns the step of name, it's appended if the step was not declared when the operation started.
+func findStep(o *Operation, name string) *Step {
+	for i := range o.Steps {
+		if o.Steps[i].Name == name {
+			return &o.Steps[i]
+		}
+	}
+	o.Steps = append(o.Steps, Step{Name: name, Phase: PhasePending})
+	return &o.Steps[len(o.Steps)-1]
+}
+
+func completeStep(step *Step, phase Phase, message string) {
+	now := time.Now()
+	step.Phase = phase
+	step.Message = message
+	step.CompletedAt = &now
+}
+
+func finish(o *Operation, phase Phase, message string) {
+	now := time.Now()
+	o.Phase = phase
+	o.Message = message
+	o.CompletedAt = &now
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "karmada-system"
+
+// waitFinished watches the operation until it finished and returns its last state.
+func waitFinished(t *testing.T, m *Manager, id string) *Operation {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ch, err := m.Watch(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last *Operation
+	for operation := range ch {
+		last = operation
+	}
+	if last == nil || !last.Phase.IsFinished() {
+		t.Fatalf("operation %s did not finish: %+v", id, last)
+	}
+	return last
+}
+
+func persisted(t *testing.T, m *Manager, id string) *Operation {
+	t.Helper()
+	configMap, err := m.kubeClient.CoreV1().ConfigMaps(testNamespace).Get(context.TODO(), configMapPrefix+id, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation := &Operation{}
+	if err = json.Unmarshal([]byte(configMap.Data[operationKey]), operation); err != nil {
+		t.Fatal(err)
+	}
+	return operation
+}
+
+func TestStartRunsSteps(t *testing.T) {
+	m := NewManager(fake.NewSimpleClientset(), testNamespace)
+	started := m.Start(TypeClusterJoin, "member1", "alice", []string{"first", "second"}, func(ctx context.Context, r *Recorder) error {
+		if err := r.Step(ctx, "first", func(context.Context) error { return nil }); err != nil {
+			return err
+		}
+		return r.Step(ctx, "second", func(context.Context) error {
+			r.Progress("second", "1 of 2 done")
+			r.Progress("second", "2 of 2 done")
+			return nil
+		})
+	})
+	if started.Phase != PhaseRunning || len(started.Steps) != 2 || started.Steps[1].Phase != PhasePending {
+		t.Fatalf("unexpected started operation: %+v", started)
+	}
+
+	finished := waitFinished(t, m, started.ID)
+	if finished.Phase != PhaseSucceeded || finished.CompletedAt == nil {
+		t.Fatalf("expected operation to succeed, got %+v", finished)
+	}
+	for _, step := range finished.Steps {
+		if step.Phase != PhaseSucceeded || step.StartedAt == nil || step.CompletedAt == nil {
+			t.Errorf("unexpected step %+v", step)
+		}
+	}
+	if finished.Steps[1].Message != "2 of 2 done" {
+		t.Errorf("expected the last progress of step to be kept, got %q", finished.Steps[1].Message)
+	}
+	if operation := persisted(t, m, started.ID); operation.Phase != PhaseSucceeded || operation.User != "alice" {
+		t.Errorf("unexpected persisted operation %+v", operation)
+	}
+}
+
+func TestStepFailure(t *testing.T) {
+	m := NewManager(nil, "")
+	started := m.Start(TypeClusterUnjoin, "member1", "", []string{"first", "second"}, func(ctx context.Context, r *Recorder) error {
+		if err := r.Step(ctx, "first", func(context.Context) error { return errors.New("boom") }); err != nil {
+			return err
+		}
+		return r.Step(ctx, "second", func(context.Context) error { return nil })
+	})
+
+	finished := waitFinished(t, m, started.ID)
+	if finished.Phase != PhaseFailed || finished.Message != "first: boom" {
+		t.Fatalf("unexpected operation %+v", finished)
+	}
+	if finished.Steps[0].Phase != PhaseFailed || finished.Steps[1].Phase != PhasePending {
+		t.Errorf("unexpected steps %+v", finished.Steps)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	m := NewManager(nil, "")
+	running := make(chan struct{})
+	started := m.Start(TypeClusterJoin, "member1", "", []string{"wait"}, func(ctx context.Context, r *Recorder) error {
+		return r.Step(ctx, "wait", func(ctx context.Context) error {
+			close(running)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	})
+	<-running
+
+	cancelled, err := m.Cancel(started.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Phase != PhaseCancelled || cancelled.Steps[0].Phase != PhaseFailed {
+		t.Fatalf("unexpected cancelled operation %+v", cancelled)
+	}
+	if finished := waitFinished(t, m, started.ID); finished.Phase != PhaseCancelled {
+		t.Errorf("expected operation to stay cancelled, got %s", finished.Phase)
+	}
+	if _, err = m.Cancel(started.ID); !errors.Is(err, ErrFinished) {
+		t.Errorf("expected ErrFinished, got %v", err)
+	}
+	if _, err = m.Cancel("unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLoadMarksInterruptedOperationsFailed(t *testing.T) {
+	now := time.Now()
+	running := &Operation{
+		ID:        "running",
+		Type:      TypeClusterJoin,
+		Phase:     PhaseRunning,
+		CreatedAt: now,
+		Steps: []Step{
+			{Name: "first", Phase: PhaseSucceeded, StartedAt: &now, CompletedAt: &now},
+			{Name: "second", Phase: PhaseRunning, StartedAt: &now},
+		},
+	}
+	data, err := json.Marshal(running)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapPrefix + running.ID,
+			Namespace: testNamespace,
+			Labels:    map[string]string{operationLabel: "true"},
+		},
+		Data: map[string]string{operationKey: string(data)},
+	})
+
+	m := NewManager(kubeClient, testNamespace)
+	if err = m.Load(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	operation, err := m.Get(running.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.Phase != PhaseFailed || operation.Message != interruptedMessage || operation.Steps[1].Phase != PhaseFailed {
+		t.Errorf("unexpected loaded operation %+v", operation)
+	}
+	if operation = persisted(t, m, running.ID); operation.Phase != PhaseFailed {
+		t.Errorf("expected the failure to be persisted, got %s", operation.Phase)
+	}
+}
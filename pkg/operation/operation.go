@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"time"
+)
+
+// Type is the kind of long-running action an operation performs.
+type Type string
+
+const (
+	// TypeClusterJoin joins a member cluster in push or pull mode.
+	TypeClusterJoin Type = "ClusterJoin"
+	// TypeClusterUnjoin unjoins a member cluster and cleans up what joining it created.
+	TypeClusterUnjoin Type = "ClusterUnjoin"
+	// TypeClusterEvacuate taints a member cluster and waits until its workloads are evicted.
+	TypeClusterEvacuate Type = "ClusterEvacuate"
+)
+
+// Phase is the state of an operation or a step.
+type Phase string
+
+const (
+	// PhasePending means the operation or step has not started yet.
+	PhasePending Phase = "Pending"
+	// PhaseRunning means the operation or step is in progress.
+	PhaseRunning Phase = "Running"
+	// PhaseSucceeded means the operation or step completed successfully.
+	PhaseSucceeded Phase = "Succeeded"
+	// PhaseFailed means the operation or step failed, the message tells why.
+	PhaseFailed Phase = "Failed"
+	// PhaseCancelled means the operation was cancelled by user before it completed.
+	PhaseCancelled Phase = "Cancelled"
+)
+
+// IsFinished reports whether the phase is a terminal one.
+func (p Phase) IsFinished() bool {
+	return p == PhaseSucceeded || p == PhaseFailed || p == PhaseCancelled
+}
+
+// Operation is the progress of a long-running action, it's returned to clients instead of blocking the request
+// until the action completes.
+type Operation struct {
+	ID   string `json:"id"`
+	Type Type   `json:"type"`
+	// Target is the name of object the operation acts on, e.g. the name of cluster being joined.
+	Target string `json:"target"`
+	// User is who started the operation as verified by karmada apiserver, only the same user may see and cancel it.
+	User    string `json:"user,omitempty"`
+	Phase   Phase  `json:"phase"`
+	Message string `json:"message,omitempty"`
+	Steps   []Step `json:"steps"`
+	// CreatedAt is when the operation was started.
+	CreatedAt time.Time `json:"createdAt"`
+	// CompletedAt is when the operation finished, it's zero while the operation is in progress.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// Step is the progress of one step of an operation.
+type Step struct {
+	Name        string     `json:"name"`
+	Phase       Phase      `json:"phase"`
+	Message     string     `json:"message,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// DeepCopy returns a copy of the operation which does not share steps with it.
+func (o *Operation) DeepCopy() *Operation {
+	out := *o
+	out.Steps = make([]Step, len(o.Steps))
+	copy(out.Steps, o.Steps)
+	return &out
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstraptoken
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	tokenutil "github.com/karmada-io/karmada/pkg/karmadactl/util/bootstraptoken"
+	"github.com/karmada-io/karmada/pkg/util/lifted/pubkeypin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcertutil "k8s.io/client-go/util/cert"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+const (
+	// secretNamePrefix is the prefix of the names of secrets which keep bootstrap tokens.
+	secretNamePrefix = "bootstrap-token-"
+	// MaxTTL is the longest a bootstrap token may live, tokens registering clusters should be short-lived.
+	MaxTTL = 7 * 24 * time.Hour
+)
+
+// BootstrapToken is a bootstrap token in karmada control plane, the secret part is never returned once created.
+type BootstrapToken struct {
+	ID                string       `json:"id"`
+	Description       string       `json:"description,omitempty"`
+	Expires           *metav1.Time `json:"expires,omitempty"`
+	Usages            []string     `json:"usages"`
+	Groups            []string     `json:"groups"`
+	CreationTimestamp metav1.Time  `json:"creationTimestamp"`
+}
+
+// CreatedBootstrapToken is a newly created bootstrap token along with the command registering a cluster with it.
+type CreatedBootstrapToken struct {
+	BootstrapToken `json:",inline"`
+	// Token is the full token in the form of id.secret.
+	Token string `json:"token"`
+	// RegisterCommand is the `karmadactl register` command to run against member cluster.
+	RegisterCommand string `json:"registerCommand"`
+}
+
+// BootstrapTokenList contains a list of bootstrap tokens.
+type BootstrapTokenList struct {
+	ListMeta types.ListMeta   `json:"listMeta"`
+	Tokens   []BootstrapToken `json:"tokens"`
+}
+
+// CreateToken creates a bootstrap token the same as `karmadactl token create` does, restConfig is the config
+// of karmada apiserver which the ca hash of register command is computed from, and server overrides its address
+// if karmada apiserver is exposed to member clusters under another one.
+func CreateToken(kubeClient kubeclient.Interface, restConfig *rest.Config, server string, ttl time.Duration, description string) (*CreatedBootstrapToken, error) {
+	if ttl == 0 {
+		ttl = tokenutil.DefaultTokenDuration
+	}
+	if ttl < 0 || ttl > MaxTTL {
+		return nil, errors.NewBadRequest(fmt.Sprintf("ttl of bootstrap token must be between 0 and %s", MaxTTL))
+	}
+	if len(server) == 0 {
+		server = restConfig.Host
+	}
+	bootstrapToken, err := tokenutil.GenerateRandomBootstrapToken(&metav1.Duration{Duration: ttl}, description,
+		tokenutil.DefaultGroups, tokenutil.DefaultUsages)
+	if err != nil {
+		return nil, err
+	}
+	tokenStr := bootstrapToken.Token.ID + "." + bootstrapToken.Token.Secret
+	command, err := registerCommand(restConfig, server, tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = tokenutil.CreateNewToken(kubeClient, bootstrapToken); err != nil {
+		return nil, err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(metav1.NamespaceSystem).Get(context.TODO(), secretNamePrefix+bootstrapToken.Token.ID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	token, err := toBootstrapToken(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedBootstrapToken{BootstrapToken: *token, Token: tokenStr, RegisterCommand: command}, nil
+}
+
+// registerCommand generates the `karmadactl register` command, the ca certificates of karmada apiserver are pinned
+// by their public key hashes so that the registering agent can trust the apiserver before it has the ca.
+func registerCommand(restConfig *rest.Config, server, token string) (string, error) {
+	var caCerts []*x509.Certificate
+	var err error
+	switch {
+	case len(restConfig.CAData) > 0:
+		caCerts, err = clientcertutil.ParseCertsPEM(restConfig.CAData)
+	case len(restConfig.CAFile) > 0:
+		caCerts, err = clientcertutil.CertsFromFile(restConfig.CAFile)
+	default:
+		err = fmt.Errorf("no ca certificates of karmada apiserver found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load ca certificates of karmada apiserver: %w", err)
+	}
+	publicKeyPins := make([]string, 0, len(caCerts))
+	for _, caCert := range caCerts {
+		publicKeyPins = append(publicKeyPins, pubkeypin.Hash(caCert))
+	}
+	return fmt.Sprintf("karmadactl register %s --token %s --discovery-token-ca-cert-hash %s",
+		strings.TrimPrefix(server, "https://"), token, strings.Join(publicKeyPins, ",")), nil
+}
+
+// GetTokenList returns the bootstrap tokens in karmada control plane.
+func GetTokenList(kubeClient kubeclient.Interface, dsQuery *dataselect.DataSelectQuery) (*BootstrapTokenList, error) {
+	secrets, err := kubeClient.CoreV1().Secrets(metav1.NamespaceSystem).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeBootstrapToken)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(secrets.Items), dsQuery)
+	secretList := fromCells(cells)
+	result := &BootstrapTokenList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Tokens:   make([]BootstrapToken, 0, len(secretList)),
+	}
+	for i := range secretList {
+		token, err := toBootstrapToken(&secretList[i])
+		if err != nil {
+			// the secret is not a valid bootstrap token, kube-apiserver ignores it as well
+			continue
+		}
+		result.Tokens = append(result.Tokens, *token)
+	}
+	return result, nil
+}
+
+// DeleteToken revokes the bootstrap token of id.
+func DeleteToken(kubeClient kubeclient.Interface, id string) error {
+	if strings.Contains(id, ".") {
+		return errors.NewBadRequest("token id must be given instead of the full token")
+	}
+	return kubeClient.CoreV1().Secrets(metav1.NamespaceSystem).Delete(context.TODO(), secretNamePrefix+id, metav1.DeleteOptions{})
+}
+
+func toBootstrapToken(secret *corev1.Secret) (*BootstrapToken, error) {
+	token, err := tokenutil.GetBootstrapTokenFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	result := &BootstrapToken{
+		ID:                token.Token.ID,
+		Description:       token.Description,
+		Expires:           token.Expires,
+		Usages:            token.Usages,
+		Groups:            token.Groups,
+		CreationTimestamp: secret.CreationTimestamp,
+	}
+	if result.Usages == nil {
+		result.Usages = make([]string, 0)
+	}
+	if result.Groups == nil {
+		result.Groups = make([]string, 0)
+	}
+	return result, nil
+}
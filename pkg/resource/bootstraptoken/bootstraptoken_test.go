@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstraptoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clientcertutil "k8s.io/client-go/util/cert"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+func TestCreateToken(t *testing.T) {
+	caData, _, err := clientcertutil.GenerateSelfSignedCertKey("karmada-apiserver", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restConfig := &rest.Config{Host: "https://karmada-apiserver.karmada-system.svc:5443"}
+	restConfig.CAData = caData
+	kubeClient := fake.NewSimpleClientset()
+
+	created, err := CreateToken(kubeClient, restConfig, "https://10.0.0.1:32443", time.Hour, "register member1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Token != created.ID+"."+strings.SplitN(created.Token, ".", 2)[1] {
+		t.Errorf("token %q does not start with its id %q", created.Token, created.ID)
+	}
+	if created.Description != "register member1" || created.Expires == nil {
+		t.Errorf("unexpected token %+v", created.BootstrapToken)
+	}
+	if remaining := time.Until(created.Expires.Time); remaining <= 0 || remaining > time.Hour {
+		t.Errorf("unexpected expiration %s", created.Expires)
+	}
+	wantPrefix := "karmadactl register 10.0.0.1:32443 --token " + created.Token + " --discovery-token-ca-cert-hash sha256:"
+	if !strings.HasPrefix(created.RegisterCommand, wantPrefix) {
+		t.Errorf("expected register command to start with %q, got %q", wantPrefix, created.RegisterCommand)
+	}
+
+	list, err := GetTokenList(kubeClient, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Tokens) != 1 || list.Tokens[0].ID != created.ID {
+		t.Fatalf("unexpected tokens %+v", list.Tokens)
+	}
+
+	if err = DeleteToken(kubeClient, created.Token); err == nil {
+		t.Error("expected deleting with the full token to be rejected")
+	}
+	if err = DeleteToken(kubeClient, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if list, err = GetTokenList(kubeClient, dataselect.NoDataSelect); err != nil || len(list.Tokens) != 0 {
+		t.Errorf("expected no tokens left, got %+v, %v", list, err)
+	}
+}
+
+func TestCreateTokenRejectsInvalidTTL(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://karmada-apiserver:5443"}
+	for _, ttl := range []time.Duration{-time.Hour, MaxTTL + time.Hour} {
+		if _, err := CreateToken(fake.NewSimpleClientset(), restConfig, "", ttl, ""); err == nil {
+			t.Errorf("expected ttl %s to be rejected", ttl)
+		}
+	}
+}
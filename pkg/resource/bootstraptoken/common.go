@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstraptoken
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// SecretCell is a cell representation of the Secret keeping a bootstrap token.
+type SecretCell corev1.Secret
+
+// GetProperty returns value of a given property.
+func (c SecretCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(c.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(c.ObjectMeta.CreationTimestamp.Time)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []corev1.Secret) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = SecretCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []corev1.Secret {
+	std := make([]corev1.Secret, len(cells))
+	for i := range std {
+		std[i] = corev1.Secret(cells[i].(SecretCell))
+	}
+	return std
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// APIEnablement is a resource served by a member cluster.
+type APIEnablement struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+}
+
+// APIEnablementList contains the resources served by a member cluster.
+type APIEnablementList struct {
+	ListMeta       types.ListMeta  `json:"listMeta"`
+	APIEnablements []APIEnablement `json:"apiEnablements"`
+	// Complete is false if some api groups of the member cluster could not be discovered.
+	Complete bool `json:"complete"`
+}
+
+// GetClusterAPIEnablements returns the resources served by cluster as reported in its status, they can be
+// filtered by name (the plural resource name), kind, group and version.
+func GetClusterAPIEnablements(client karmadaclientset.Interface, clusterName string, dsQuery *dataselect.DataSelectQuery) (*APIEnablementList, error) {
+	cluster, err := client.ClusterV1alpha1().Clusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toAPIEnablementCells(toAPIEnablements(cluster.Status.APIEnablements)), dsQuery)
+	result := &APIEnablementList{
+		ListMeta:       types.ListMeta{TotalItems: filteredTotal},
+		APIEnablements: make([]APIEnablement, 0, len(cells)),
+		Complete:       meta.IsStatusConditionTrue(cluster.Status.Conditions, v1alpha1.ClusterConditionCompleteAPIEnablements),
+	}
+	for _, cell := range cells {
+		result.APIEnablements = append(result.APIEnablements, APIEnablement(cell.(APIEnablementCell)))
+	}
+	return result, nil
+}
+
+// toAPIEnablements flattens the api enablements of cluster status, sorted by group, version and resource.
+func toAPIEnablements(enablements []v1alpha1.APIEnablement) []APIEnablement {
+	result := make([]APIEnablement, 0)
+	for _, enablement := range enablements {
+		gv, err := schema.ParseGroupVersion(enablement.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range enablement.Resources {
+			result = append(result, APIEnablement{
+				Group:    gv.Group,
+				Version:  gv.Version,
+				Kind:     resource.Kind,
+				Resource: resource.Name,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		if result[i].Version != result[j].Version {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].Resource < result[j].Resource
+	})
+	return result
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+)
+
+// Slots bounds how many clusters of a batch are joined at the same time, a join holds a slot until it's done.
+type Slots chan struct{}
+
+// NewSlots returns the slots of n clusters joined at the same time.
+func NewSlots(n int) Slots {
+	return make(Slots, n)
+}
+
+// Acquire waits for a free slot and returns the function releasing it, ctx.Err() is returned if ctx is done first.
+func (s Slots) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// DuplicateNames returns the cluster names which occur more than once in names, none of these clusters is joined
+// since it's ambiguous which of the requests is meant.
+func DuplicateNames(names []string) map[string]bool {
+	seen := make(map[string]bool, len(names))
+	duplicates := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			duplicates[name] = true
+		}
+		seen[name] = true
+	}
+	return duplicates
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string]bool
+	}{
+		{name: "no clusters", names: nil, want: map[string]bool{}},
+		{name: "distinct", names: []string{"member1", "member2"}, want: map[string]bool{}},
+		{name: "twice", names: []string{"member1", "member2", "member1"}, want: map[string]bool{"member1": true}},
+		{name: "three times", names: []string{"member1", "member1", "member1", "member2", "member2"},
+			want: map[string]bool{"member1": true, "member2": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DuplicateNames(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DuplicateNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlots(t *testing.T) {
+	tests := []struct {
+		name        string
+		slots       int
+		workers     int
+		joins       int
+		wantMaxHeld int32
+	}{
+		{name: "fewer workers than slots", slots: 4, workers: 2, joins: 10, wantMaxHeld: 2},
+		{name: "more workers than slots", slots: 2, workers: 8, joins: 20, wantMaxHeld: 2},
+		{name: "single slot", slots: 1, workers: 4, joins: 8, wantMaxHeld: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := NewSlots(tt.slots)
+			var held, maxHeld int32
+			var lock sync.Mutex
+			workqueue.ParallelizeUntil(context.TODO(), tt.workers, tt.joins, func(int) {
+				release, err := slots.Acquire(context.TODO())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer release()
+				current := atomic.AddInt32(&held, 1)
+				lock.Lock()
+				if current > maxHeld {
+					maxHeld = current
+				}
+				lock.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&held, -1)
+			})
+			if maxHeld == 0 || maxHeld > tt.wantMaxHeld {
+				t.Errorf("%d joins ran at the same time, want at most %d", maxHeld, tt.wantMaxHeld)
+			}
+		})
+	}
+}
+
+func TestSlotsAcquireCancelled(t *testing.T) {
+	slots := NewSlots(1)
+	release, err := slots.Acquire(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err = slots.Acquire(ctx); err != context.Canceled {
+		t.Errorf("expected acquiring a busy slot to be cancelled, got %v", err)
+	}
+}
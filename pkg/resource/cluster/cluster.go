@@ -18,7 +18,6 @@ package cluster
 
 import (
 	"context"
-	"log"
 
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
@@ -78,17 +77,12 @@ func toClusterList(_ karmadaclientset.Interface, clusters []v1alpha1.Cluster, no
 }
 
 func toCluster(cluster *v1alpha1.Cluster) Cluster {
-	allocatedResources, err := getclusterAllocatedResources(cluster)
-	if err != nil {
-		log.Printf("Couldn't get allocated resources of %s cluster: %s\n", cluster.Name, err)
-	}
-
 	return Cluster{
 		ObjectMeta:         types.NewObjectMeta(cluster.ObjectMeta),
 		TypeMeta:           types.NewTypeMeta(types.ResourceKindCluster),
 		Ready:              getClusterConditionStatus(cluster, metav1.ConditionTrue),
 		KubernetesVersion:  cluster.Status.KubernetesVersion,
-		AllocatedResources: allocatedResources,
+		AllocatedResources: getclusterAllocatedResources(cluster),
 		SyncMode:           cluster.Spec.SyncMode,
 		NodeSummary:        cluster.Status.NodeSummary,
 	}
@@ -55,3 +55,31 @@ func fromCells(cells []dataselect.DataCell) []v1alpha1.Cluster {
 	}
 	return std
 }
+
+// APIEnablementCell is a cell representation of a resource served by a member cluster.
+type APIEnablementCell APIEnablement
+
+// GetProperty returns value of a given property.
+func (c APIEnablementCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(c.Resource)
+	case dataselect.KindProperty:
+		return dataselect.StdComparableString(c.Kind)
+	case dataselect.GroupProperty:
+		return dataselect.StdComparableString(c.Group)
+	case dataselect.VersionProperty:
+		return dataselect.StdComparableString(c.Version)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toAPIEnablementCells(std []APIEnablement) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = APIEnablementCell(std[i])
+	}
+	return cells
+}
@@ -18,81 +18,46 @@ package cluster
 
 import (
 	"context"
-	"fmt"
 	"log"
 
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// ClusterAllocatedResources is the resource summary of a cluster.
-type ClusterAllocatedResources struct {
-	// CPUCapacity is specified node CPU capacity in milicores.
-	CPUCapacity int64   `json:"cpuCapacity"`
-	CPUFraction float64 `json:"cpuFraction"`
-
-	// MemoryCapacity is specified node memory capacity in bytes.
-	MemoryCapacity int64   `json:"memoryCapacity"`
-	MemoryFraction float64 `json:"memoryFraction"`
-
-	// AllocatedPods in number of currently allocated pods on the node.
-	AllocatedPods int64 `json:"allocatedPods"`
-
-	// PodCapacity is maximum number of pods, that can be allocated on the node.
-	PodCapacity int64 `json:"podCapacity"`
-
-	// PodFraction is a fraction of pods, that can be allocated on given node.
-	PodFraction float64 `json:"podFraction"`
-}
-
-func getclusterAllocatedResources(cluster *v1alpha1.Cluster) (ClusterAllocatedResources, error) {
-	if cluster.Status.ResourceSummary == nil {
-		return ClusterAllocatedResources{}, nil
-	}
-	allocatableCPU := cluster.Status.ResourceSummary.Allocatable.Cpu()
-	allocatedCPU := cluster.Status.ResourceSummary.Allocated.Cpu()
-	var cpuCapacity int64 = allocatableCPU.Value()
-	var cpuFraction float64
-	if cpuCapacity > 0 {
-		cpuFraction = float64(allocatedCPU.ScaledValue(resource.Micro)) / float64(allocatableCPU.ScaledValue(resource.Micro)) * 100
-	}
-
-	allocatableMemory := cluster.Status.ResourceSummary.Allocatable.Memory()
-	allocatedMemory := cluster.Status.ResourceSummary.Allocated.Memory()
-	fmt.Println(allocatableMemory.String())
-	fmt.Println(allocatedMemory.Value())
-	var memoryCapacity int64 = allocatableMemory.Value()
-	var memoryFraction float64
-	if memoryCapacity > 0 {
-		memoryFraction = float64(allocatedMemory.ScaledValue(resource.Micro)) / float64(allocatableMemory.ScaledValue(resource.Micro)) * 100
-	}
-
-	allocatablePod := cluster.Status.ResourceSummary.Allocatable.Pods()
-	allocatedPod := cluster.Status.ResourceSummary.Allocated.Pods()
-
-	var podCapacity int64 = allocatablePod.Value()
-	var podFraction float64
-	if podCapacity > 0 {
-		podFraction = float64(allocatedPod.Value()) / float64(podCapacity) * 100
-	}
-	return ClusterAllocatedResources{
-		CPUCapacity:    allocatableCPU.Value(),
-		CPUFraction:    cpuFraction,
-		MemoryCapacity: allocatedMemory.Value(),
-		MemoryFraction: memoryFraction,
-		AllocatedPods:  allocatedPod.Value(),
-		PodCapacity:    podCapacity,
-		PodFraction:    podFraction,
-	}, nil
-}
-
 // ClusterDetail is the detailed information of a cluster.
 type ClusterDetail struct {
 	Cluster `json:",inline"`
 	Taints  []corev1.Taint `json:"taints,omitempty"`
+
+	ID          string   `json:"id,omitempty"`
+	APIEndpoint string   `json:"apiEndpoint,omitempty"`
+	Provider    string   `json:"provider,omitempty"`
+	Region      string   `json:"region,omitempty"`
+	Zones       []string `json:"zones,omitempty"`
+
+	ProxyURL                    string            `json:"proxyURL,omitempty"`
+	ProxyHeader                 map[string]string `json:"proxyHeader,omitempty"`
+	InsecureSkipTLSVerification bool              `json:"insecureSkipTLSVerification,omitempty"`
+
+	SecretRef             *v1alpha1.LocalSecretReference `json:"secretRef,omitempty"`
+	ImpersonatorSecretRef *v1alpha1.LocalSecretReference `json:"impersonatorSecretRef,omitempty"`
+
+	ResourceModels []ResourceModel `json:"resourceModels,omitempty"`
+	// APIEnablementCount is the number of resources the member cluster serves, they are listed by
+	// GetClusterAPIEnablements.
+	APIEnablementCount int `json:"apiEnablementCount"`
+	// APIEnablementsComplete is false if some api groups of the member cluster could not be discovered.
+	APIEnablementsComplete bool `json:"apiEnablementsComplete"`
+}
+
+// ResourceModel is a grade of the resource models of a cluster with the number of nodes in it.
+type ResourceModel struct {
+	v1alpha1.ResourceModel `json:",inline"`
+	// NodeCount is the number of nodes whose allocatable resources fall into the grade.
+	NodeCount int `json:"nodeCount"`
 }
 
 // GetClusterDetail gets details of cluster.
@@ -102,8 +67,39 @@ func GetClusterDetail(client karmadaclientset.Interface, clusterName string) (*C
 	if err != nil {
 		return nil, err
 	}
+	zones := cluster.Spec.Zones
+	if len(zones) == 0 && len(cluster.Spec.Zone) > 0 {
+		zones = []string{cluster.Spec.Zone}
+	}
 	return &ClusterDetail{
-		Cluster: toCluster(cluster),
-		Taints:  cluster.Spec.Taints,
+		Cluster:                     toCluster(cluster),
+		Taints:                      cluster.Spec.Taints,
+		ID:                          cluster.Spec.ID,
+		APIEndpoint:                 cluster.Spec.APIEndpoint,
+		Provider:                    cluster.Spec.Provider,
+		Region:                      cluster.Spec.Region,
+		Zones:                       zones,
+		ProxyURL:                    cluster.Spec.ProxyURL,
+		ProxyHeader:                 cluster.Spec.ProxyHeader,
+		InsecureSkipTLSVerification: cluster.Spec.InsecureSkipTLSVerification,
+		SecretRef:                   cluster.Spec.SecretRef,
+		ImpersonatorSecretRef:       cluster.Spec.ImpersonatorSecretRef,
+		ResourceModels:              toResourceModels(cluster),
+		APIEnablementCount:          len(toAPIEnablements(cluster.Status.APIEnablements)),
+		APIEnablementsComplete:      meta.IsStatusConditionTrue(cluster.Status.Conditions, v1alpha1.ClusterConditionCompleteAPIEnablements),
 	}, nil
 }
+
+func toResourceModels(cluster *v1alpha1.Cluster) []ResourceModel {
+	nodeCounts := make(map[uint]int)
+	if cluster.Status.ResourceSummary != nil {
+		for _, modeling := range cluster.Status.ResourceSummary.AllocatableModelings {
+			nodeCounts[modeling.Grade] = modeling.Count
+		}
+	}
+	models := make([]ResourceModel, 0, len(cluster.Spec.ResourceModels))
+	for _, model := range cluster.Spec.ResourceModels {
+		models = append(models, ResourceModel{ResourceModel: model, NodeCount: nodeCounts[model.Grade]})
+	}
+	return models
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+func newTestCluster() *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Spec: v1alpha1.ClusterSpec{
+			SyncMode: v1alpha1.Push,
+			Provider: "aws",
+			Region:   "us-east-1",
+			Zone:     "us-east-1a",
+			ResourceModels: []v1alpha1.ResourceModel{
+				{Grade: 0}, {Grade: 1},
+			},
+		},
+		Status: v1alpha1.ClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: v1alpha1.ClusterConditionCompleteAPIEnablements, Status: metav1.ConditionTrue},
+			},
+			APIEnablements: []v1alpha1.APIEnablement{
+				{GroupVersion: "v1", Resources: []v1alpha1.APIResource{{Name: "pods", Kind: "Pod"}, {Name: "configmaps", Kind: "ConfigMap"}}},
+				{GroupVersion: "apps/v1", Resources: []v1alpha1.APIResource{{Name: "deployments", Kind: "Deployment"}}},
+			},
+			ResourceSummary: &v1alpha1.ResourceSummary{
+				AllocatableModelings: []v1alpha1.AllocatableModeling{{Grade: 0, Count: 2}, {Grade: 1, Count: 3}},
+			},
+		},
+	}
+}
+
+func TestGetClusterDetail(t *testing.T) {
+	detail, err := GetClusterDetail(karmadafake.NewSimpleClientset(newTestCluster()), "member1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Provider != "aws" || detail.Region != "us-east-1" || len(detail.Zones) != 1 || detail.Zones[0] != "us-east-1a" {
+		t.Errorf("unexpected location %s/%s/%v", detail.Provider, detail.Region, detail.Zones)
+	}
+	if len(detail.ResourceModels) != 2 || detail.ResourceModels[1].NodeCount != 3 {
+		t.Errorf("unexpected resource models %+v", detail.ResourceModels)
+	}
+	if detail.APIEnablementCount != 3 || !detail.APIEnablementsComplete {
+		t.Errorf("unexpected api enablements %d complete %v", detail.APIEnablementCount, detail.APIEnablementsComplete)
+	}
+}
+
+func TestGetClusterAPIEnablements(t *testing.T) {
+	client := karmadafake.NewSimpleClientset(newTestCluster())
+	result, err := GetClusterAPIEnablements(client, "member1", dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ListMeta.TotalItems != 3 || result.APIEnablements[0].Group != "" || result.APIEnablements[0].Resource != "configmaps" ||
+		result.APIEnablements[2].Group != "apps" {
+		t.Errorf("unexpected api enablements %+v", result.APIEnablements)
+	}
+
+	query := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort,
+		dataselect.NewFilterQuery([]string{dataselect.GroupProperty, "apps"}))
+	result, err = GetClusterAPIEnablements(client, "member1", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ListMeta.TotalItems != 1 || result.APIEnablements[0].Kind != "Deployment" {
+		t.Errorf("expected api enablements filtered by group, got %+v", result.APIEnablements)
+	}
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// EvacuationTaintKey is the key of the NoExecute taint which makes karmada evict workloads from a cluster.
+const EvacuationTaintKey = "dashboard.karmada.io/evacuation"
+
+// EvacuationAction is what happens to a binding once its cluster is tainted for evacuation.
+type EvacuationAction string
+
+const (
+	// EvacuationActionEvict means the binding does not tolerate the taint and is evicted right away.
+	EvacuationActionEvict EvacuationAction = "Evict"
+	// EvacuationActionEvictLater means the binding tolerates the taint for a while before it's evicted.
+	EvacuationActionEvictLater EvacuationAction = "EvictLater"
+	// EvacuationActionRemain means the binding tolerates the taint forever and stays on the cluster.
+	EvacuationActionRemain EvacuationAction = "Remain"
+)
+
+// BindingImpact is what evacuating a cluster does to a ResourceBinding or ClusterResourceBinding scheduled to it.
+type BindingImpact struct {
+	Kind      string                       `json:"kind"`
+	Namespace string                       `json:"namespace,omitempty"`
+	Name      string                       `json:"name"`
+	Resource  workv1alpha2.ObjectReference `json:"resource"`
+	// Replicas is the number of replicas scheduled to the evacuated cluster.
+	Replicas int32            `json:"replicas"`
+	Action   EvacuationAction `json:"action"`
+	// TolerationSeconds is how long the binding stays on the cluster if the action is EvictLater.
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+	// CandidateClusters are the other clusters the placement of binding allows and whose taints it tolerates,
+	// an evicted binding without candidates is not rescheduled anywhere.
+	CandidateClusters []string `json:"candidateClusters"`
+}
+
+// EvacuationPreview is the impact of evacuating a cluster.
+type EvacuationPreview struct {
+	Cluster  string          `json:"cluster"`
+	Bindings []BindingImpact `json:"bindings"`
+	// Unschedulable is the number of bindings which are evicted without any other cluster to move to.
+	Unschedulable int `json:"unschedulable"`
+}
+
+// EvacuationProgress is how much of a cluster is left to evacuate.
+type EvacuationProgress struct {
+	// Pending is the number of bindings which are still scheduled to the cluster and will be evicted.
+	Pending int `json:"pending"`
+	// Remaining is the number of bindings which tolerate the evacuation taint forever.
+	Remaining int `json:"remaining"`
+	// GracefulEvictionTasks is the number of graceful eviction tasks from the cluster which are not done yet.
+	GracefulEvictionTasks int `json:"gracefulEvictionTasks"`
+}
+
+// Done reports whether nothing will be evicted from the cluster anymore.
+func (p *EvacuationProgress) Done() bool {
+	return p.Pending == 0 && p.GracefulEvictionTasks == 0
+}
+
+func (p *EvacuationProgress) String() string {
+	return fmt.Sprintf("%d bindings to evict, %d graceful eviction tasks in progress, %d bindings tolerating the taint",
+		p.Pending, p.GracefulEvictionTasks, p.Remaining)
+}
+
+// NewEvacuationTaint returns the NoExecute taint which evacuates a cluster.
+func NewEvacuationTaint() corev1.Taint {
+	now := metav1.Now()
+	return corev1.Taint{Key: EvacuationTaintKey, Effect: corev1.TaintEffectNoExecute, TimeAdded: &now}
+}
+
+// PreviewEvacuation evaluates every binding scheduled to cluster against the evacuation taint the way the taint
+// manager of karmada does. Nothing is evicted unless the taint manager is enabled in karmada-controller-manager.
+func PreviewEvacuation(client karmadaclientset.Interface, clusterName string) (*EvacuationPreview, error) {
+	cluster, err := client.ClusterV1alpha1().Clusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterList, err := client.ClusterV1alpha1().Clusters().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := listBindingsOnCluster(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	taints := evacuationTaints(cluster)
+
+	preview := &EvacuationPreview{Cluster: clusterName, Bindings: make([]BindingImpact, 0, len(bindings))}
+	for _, binding := range bindings {
+		if !binding.scheduled {
+			continue
+		}
+		impact := binding.impact
+		placement := binding.placement()
+		impact.Action, impact.TolerationSeconds = evacuationAction(taints, placement)
+		impact.CandidateClusters = candidateClusters(clusterList.Items, clusterName, placement)
+		if impact.Action != EvacuationActionRemain && len(impact.CandidateClusters) == 0 {
+			preview.Unschedulable++
+		}
+		preview.Bindings = append(preview.Bindings, impact)
+	}
+	return preview, nil
+}
+
+// GetEvacuationProgress counts the bindings and graceful eviction tasks which are still on cluster.
+func GetEvacuationProgress(client karmadaclientset.Interface, clusterName string) (*EvacuationProgress, error) {
+	cluster, err := client.ClusterV1alpha1().Clusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := listBindingsOnCluster(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	taints := evacuationTaints(cluster)
+	progress := &EvacuationProgress{}
+	for _, binding := range bindings {
+		if binding.scheduled {
+			if action, _ := evacuationAction(taints, binding.placement()); action == EvacuationActionRemain {
+				progress.Remaining++
+			} else {
+				progress.Pending++
+			}
+		}
+		progress.GracefulEvictionTasks += binding.evictionTasks
+	}
+	return progress, nil
+}
+
+// AddEvacuationTaint taints cluster for evacuation, it does nothing if the cluster is tainted already.
+func AddEvacuationTaint(ctx context.Context, client karmadaclientset.Interface, clusterName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := client.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if findEvacuationTaint(cluster) >= 0 {
+			return nil
+		}
+		cluster.Spec.Taints = append(cluster.Spec.Taints, NewEvacuationTaint())
+		_, err = client.ClusterV1alpha1().Clusters().Update(ctx, cluster, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// RemoveEvacuationTaint removes the evacuation taint of cluster, evicted workloads are not moved back.
+func RemoveEvacuationTaint(ctx context.Context, client karmadaclientset.Interface, clusterName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := client.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		i := findEvacuationTaint(cluster)
+		if i < 0 {
+			return nil
+		}
+		cluster.Spec.Taints = append(cluster.Spec.Taints[:i], cluster.Spec.Taints[i+1:]...)
+		_, err = client.ClusterV1alpha1().Clusters().Update(ctx, cluster, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func findEvacuationTaint(cluster *v1alpha1.Cluster) int {
+	for i, taint := range cluster.Spec.Taints {
+		if taint.Key == EvacuationTaintKey && taint.Effect == corev1.TaintEffectNoExecute {
+			return i
+		}
+	}
+	return -1
+}
+
+// evacuationTaints returns the NoExecute taints of cluster including the evacuation taint.
+func evacuationTaints(cluster *v1alpha1.Cluster) []corev1.Taint {
+	var taints []corev1.Taint
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			taints = append(taints, taint)
+		}
+	}
+	if findEvacuationTaint(cluster) < 0 {
+		taints = append(taints, NewEvacuationTaint())
+	}
+	for i := range taints {
+		if taints[i].TimeAdded == nil {
+			taints[i].TimeAdded = &metav1.Time{Time: time.Now()}
+		}
+	}
+	return taints
+}
+
+// evacuationAction mirrors how the taint manager of karmada decides whether a binding is evicted: it's evicted
+// right away unless every NoExecute taint is tolerated, and once the shortest toleration expired otherwise.
+func evacuationAction(noExecuteTaints []corev1.Taint, placement *policyv1alpha1.Placement) (EvacuationAction, *int64) {
+	var tolerations []corev1.Toleration
+	if placement != nil {
+		tolerations = placement.ClusterTolerations
+	}
+	var evictAt *time.Time
+	for i := range noExecuteTaints {
+		taint := &noExecuteTaints[i]
+		toleration := matchingToleration(taint, tolerations)
+		if toleration == nil {
+			return EvacuationActionEvict, nil
+		}
+		if toleration.TolerationSeconds == nil {
+			continue
+		}
+		at := taint.TimeAdded.Add(time.Duration(*toleration.TolerationSeconds) * time.Second)
+		if evictAt == nil || at.Before(*evictAt) {
+			evictAt = &at
+		}
+	}
+	if evictAt == nil {
+		return EvacuationActionRemain, nil
+	}
+	seconds := int64(time.Until(*evictAt).Seconds())
+	if seconds <= 0 {
+		return EvacuationActionEvict, nil
+	}
+	return EvacuationActionEvictLater, &seconds
+}
+
+func matchingToleration(taint *corev1.Taint, tolerations []corev1.Toleration) *corev1.Toleration {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return &tolerations[i]
+		}
+	}
+	return nil
+}
+
+// candidateClusters returns the clusters other than the evacuated one which the placement selects and whose
+// NoSchedule and NoExecute taints it tolerates.
+func candidateClusters(clusters []v1alpha1.Cluster, evacuated string, placement *policyv1alpha1.Placement) []string {
+	candidates := make([]string, 0)
+	for i := range clusters {
+		cluster := &clusters[i]
+		if cluster.Name == evacuated || !placementSelects(placement, cluster) {
+			continue
+		}
+		var tolerations []corev1.Toleration
+		if placement != nil {
+			tolerations = placement.ClusterTolerations
+		}
+		tolerated := true
+		for j := range cluster.Spec.Taints {
+			taint := &cluster.Spec.Taints[j]
+			if taint.Effect != corev1.TaintEffectPreferNoSchedule && matchingToleration(taint, tolerations) == nil {
+				tolerated = false
+				break
+			}
+		}
+		if tolerated {
+			candidates = append(candidates, cluster.Name)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+func placementSelects(placement *policyv1alpha1.Placement, cluster *v1alpha1.Cluster) bool {
+	switch {
+	case placement == nil:
+		return true
+	case placement.ClusterAffinity != nil:
+		return karmadautil.ClusterMatches(cluster, *placement.ClusterAffinity)
+	case len(placement.ClusterAffinities) > 0:
+		for _, term := range placement.ClusterAffinities {
+			if karmadautil.ClusterMatches(cluster, term.ClusterAffinity) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// bindingOnCluster is a ResourceBinding or ClusterResourceBinding which is scheduled to, or being evicted from,
+// a cluster.
+type bindingOnCluster struct {
+	impact      BindingImpact
+	annotations map[string]string
+	spec        *workv1alpha2.ResourceBindingSpec
+	// scheduled tells whether the cluster is one of the target clusters of binding.
+	scheduled bool
+	// evictionTasks is the number of graceful eviction tasks of binding from the cluster.
+	evictionTasks int
+}
+
+// placement returns the placement the binding was scheduled with, which is what the taint manager evaluates.
+func (b *bindingOnCluster) placement() *policyv1alpha1.Placement {
+	if applied, ok := b.annotations[karmadautil.PolicyPlacementAnnotation]; ok && len(applied) > 0 {
+		placement := &policyv1alpha1.Placement{}
+		if err := json.Unmarshal([]byte(applied), placement); err == nil {
+			return placement
+		}
+	}
+	return b.spec.Placement
+}
+
+func listBindingsOnCluster(client karmadaclientset.Interface, clusterName string) ([]*bindingOnCluster, error) {
+	var result []*bindingOnCluster
+	add := func(kind string, objectMeta metav1.ObjectMeta, spec *workv1alpha2.ResourceBindingSpec) {
+		binding := &bindingOnCluster{
+			impact: BindingImpact{
+				Kind:      kind,
+				Namespace: objectMeta.Namespace,
+				Name:      objectMeta.Name,
+				Resource:  spec.Resource,
+			},
+			annotations: objectMeta.Annotations,
+			spec:        spec,
+		}
+		for _, target := range spec.Clusters {
+			if target.Name == clusterName {
+				binding.scheduled = true
+				binding.impact.Replicas = target.Replicas
+			}
+		}
+		for _, task := range spec.GracefulEvictionTasks {
+			if task.FromCluster == clusterName {
+				binding.evictionTasks++
+			}
+		}
+		if binding.scheduled || binding.evictionTasks > 0 {
+			result = append(result, binding)
+		}
+	}
+
+	resourceBindings, err := client.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range resourceBindings.Items {
+		binding := &resourceBindings.Items[i]
+		add(workv1alpha2.ResourceKindResourceBinding, binding.ObjectMeta, &binding.Spec)
+	}
+	clusterResourceBindings, err := client.WorkV1alpha2().ClusterResourceBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusterResourceBindings.Items {
+		binding := &clusterResourceBindings.Items[i]
+		add(workv1alpha2.ResourceKindClusterResourceBinding, binding.ObjectMeta, &binding.Spec)
+	}
+	return result, nil
+}
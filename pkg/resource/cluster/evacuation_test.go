@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newBinding(name string, clusters []string, placement *policyv1alpha1.Placement) *workv1alpha2.ResourceBinding {
+	binding := &workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Resource:  workv1alpha2.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: name},
+			Placement: placement,
+		},
+	}
+	for _, cluster := range clusters {
+		binding.Spec.Clusters = append(binding.Spec.Clusters, workv1alpha2.TargetCluster{Name: cluster, Replicas: 2})
+	}
+	return binding
+}
+
+func TestEvacuation(t *testing.T) {
+	member2 := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member2"},
+		Spec: v1alpha1.ClusterSpec{
+			Taints: []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+	tolerateForever := &policyv1alpha1.Placement{ClusterTolerations: []corev1.Toleration{
+		{Key: EvacuationTaintKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	}}
+	tolerationSeconds := int64(600)
+	tolerateForAWhile := &policyv1alpha1.Placement{ClusterTolerations: []corev1.Toleration{
+		{Key: EvacuationTaintKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &tolerationSeconds},
+		{Key: "dedicated", Operator: corev1.TolerationOpExists},
+	}}
+	client := karmadafake.NewSimpleClientset(
+		&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "member1"}},
+		member2,
+		newBinding("evicted", []string{"member1"}, nil),
+		newBinding("remain", []string{"member1"}, tolerateForever),
+		newBinding("later", []string{"member1", "member2"}, tolerateForAWhile),
+		newBinding("elsewhere", []string{"member2"}, nil),
+	)
+
+	preview, err := PreviewEvacuation(client, "member1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := make(map[string]BindingImpact)
+	for _, impact := range preview.Bindings {
+		actions[impact.Name] = impact
+	}
+	if len(actions) != 3 || preview.Unschedulable != 1 {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if impact := actions["evicted"]; impact.Action != EvacuationActionEvict || len(impact.CandidateClusters) != 0 || impact.Replicas != 2 {
+		t.Errorf("expected binding to be evicted without candidates, got %+v", impact)
+	}
+	if impact := actions["remain"]; impact.Action != EvacuationActionRemain {
+		t.Errorf("expected binding tolerating the taint to remain, got %+v", impact)
+	}
+	if impact := actions["later"]; impact.Action != EvacuationActionEvictLater || impact.TolerationSeconds == nil ||
+		*impact.TolerationSeconds > 600 || len(impact.CandidateClusters) != 1 {
+		t.Errorf("expected binding to be evicted later and moved to member2, got %+v", impact)
+	}
+
+	if err = AddEvacuationTaint(context.TODO(), client, "member1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = AddEvacuationTaint(context.TODO(), client, "member1"); err != nil {
+		t.Fatal(err)
+	}
+	cluster, err := client.ClusterV1alpha1().Clusters().Get(context.TODO(), "member1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster.Spec.Taints) != 1 || cluster.Spec.Taints[0].TimeAdded == nil {
+		t.Errorf("expected the evacuation taint to be added once, got %+v", cluster.Spec.Taints)
+	}
+
+	progress, err := GetEvacuationProgress(client, "member1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Pending != 2 || progress.Remaining != 1 || progress.Done() {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+
+	if err = RemoveEvacuationTaint(context.TODO(), client, "member1"); err != nil {
+		t.Fatal(err)
+	}
+	cluster, err = client.ClusterV1alpha1().Clusters().Get(context.TODO(), "member1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster.Spec.Taints) != 0 {
+		t.Errorf("expected the evacuation taint to be removed, got %+v", cluster.Spec.Taints)
+	}
+}
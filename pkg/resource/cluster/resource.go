@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sort"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ClusterAllocatedResources is the resource summary of a cluster.
+type ClusterAllocatedResources struct {
+	// CPUCapacity is the allocatable CPU of the cluster in cores.
+	CPUCapacity int64   `json:"cpuCapacity"`
+	CPUFraction float64 `json:"cpuFraction"`
+
+	// MemoryCapacity is the allocatable memory of the cluster in bytes.
+	MemoryCapacity int64   `json:"memoryCapacity"`
+	MemoryFraction float64 `json:"memoryFraction"`
+
+	// AllocatedPods in number of currently allocated pods on the node.
+	AllocatedPods int64 `json:"allocatedPods"`
+
+	// PodCapacity is maximum number of pods, that can be allocated on the node.
+	PodCapacity int64 `json:"podCapacity"`
+
+	// PodFraction is a fraction of pods, that can be allocated on given node.
+	PodFraction float64 `json:"podFraction"`
+
+	// Resources is the usage of every resource reported in the resource summary of the cluster,
+	// including ephemeral storage and extended resources.
+	Resources []ResourceUsage `json:"resources"`
+}
+
+// ResourceUsage is the usage of a resource in one or more clusters.
+type ResourceUsage struct {
+	Name corev1.ResourceName `json:"name"`
+	// Allocatable is the amount of the resource which can be allocated to pods.
+	Allocatable resource.Quantity `json:"allocatable"`
+	// Allocated is the amount of the resource requested by scheduled pods.
+	Allocated resource.Quantity `json:"allocated"`
+	// Allocating is the amount of the resource requested by pods waiting for scheduling.
+	Allocating resource.Quantity `json:"allocating"`
+	// Fraction is the percentage of the allocatable amount which is allocated.
+	Fraction float64 `json:"fraction"`
+}
+
+// GetResourceUsages returns the usage of every resource present in the allocatable, allocated or allocating
+// lists of summary, sorted by name. Resources missing from a list are reported as zero.
+func GetResourceUsages(summary *v1alpha1.ResourceSummary) []ResourceUsage {
+	if summary == nil {
+		return make([]ResourceUsage, 0)
+	}
+	return AggregateResourceUsages(toResourceUsages(summary.Allocatable, summary.Allocated, summary.Allocating))
+}
+
+// AggregateResourceUsages merges the usages of several clusters into the total usage of each resource, sorted by
+// name.
+func AggregateResourceUsages(usages ...[]ResourceUsage) []ResourceUsage {
+	totals := make(map[corev1.ResourceName]*ResourceUsage)
+	for _, list := range usages {
+		for _, usage := range list {
+			total, ok := totals[usage.Name]
+			if !ok {
+				total = &ResourceUsage{Name: usage.Name}
+				totals[usage.Name] = total
+			}
+			total.Allocatable.Add(usage.Allocatable)
+			total.Allocated.Add(usage.Allocated)
+			total.Allocating.Add(usage.Allocating)
+		}
+	}
+	result := make([]ResourceUsage, 0, len(totals))
+	for _, total := range totals {
+		total.Fraction = fraction(total.Allocated, total.Allocatable)
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// FindResourceUsage returns the usage of the named resource, it's zero if the resource is not in usages.
+func FindResourceUsage(usages []ResourceUsage, name corev1.ResourceName) ResourceUsage {
+	for _, usage := range usages {
+		if usage.Name == name {
+			return usage
+		}
+	}
+	return ResourceUsage{Name: name}
+}
+
+func toResourceUsages(allocatable, allocated, allocating corev1.ResourceList) []ResourceUsage {
+	usages := make([]ResourceUsage, 0, len(allocatable))
+	for name, quantity := range allocatable {
+		usages = append(usages, ResourceUsage{Name: name, Allocatable: quantity})
+	}
+	for name, quantity := range allocated {
+		usages = append(usages, ResourceUsage{Name: name, Allocated: quantity})
+	}
+	for name, quantity := range allocating {
+		usages = append(usages, ResourceUsage{Name: name, Allocating: quantity})
+	}
+	return usages
+}
+
+func getclusterAllocatedResources(cluster *v1alpha1.Cluster) ClusterAllocatedResources {
+	resources := GetResourceUsages(cluster.Status.ResourceSummary)
+	cpu := FindResourceUsage(resources, corev1.ResourceCPU)
+	memory := FindResourceUsage(resources, corev1.ResourceMemory)
+	pods := FindResourceUsage(resources, corev1.ResourcePods)
+	return ClusterAllocatedResources{
+		CPUCapacity:    cpu.Allocatable.Value(),
+		CPUFraction:    cpu.Fraction,
+		MemoryCapacity: memory.Allocatable.Value(),
+		MemoryFraction: memory.Fraction,
+		AllocatedPods:  pods.Allocated.Value(),
+		PodCapacity:    pods.Allocatable.Value(),
+		PodFraction:    pods.Fraction,
+		Resources:      resources,
+	}
+}
+
+// fraction returns allocated as a percentage of allocatable, it's zero if nothing is allocatable.
+func fraction(allocated, allocatable resource.Quantity) float64 {
+	if allocatable.IsZero() {
+		return 0
+	}
+	return allocated.AsApproximateFloat64() / allocatable.AsApproximateFloat64() * 100
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestClusterAllocatedResources(t *testing.T) {
+	cluster := newTestCluster()
+	cluster.Status.ResourceSummary = &v1alpha1.ResourceSummary{
+		Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("4"),
+			corev1.ResourceMemory:           resource.MustParse("8Gi"),
+			corev1.ResourcePods:             resource.MustParse("110"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+			"nvidia.com/gpu":                resource.MustParse("2"),
+		},
+		Allocated: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+			corev1.ResourcePods:   resource.MustParse("11"),
+			"nvidia.com/gpu":      resource.MustParse("1"),
+		},
+		Allocating: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("500m"),
+		},
+	}
+
+	allocated := getclusterAllocatedResources(cluster)
+	if allocated.CPUCapacity != 4 || allocated.CPUFraction != 25 {
+		t.Errorf("unexpected cpu %d %v", allocated.CPUCapacity, allocated.CPUFraction)
+	}
+	if allocated.MemoryCapacity != 8<<30 || allocated.MemoryFraction != 25 {
+		t.Errorf("expected allocatable memory, got %d %v", allocated.MemoryCapacity, allocated.MemoryFraction)
+	}
+	if allocated.PodCapacity != 110 || allocated.AllocatedPods != 11 || allocated.PodFraction != 10 {
+		t.Errorf("unexpected pods %d/%d %v", allocated.AllocatedPods, allocated.PodCapacity, allocated.PodFraction)
+	}
+	if len(allocated.Resources) != 5 || allocated.Resources[0].Name != corev1.ResourceCPU || allocated.Resources[3].Name != "nvidia.com/gpu" {
+		t.Fatalf("unexpected resources %+v", allocated.Resources)
+	}
+	if cpu := allocated.Resources[0]; cpu.Allocating.MilliValue() != 500 {
+		t.Errorf("expected allocating cpu, got %s", cpu.Allocating.String())
+	}
+	if storage := FindResourceUsage(allocated.Resources, corev1.ResourceEphemeralStorage); !storage.Allocated.IsZero() || storage.Fraction != 0 {
+		t.Errorf("unexpected ephemeral storage %+v", storage)
+	}
+
+	total := AggregateResourceUsages(allocated.Resources, allocated.Resources)
+	gpu := FindResourceUsage(total, "nvidia.com/gpu")
+	if gpu.Allocatable.Value() != 4 || gpu.Allocated.Value() != 2 || gpu.Fraction != 50 {
+		t.Errorf("unexpected total gpu %+v", gpu)
+	}
+	if empty := getclusterAllocatedResources(newTestCluster()); len(empty.Resources) != 0 || empty.CPUCapacity != 0 {
+		t.Errorf("expected no resources, got %+v", empty)
+	}
+}
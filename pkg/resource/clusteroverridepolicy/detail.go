@@ -31,6 +31,9 @@ type ClusterOverridePolicyDetail struct {
 	// Extends list item structure.
 	ClusterOverridePolicy `json:",inline"`
 
+	// Spec is the complete spec of the policy, forms editing the policy are built from it.
+	Spec v1alpha1.OverrideSpec `json:"spec"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -54,6 +57,7 @@ func GetClusterOverridePolicyDetail(client karmadaclientset.Interface, name stri
 func toOverridePolicyDetail(clusterOverridepolicy *v1alpha1.ClusterOverridePolicy, nonCriticalErrors []error) ClusterOverridePolicyDetail {
 	return ClusterOverridePolicyDetail{
 		ClusterOverridePolicy: toClusterOverridePolicy(clusterOverridepolicy),
+		Spec:                  clusterOverridepolicy.Spec,
 		Errors:                nonCriticalErrors,
 	}
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteroverridepolicy
+
+import (
+	"context"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/resource/policy"
+)
+
+// CreateClusterOverridePolicy creates the ClusterOverridePolicy and returns its details, nothing is persisted if dryRun is set.
+func CreateClusterOverridePolicy(ctx context.Context, client karmadaclientset.Interface, clusterOverridePolicy *v1alpha1.ClusterOverridePolicy, dryRun []string) (*ClusterOverridePolicyDetail, error) {
+	created, err := client.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, clusterOverridePolicy, metaV1.CreateOptions{DryRun: dryRun})
+	if err != nil {
+		return nil, err
+	}
+	detail := toOverridePolicyDetail(created, make([]error, 0))
+	return &detail, nil
+}
+
+// UpdateClusterOverridePolicy replaces the spec of the ClusterOverridePolicy named after clusterOverridePolicy, see policy.Update for how its
+// metadata and resourceVersion are handled. Nothing is persisted if dryRun is set.
+func UpdateClusterOverridePolicy(ctx context.Context, client karmadaclientset.Interface, clusterOverridePolicy *v1alpha1.ClusterOverridePolicy, dryRun []string) (*ClusterOverridePolicyDetail, error) {
+	updated, err := policy.Update(ctx, client.PolicyV1alpha1().ClusterOverridePolicies(), v1alpha1.Resource(v1alpha1.ResourcePluralClusterOverridePolicy), clusterOverridePolicy,
+		func(current, clusterOverridePolicy *v1alpha1.ClusterOverridePolicy) {
+			current.Spec = clusterOverridePolicy.Spec
+		}, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	detail := toOverridePolicyDetail(updated, make([]error, 0))
+	return &detail, nil
+}
+
+// PatchClusterOverridePolicy applies the JSON merge patch to the ClusterOverridePolicy, see policy.Patch for what the patch must
+// contain. Nothing is persisted if dryRun is set.
+func PatchClusterOverridePolicy(ctx context.Context, client karmadaclientset.Interface, name string, patch []byte, dryRun []string) (*ClusterOverridePolicyDetail, error) {
+	patched, err := policy.Patch(ctx, client.PolicyV1alpha1().ClusterOverridePolicies(), v1alpha1.Resource(v1alpha1.ResourcePluralClusterOverridePolicy), name,
+		patch, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	detail := toOverridePolicyDetail(patched, make([]error, 0))
+	return &detail, nil
+}
+
+// DeleteClusterOverridePolicy deletes the ClusterOverridePolicy, the deletion is conditional if resourceVersion is not empty.
+func DeleteClusterOverridePolicy(ctx context.Context, client karmadaclientset.Interface, name, resourceVersion string) error {
+	return policy.Delete(ctx, client.PolicyV1alpha1().ClusterOverridePolicies(), name, resourceVersion)
+}
@@ -31,6 +31,9 @@ type ClusterPropagationPolicyDetail struct {
 	// Extends list item structure.
 	ClusterPropagationPolicy `json:",inline"`
 
+	// Spec is the complete spec of the policy, forms editing the policy are built from it.
+	Spec v1alpha1.PropagationSpec `json:"spec"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -54,6 +57,7 @@ func GetClusterPropagationPolicyDetail(client karmadaclientset.Interface, name s
 func toPropagationPolicyDetail(clusterPropagationpolicy *v1alpha1.ClusterPropagationPolicy, nonCriticalErrors []error) ClusterPropagationPolicyDetail {
 	return ClusterPropagationPolicyDetail{
 		ClusterPropagationPolicy: toClusterPropagationPolicy(clusterPropagationpolicy),
+		Spec:                     clusterPropagationpolicy.Spec,
 		Errors:                   nonCriticalErrors,
 	}
 }
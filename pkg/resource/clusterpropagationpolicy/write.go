@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpropagationpolicy
+
+import (
+	"context"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/resource/policy"
+)
+
+// CreateClusterPropagationPolicy creates the ClusterPropagationPolicy and returns its details, nothing is persisted if dryRun is set.
+func CreateClusterPropagationPolicy(ctx context.Context, client karmadaclientset.Interface, clusterPropagationPolicy *v1alpha1.ClusterPropagationPolicy, dryRun []string) (*ClusterPropagationPolicyDetail, error) {
+	created, err := client.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, clusterPropagationPolicy, metaV1.CreateOptions{DryRun: dryRun})
+	if err != nil {
+		return nil, err
+	}
+	detail := toPropagationPolicyDetail(created, make([]error, 0))
+	return &detail, nil
+}
+
+// UpdateClusterPropagationPolicy replaces the spec of the ClusterPropagationPolicy named after clusterPropagationPolicy, see policy.Update for how its
+// metadata and resourceVersion are handled. Nothing is persisted if dryRun is set.
+func UpdateClusterPropagationPolicy(ctx context.Context, client karmadaclientset.Interface, clusterPropagationPolicy *v1alpha1.ClusterPropagationPolicy, dryRun []string) (*ClusterPropagationPolicyDetail, error) {
+	updated, err := policy.Update(ctx, client.PolicyV1alpha1().ClusterPropagationPolicies(), v1alpha1.Resource(v1alpha1.ResourcePluralClusterPropagationPolicy), clusterPropagationPolicy,
+		func(current, clusterPropagationPolicy *v1alpha1.ClusterPropagationPolicy) {
+			current.Spec = clusterPropagationPolicy.Spec
+		}, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	detail := toPropagationPolicyDetail(updated, make([]error, 0))
+	return &detail, nil
+}
+
+// PatchClusterPropagationPolicy applies the JSON merge patch to the ClusterPropagationPolicy, see policy.Patch for what the patch must
+// contain. Nothing is persisted if dryRun is set.
+func PatchClusterPropagationPolicy(ctx context.Context, client karmadaclientset.Interface, name string, patch []byte, dryRun []string) (*ClusterPropagationPolicyDetail, error) {
+	patched, err := policy.Patch(ctx, client.PolicyV1alpha1().ClusterPropagationPolicies(), v1alpha1.Resource(v1alpha1.ResourcePluralClusterPropagationPolicy), name,
+		patch, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	detail := toPropagationPolicyDetail(patched, make([]error, 0))
+	return &detail, nil
+}
+
+// DeleteClusterPropagationPolicy deletes the ClusterPropagationPolicy, the deletion is conditional if resourceVersion is not empty.
+func DeleteClusterPropagationPolicy(ctx context.Context, client karmadaclientset.Interface, name, resourceVersion string) error {
+	return policy.Delete(ctx, client.PolicyV1alpha1().ClusterPropagationPolicies(), name, resourceVersion)
+}
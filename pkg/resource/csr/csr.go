@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+const (
+	// StatusPending means the request has been neither approved nor denied.
+	StatusPending = "Pending"
+	// StatusApproved means the request has been approved.
+	StatusApproved = "Approved"
+	// StatusDenied means the request has been denied.
+	StatusDenied = "Denied"
+	// StatusFailed means the signer failed to issue the certificate.
+	StatusFailed = "Failed"
+
+	// approvalReason is the reason of the conditions set by dashboard.
+	approvalReason = "KarmadaDashboard"
+)
+
+// CertificateSigningRequest is a request of karmada-agent for the client certificate of karmada apiserver.
+type CertificateSigningRequest struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+	// Username is who created the request, e.g. system:bootstrap:<token id> for agents being registered.
+	Username   string `json:"username"`
+	SignerName string `json:"signerName"`
+	// CommonName and Organizations are the subject of the requested certificate.
+	CommonName    string   `json:"commonName"`
+	Organizations []string `json:"organizations"`
+	Status        string   `json:"status"`
+}
+
+// CertificateSigningRequestList contains a list of certificate signing requests.
+type CertificateSigningRequestList struct {
+	ListMeta                   types.ListMeta              `json:"listMeta"`
+	CertificateSigningRequests []CertificateSigningRequest `json:"certificateSigningRequests"`
+	Errors                     []error                     `json:"errors"`
+}
+
+// GetCSRList returns the certificate signing requests created by `karmadactl register`, which are labeled as
+// karmada system resources.
+func GetCSRList(kubeClient kubeclient.Interface, dsQuery *dataselect.DataSelectQuery) (*CertificateSigningRequestList, error) {
+	csrs, err := kubeClient.CertificatesV1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(csrs.Items), dsQuery)
+	csrList := fromCells(cells)
+	result := &CertificateSigningRequestList{
+		ListMeta:                   types.ListMeta{TotalItems: filteredTotal},
+		CertificateSigningRequests: make([]CertificateSigningRequest, 0, len(csrList)),
+		Errors:                     []error{},
+	}
+	for i := range csrList {
+		result.CertificateSigningRequests = append(result.CertificateSigningRequests, toCertificateSigningRequest(&csrList[i]))
+	}
+	return result, nil
+}
+
+// Approve approves the pending certificate signing request of name.
+func Approve(kubeClient kubeclient.Interface, name, message string) (*CertificateSigningRequest, error) {
+	return setApproval(kubeClient, name, certificatesv1.CertificateApproved, message)
+}
+
+// Deny denies the pending certificate signing request of name.
+func Deny(kubeClient kubeclient.Interface, name, message string) (*CertificateSigningRequest, error) {
+	return setApproval(kubeClient, name, certificatesv1.CertificateDenied, message)
+}
+
+func setApproval(kubeClient kubeclient.Interface, name string, conditionType certificatesv1.RequestConditionType, message string) (*CertificateSigningRequest, error) {
+	csr, err := kubeClient.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if status := statusOf(csr); status != StatusPending {
+		return nil, errors.NewBadRequest(fmt.Sprintf("certificate signing request %s is %s already", name, status))
+	}
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           conditionType,
+		Status:         corev1.ConditionTrue,
+		Reason:         approvalReason,
+		Message:        message,
+		LastUpdateTime: metav1.Now(),
+	})
+	csr, err = kubeClient.CertificatesV1().CertificateSigningRequests().UpdateApproval(context.TODO(), name, csr, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := toCertificateSigningRequest(csr)
+	return &result, nil
+}
+
+func toCertificateSigningRequest(csr *certificatesv1.CertificateSigningRequest) CertificateSigningRequest {
+	result := CertificateSigningRequest{
+		ObjectMeta:    types.NewObjectMeta(csr.ObjectMeta),
+		TypeMeta:      types.NewTypeMeta(types.ResourceKindCertificateSigningRequest),
+		Username:      csr.Spec.Username,
+		SignerName:    csr.Spec.SignerName,
+		Organizations: make([]string, 0),
+		Status:        statusOf(csr),
+	}
+	if block, _ := pem.Decode(csr.Spec.Request); block != nil {
+		if request, err := x509.ParseCertificateRequest(block.Bytes); err == nil {
+			result.CommonName = request.Subject.CommonName
+			result.Organizations = append(result.Organizations, request.Subject.Organization...)
+		}
+	}
+	return result
+}
+
+func statusOf(csr *certificatesv1.CertificateSigningRequest) string {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case certificatesv1.CertificateDenied:
+			return StatusDenied
+		case certificatesv1.CertificateFailed:
+			return StatusFailed
+		case certificatesv1.CertificateApproved:
+			return StatusApproved
+		}
+	}
+	return StatusPending
+}
@@ -39,8 +39,8 @@ type NamespaceSpec struct {
 	SkipAutoPropagation bool
 }
 
-// CreateNamespace creates namespace based on given specification.
-func CreateNamespace(spec *NamespaceSpec, client kubernetes.Interface) error {
+// CreateNamespace creates namespace based on given specification, nothing is persisted if dryRun is set.
+func CreateNamespace(spec *NamespaceSpec, client kubernetes.Interface, dryRun []string) error {
 	// todo add namespace.karmada.io/skip-auto-propagation: "true"  to avoid auto-propagation
 	// https://karmada.io/docs/userguide/bestpractices/namespace-management/#labeling-the-namespace
 	log.Printf("Creating namespace %s", spec.Name)
@@ -54,7 +54,7 @@ func CreateNamespace(spec *NamespaceSpec, client kubernetes.Interface) error {
 			skipAutoPropagationLable: "true",
 		}
 	}
-	_, err := client.CoreV1().Namespaces().Create(context.TODO(), namespace, metaV1.CreateOptions{})
+	_, err := client.CoreV1().Namespaces().Create(context.TODO(), namespace, metaV1.CreateOptions{DryRun: dryRun})
 	return err
 }
 
@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/overridemanager"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KindOverridePolicy is the kind of namespaced override policies.
+	KindOverridePolicy = "OverridePolicy"
+	// KindClusterOverridePolicy is the kind of cluster scoped override policies.
+	KindClusterOverridePolicy = "ClusterOverridePolicy"
+)
+
+// PreviewPolicy is an override policy which may not have been created yet, Namespace is empty for
+// a ClusterOverridePolicy.
+type PreviewPolicy struct {
+	Namespace string
+	Name      string
+	Spec      v1alpha1.OverrideSpec
+}
+
+// PolicyReference refers to an override policy applied to a resource.
+type PolicyReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// ClusterOverride is the resource rendered for a cluster matched by the rules of the previewed policy.
+type ClusterOverride struct {
+	Cluster string `json:"cluster"`
+	// AppliedPolicies are the policies whose rules matched the cluster in the order they're applied, it
+	// includes the other existing policies selecting the resource.
+	AppliedPolicies []PolicyReference `json:"appliedPolicies"`
+	// Manifest is the rendered resource in YAML, it's empty if the overriders failed to apply.
+	Manifest string `json:"manifest,omitempty"`
+	// Diff is the unified diff of Manifest against the template.
+	Diff  string `json:"diff,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// OverridePreview is the result of rendering a resource with an override policy for every cluster the
+// rules of the policy match.
+type OverridePreview struct {
+	// Selected tells whether the ResourceSelectors of the previewed policy select the resource.
+	Selected bool `json:"selected"`
+	// Template is the resource in YAML the overriders are applied to.
+	Template string            `json:"template"`
+	Clusters []ClusterOverride `json:"clusters"`
+	// Warnings are problems which don't prevent the preview, e.g. a namespaced policy in another namespace.
+	Warnings []string `json:"warnings"`
+}
+
+// draftPolicyName names the previewed policy if it has no name yet.
+const draftPolicyName = "draft"
+
+// Preview renders resource with policy and the existing override policies selecting it by the override manager
+// of karmada, the previewed policy replaces the existing one of the same name. The override manager reads the
+// policies and clusters listed from karmadaClient, only the clusters matched by a rule of policy are rendered.
+func Preview(ctx context.Context, karmadaClient karmadaclientset.Interface, policy *PreviewPolicy,
+	resource *unstructured.Unstructured) (*OverridePreview, error) {
+	template := pruneTemplate(resource)
+	templateData, err := yaml.Marshal(template.Object)
+	if err != nil {
+		return nil, err
+	}
+	preview := &OverridePreview{
+		Template: string(templateData),
+		Clusters: make([]ClusterOverride, 0),
+		Warnings: make([]string, 0),
+	}
+
+	draft := draftPolicy(policy)
+	// karmada only applies OverridePolicies to namespaced resources in the same namespace
+	if len(draft.GetNamespace()) > 0 && draft.GetNamespace() != template.GetNamespace() {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("OverridePolicy in namespace %s does not apply to resources outside of it", draft.GetNamespace()))
+	} else {
+		preview.Selected = len(policy.Spec.ResourceSelectors) == 0 || karmadautil.ResourceMatchSelectors(template, policy.Spec.ResourceSelectors...)
+	}
+	if !preview.Selected {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("resource %s %s is not selected by the resourceSelectors of the policy",
+			template.GetKind(), objectName(template.GetNamespace(), template.GetName())))
+		return preview, nil
+	}
+
+	clusters, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	reader, err := previewPolicies(ctx, karmadaClient, draft, template)
+	if err != nil {
+		return nil, err
+	}
+	reader.clusters = clusters.Items
+	manager := overridemanager.New(reader, noopRecorder{})
+
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		return clusters.Items[i].Name < clusters.Items[j].Name
+	})
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !rulesMatchCluster(policy.Spec, cluster) {
+			continue
+		}
+		rendered := template.DeepCopy()
+		override := ClusterOverride{Cluster: cluster.Name, AppliedPolicies: make([]PolicyReference, 0)}
+		clusterOverrides, namespacedOverrides, err := manager.ApplyOverridePolicies(rendered, cluster.Name)
+		if err != nil {
+			override.Error = err.Error()
+			preview.Clusters = append(preview.Clusters, override)
+			continue
+		}
+		override.AppliedPolicies = appendAppliedPolicies(override.AppliedPolicies, clusterOverrides, KindClusterOverridePolicy, "")
+		override.AppliedPolicies = appendAppliedPolicies(override.AppliedPolicies, namespacedOverrides, KindOverridePolicy, template.GetNamespace())
+		override.Manifest, override.Diff, err = renderDiff(preview.Template, rendered, cluster.Name)
+		if err != nil {
+			override.Error = err.Error()
+		}
+		preview.Clusters = append(preview.Clusters, override)
+	}
+	return preview, nil
+}
+
+// draftPolicy returns the previewed policy as the object karmada would store.
+func draftPolicy(policy *PreviewPolicy) client.Object {
+	name := policy.Name
+	if len(name) == 0 {
+		name = draftPolicyName
+	}
+	if len(policy.Namespace) > 0 {
+		return &v1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: policy.Namespace, Name: name},
+			Spec:       policy.Spec,
+		}
+	}
+	return &v1alpha1.ClusterOverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       policy.Spec,
+	}
+}
+
+// previewPolicies returns a reader of the override policies which may apply to resource, with the existing policy
+// named after the draft replaced by it.
+func previewPolicies(ctx context.Context, karmadaClient karmadaclientset.Interface, draft client.Object,
+	resource *unstructured.Unstructured) (*previewReader, error) {
+	reader := &previewReader{}
+	switch p := draft.(type) {
+	case *v1alpha1.OverridePolicy:
+		reader.overridePolicies = append(reader.overridePolicies, *p)
+	case *v1alpha1.ClusterOverridePolicy:
+		reader.clusterOverridePolicies = append(reader.clusterOverridePolicies, *p)
+	}
+
+	clusterOverridePolicies, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range clusterOverridePolicies.Items {
+		if len(draft.GetNamespace()) == 0 && p.Name == draft.GetName() {
+			continue
+		}
+		reader.clusterOverridePolicies = append(reader.clusterOverridePolicies, p)
+	}
+
+	if len(resource.GetNamespace()) == 0 {
+		return reader, nil
+	}
+	overridePolicies, err := karmadaClient.PolicyV1alpha1().OverridePolicies(resource.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range overridePolicies.Items {
+		if p.Namespace == draft.GetNamespace() && p.Name == draft.GetName() {
+			continue
+		}
+		reader.overridePolicies = append(reader.overridePolicies, p)
+	}
+	return reader, nil
+}
+
+// rulesMatchCluster tells whether a rule of spec targets cluster, i.e. whether the policy is rendered for it.
+func rulesMatchCluster(spec v1alpha1.OverrideSpec, cluster *clusterv1alpha1.Cluster) bool {
+	for _, rule := range overrideRules(spec) {
+		if rule.TargetCluster == nil || karmadautil.ClusterMatches(cluster, *rule.TargetCluster) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendAppliedPolicies appends the policies of applied in the order they're applied, a policy with several
+// matching rules is listed once.
+func appendAppliedPolicies(policies []PolicyReference, applied *overridemanager.AppliedOverrides, kind, namespace string) []PolicyReference {
+	if applied == nil {
+		return policies
+	}
+	for _, item := range applied.AppliedItems {
+		if n := len(policies); n > 0 && policies[n-1].Kind == kind && policies[n-1].Name == item.PolicyName {
+			continue
+		}
+		policies = append(policies, PolicyReference{Kind: kind, Namespace: namespace, Name: item.PolicyName})
+	}
+	return policies
+}
+
+// overrideRules returns the OverrideRules of spec, or a rule of the deprecated TargetCluster and Overriders
+// if there are none.
+func overrideRules(spec v1alpha1.OverrideSpec) []v1alpha1.RuleWithCluster {
+	if len(spec.OverrideRules) > 0 {
+		return spec.OverrideRules
+	}
+	//nolint:staticcheck
+	// the deprecated fields are still honored by karmada for backward compatibility.
+	return []v1alpha1.RuleWithCluster{{TargetCluster: spec.TargetCluster, Overriders: spec.Overriders}}
+}
+
+// pruneTemplate removes the status and the fields maintained by the apiserver, which are not propagated.
+func pruneTemplate(resource *unstructured.Unstructured) *unstructured.Unstructured {
+	template := resource.DeepCopy()
+	unstructured.RemoveNestedField(template.Object, "status")
+	template.SetManagedFields(nil)
+	template.SetResourceVersion("")
+	template.SetUID("")
+	template.SetCreationTimestamp(metav1.Time{})
+	template.SetGeneration(0)
+	template.SetSelfLink("")
+	return template
+}
+
+func renderDiff(template string, rendered *unstructured.Unstructured, cluster string) (string, string, error) {
+	manifest, err := yaml.Marshal(rendered.Object)
+	if err != nil {
+		return "", "", err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(template),
+		B:        difflib.SplitLines(string(manifest)),
+		FromFile: "template",
+		ToFile:   cluster,
+		Context:  3,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return string(manifest), diff, nil
+}
+
+func objectName(namespace, name string) string {
+	if len(namespace) == 0 {
+		return name
+	}
+	return namespace + "/" + name
+}
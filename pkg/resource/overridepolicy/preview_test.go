@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/karmada-io/dashboard/pkg/testing/helper"
+)
+
+func TestPreview(t *testing.T) {
+	karmadaClient := karmadafake.NewSimpleClientset(
+		helper.NewCluster("member1", "us-east", nil),
+		helper.NewCluster("member2", "eu-west", nil),
+		helper.NewCluster("member3", "us-east", nil),
+		&v1alpha1.ClusterOverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			Spec: v1alpha1.OverrideSpec{OverrideRules: []v1alpha1.RuleWithCluster{{
+				Overriders: v1alpha1.Overriders{LabelsOverrider: []v1alpha1.LabelAnnotationOverrider{
+					{Operator: v1alpha1.OverriderOpAdd, Value: map[string]string{"team": "platform"}},
+				}},
+			}}},
+		},
+		&v1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: v1alpha1.OverrideSpec{OverrideRules: []v1alpha1.RuleWithCluster{{
+				Overriders: v1alpha1.Overriders{LabelsOverrider: []v1alpha1.LabelAnnotationOverrider{
+					{Operator: v1alpha1.OverriderOpAdd, Value: map[string]string{"stale": "true"}},
+				}},
+			}}},
+		},
+	)
+
+	type renderedCluster struct {
+		cluster string
+		// appliedPolicies are the kinds and names of the policies applied in order
+		appliedPolicies []string
+		contains        []string
+		excludes        []string
+		diff            string
+	}
+	cases := []struct {
+		name     string
+		policy   *PreviewPolicy
+		selected bool
+		warnings int
+		clusters []renderedCluster
+	}{
+		{
+			name: "draft replaces the existing policy",
+			policy: &PreviewPolicy{
+				Namespace: "default",
+				Name:      "web",
+				Spec: v1alpha1.OverrideSpec{
+					ResourceSelectors: []v1alpha1.ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}},
+					OverrideRules: []v1alpha1.RuleWithCluster{
+						{
+							TargetCluster: &v1alpha1.ClusterAffinity{ClusterNames: []string{"member1", "member2"}},
+							Overriders: v1alpha1.Overriders{
+								ImageOverrider: []v1alpha1.ImageOverrider{
+									{Component: v1alpha1.Registry, Operator: v1alpha1.OverriderOpReplace, Value: "registry.example.com"},
+									{Component: v1alpha1.Tag, Operator: v1alpha1.OverriderOpReplace, Value: "1.26"},
+								},
+								ArgsOverrider: []v1alpha1.CommandArgsOverrider{
+									{ContainerName: "web", Operator: v1alpha1.OverriderOpAdd, Value: []string{"--verbose"}},
+								},
+							},
+						},
+						{
+							TargetCluster: &v1alpha1.ClusterAffinity{ClusterNames: []string{"member2"}},
+							Overriders: v1alpha1.Overriders{Plaintext: []v1alpha1.PlaintextOverrider{
+								{Path: "/metadata/labels/region", Operator: v1alpha1.OverriderOpAdd, Value: apiextensionsv1.JSON{Raw: []byte(`"eu"`)}},
+							}},
+						},
+					},
+				},
+			},
+			selected: true,
+			clusters: []renderedCluster{
+				{
+					cluster: "member1",
+					// the draft is applied after the ClusterOverridePolicy
+					appliedPolicies: []string{"ClusterOverridePolicy/global", "OverridePolicy/web"},
+					contains:        []string{"image: registry.example.com/library/nginx:1.26", "- --verbose", "team: platform"},
+					excludes:        []string{"stale", "region: eu"},
+					diff:            "+        image: registry.example.com/library/nginx:1.26",
+				},
+				{
+					cluster:         "member2",
+					appliedPolicies: []string{"ClusterOverridePolicy/global", "OverridePolicy/web"},
+					contains:        []string{"region: eu"},
+					excludes:        []string{"stale"},
+					diff:            "+    region: eu",
+				},
+			},
+		},
+		{
+			name: "resource not selected",
+			policy: &PreviewPolicy{
+				Name: "other",
+				Spec: v1alpha1.OverrideSpec{
+					ResourceSelectors: []v1alpha1.ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment", Name: "other"}},
+				},
+			},
+			warnings: 1,
+		},
+	}
+	for _, c := range cases {
+		deployment := helper.NewDeployment("default", "web", map[string]string{"app": "web"}, nil)
+		deployment.SetResourceVersion("42")
+		if err := unstructured.SetNestedField(deployment.Object, int64(1), "status", "replicas"); err != nil {
+			t.Fatal(err)
+		}
+		preview, err := Preview(context.TODO(), karmadaClient, c.policy, deployment)
+		if err != nil {
+			t.Fatalf("%s: Preview() error = %v", c.name, err)
+		}
+		if preview.Selected != c.selected || len(preview.Warnings) != c.warnings || len(preview.Clusters) != len(c.clusters) {
+			t.Errorf("%s: unexpected preview %+v", c.name, preview)
+			continue
+		}
+		if c.selected && (strings.Contains(preview.Template, "resourceVersion") || strings.Contains(preview.Template, "status")) {
+			t.Errorf("%s: expected the template to be pruned, got:\n%s", c.name, preview.Template)
+		}
+		for n, want := range c.clusters {
+			rendered := preview.Clusters[n]
+			if rendered.Cluster != want.cluster || len(rendered.Error) > 0 || len(rendered.AppliedPolicies) != len(want.appliedPolicies) {
+				t.Errorf("%s: unexpected rendering of %s: %+v", c.name, want.cluster, rendered)
+				continue
+			}
+			for i, name := range want.appliedPolicies {
+				if applied := rendered.AppliedPolicies[i]; applied.Kind+"/"+applied.Name != name {
+					t.Errorf("%s: expected %s to be applied to %s at %d, got %+v", c.name, name, want.cluster, i, rendered.AppliedPolicies)
+				}
+			}
+			for _, s := range want.contains {
+				if !strings.Contains(rendered.Manifest, s) {
+					t.Errorf("%s: expected manifest of %s to contain %q, got:\n%s", c.name, want.cluster, s, rendered.Manifest)
+				}
+			}
+			for _, s := range want.excludes {
+				if strings.Contains(rendered.Manifest, s) {
+					t.Errorf("%s: expected manifest of %s not to contain %q, got:\n%s", c.name, want.cluster, s, rendered.Manifest)
+				}
+			}
+			if !strings.Contains(rendered.Diff, "+++ "+want.cluster) || !strings.Contains(rendered.Diff, want.diff) {
+				t.Errorf("%s: unexpected diff of %s:\n%s", c.name, want.cluster, rendered.Diff)
+			}
+		}
+	}
+}
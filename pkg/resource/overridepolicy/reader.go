@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// previewReader serves the reads of the override manager from the policies and clusters listed for a preview.
+// The override manager only reads, the embedded client is nil and its write methods must not be called.
+type previewReader struct {
+	client.Client
+	clusters                []clusterv1alpha1.Cluster
+	clusterOverridePolicies []v1alpha1.ClusterOverridePolicy
+	overridePolicies        []v1alpha1.OverridePolicy
+}
+
+var _ client.Reader = &previewReader{}
+
+// Get returns the cluster named after key, other kinds are never read by the override manager.
+func (r *previewReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	cluster, ok := obj.(*clusterv1alpha1.Cluster)
+	if !ok {
+		return fmt.Errorf("unsupported object %T", obj)
+	}
+	for i := range r.clusters {
+		if r.clusters[i].Name == key.Name {
+			r.clusters[i].DeepCopyInto(cluster)
+			return nil
+		}
+	}
+	return apierrors.NewNotFound(clusterv1alpha1.Resource("clusters"), key.Name)
+}
+
+// List returns the ClusterOverridePolicies, or the OverridePolicies in the namespace of opts.
+func (r *previewReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	options := (&client.ListOptions{}).ApplyOptions(opts)
+	switch l := list.(type) {
+	case *v1alpha1.ClusterOverridePolicyList:
+		l.Items = make([]v1alpha1.ClusterOverridePolicy, 0, len(r.clusterOverridePolicies))
+		for i := range r.clusterOverridePolicies {
+			l.Items = append(l.Items, *r.clusterOverridePolicies[i].DeepCopy())
+		}
+	case *v1alpha1.OverridePolicyList:
+		l.Items = make([]v1alpha1.OverridePolicy, 0, len(r.overridePolicies))
+		for i := range r.overridePolicies {
+			if len(options.Namespace) == 0 || r.overridePolicies[i].Namespace == options.Namespace {
+				l.Items = append(l.Items, *r.overridePolicies[i].DeepCopy())
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported list %T", list)
+	}
+	return nil
+}
+
+// noopRecorder drops the events of the override manager, failures are returned by ApplyOverridePolicies as well.
+type noopRecorder struct{}
+
+func (noopRecorder) Event(runtime.Object, string, string, string) {}
+
+func (noopRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (noopRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
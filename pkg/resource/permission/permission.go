@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permission
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+const (
+	// DefaultNamespace is the namespace permissions are evaluated in if none is given.
+	DefaultNamespace = "default"
+	// maxConcurrentAccessReviews limits the SelfSubjectAccessReviews sent at the same time
+	// when the authorizer can not enumerate the rules of user.
+	maxConcurrentAccessReviews = 16
+)
+
+// Verbs are the verbs that decide which actions the UI offers.
+var Verbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// UserInfo is the identity of the user as seen by the karmada apiserver.
+type UserInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups"`
+}
+
+// NamespacePermissions are the verbs the user holds on each namespaced resource kind in a namespace.
+type NamespacePermissions struct {
+	Namespace string                          `json:"namespace"`
+	Resources map[types.ResourceKind][]string `json:"resources"`
+}
+
+// Permissions are the verbs the user holds on the resource kinds supported by the UI.
+type Permissions struct {
+	// Cluster contains the verbs on cluster scoped resource kinds.
+	Cluster map[types.ResourceKind][]string `json:"cluster"`
+	// Namespaces contains the verbs on namespaced resource kinds for each requested namespace.
+	Namespaces []NamespacePermissions `json:"namespaces"`
+}
+
+// GetPermissions evaluates the verbs the user of kubeClient holds on the given kinds in the given namespaces,
+// all supported kinds are evaluated if kinds is empty. Cluster scoped kinds are evaluated with a
+// SelfSubjectAccessReview for each kind and verb. Rules of namespaced kinds are fetched with
+// SelfSubjectRulesReview, if the authorizer can not enumerate them access reviews are sent instead.
+func GetPermissions(kubeClient kubeclient.Interface, namespaces []string, kinds []types.ResourceKind) (*Permissions, error) {
+	if len(namespaces) == 0 {
+		namespaces = []string{DefaultNamespace}
+	}
+	if len(kinds) == 0 {
+		for kind := range types.KindToAPIResource {
+			kinds = append(kinds, kind)
+		}
+	}
+	var namespacedKinds, clusterKinds []types.ResourceKind
+	for _, kind := range kinds {
+		resource, ok := types.KindToAPIResource[kind]
+		if !ok {
+			return nil, errors.NewBadRequest("unknown resource kind " + string(kind))
+		}
+		if resource.Namespaced {
+			namespacedKinds = append(namespacedKinds, kind)
+		} else {
+			clusterKinds = append(clusterKinds, kind)
+		}
+	}
+
+	// rules reviews include the namespaced role bindings of their namespace, so cluster scoped kinds are
+	// always evaluated with access reviews without a namespace
+	clusterPermissions, err := accessReviews(kubeClient, "", clusterKinds)
+	if err != nil {
+		return nil, err
+	}
+	permissions := &Permissions{
+		Cluster:    clusterPermissions,
+		Namespaces: make([]NamespacePermissions, 0, len(namespaces)),
+	}
+	for _, namespace := range namespaces {
+		review, err := kubeClient.AuthorizationV1().SelfSubjectRulesReviews().Create(context.TODO(), &authorizationv1.SelfSubjectRulesReview{
+			Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		namespacePermissions := NamespacePermissions{Namespace: namespace}
+		if review.Status.Incomplete {
+			klog.V(2).InfoS("Rules of user are incomplete, falling back to access reviews", "namespace", namespace, "reason", review.Status.EvaluationError)
+			if namespacePermissions.Resources, err = accessReviews(kubeClient, namespace, namespacedKinds); err != nil {
+				return nil, err
+			}
+		} else {
+			namespacePermissions.Resources = verbsFromRules(review.Status.ResourceRules, namespacedKinds)
+		}
+		permissions.Namespaces = append(permissions.Namespaces, namespacePermissions)
+	}
+	return permissions, nil
+}
+
+// ReviewToken authenticates the token with a TokenReview and returns the user it belongs to.
+// The review is sent with the credentials of the given client since users usually may not create TokenReviews.
+func ReviewToken(kubeClient kubeclient.Interface, token string) (*UserInfo, error) {
+	review, err := kubeClient.AuthenticationV1().TokenReviews().Create(context.TODO(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+	}
+	return toUserInfo(review.Status.User), nil
+}
+
+// ReviewSelf returns the user of kubeClient with a SelfSubjectReview, it's used when the token of user is not
+// known, e.g. when the request is served with impersonation.
+func ReviewSelf(kubeClient kubeclient.Interface) (*UserInfo, error) {
+	review, err := kubeClient.AuthenticationV1().SelfSubjectReviews().Create(context.TODO(), &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return toUserInfo(review.Status.UserInfo), nil
+}
+
+// CanAccessNonResource reports whether the user of kubeClient may perform verb on the non-resource path, it's used
+// to authorize dashboard features which are not backed by a kubernetes resource, e.g. the audit log.
+func CanAccessNonResource(kubeClient kubeclient.Interface, path, verb string) (bool, error) {
+	review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: path, Verb: verb},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// CanAccess reports whether the user of kubeClient may perform the action described by attributes.
+func CanAccess(kubeClient kubeclient.Interface, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+func toUserInfo(user authenticationv1.UserInfo) *UserInfo {
+	groups := user.Groups
+	if groups == nil {
+		groups = make([]string, 0)
+	}
+	return &UserInfo{Username: user.Username, UID: user.UID, Groups: groups}
+}
+
+func verbsFromRules(rules []authorizationv1.ResourceRule, kinds []types.ResourceKind) map[types.ResourceKind][]string {
+	result := make(map[types.ResourceKind][]string, len(kinds))
+	for _, kind := range kinds {
+		resource := types.KindToAPIResource[kind]
+		verbs := make([]string, 0)
+		for _, verb := range Verbs {
+			for _, rule := range rules {
+				if ruleAllows(rule, resource, verb) {
+					verbs = append(verbs, verb)
+					break
+				}
+			}
+		}
+		result[kind] = verbs
+	}
+	return result
+}
+
+// ruleAllows reports whether the rule grants the verb on all objects of the resource,
+// rules restricted to resource names are ignored since the UI can not tell which objects they cover.
+func ruleAllows(rule authorizationv1.ResourceRule, resource types.APIResource, verb string) bool {
+	return len(rule.ResourceNames) == 0 &&
+		matches(rule.APIGroups, resource.Group) &&
+		matches(rule.Resources, resource.Resource) &&
+		matches(rule.Verbs, verb)
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// accessReviews sends a SelfSubjectAccessReview for each kind and verb and returns the allowed verbs,
+// the first failed review aborts the remaining ones and is returned.
+func accessReviews(kubeClient kubeclient.Interface, namespace string, kinds []types.ResourceKind) (map[types.ResourceKind][]string, error) {
+	type check struct {
+		kind types.ResourceKind
+		verb string
+	}
+	checks := make([]check, 0, len(kinds)*len(Verbs))
+	for _, kind := range kinds {
+		for _, verb := range Verbs {
+			checks = append(checks, check{kind: kind, verb: verb})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	var lock sync.Mutex
+	var reviewErr error
+	result := make(map[types.ResourceKind][]string, len(kinds))
+	for _, kind := range kinds {
+		result[kind] = make([]string, 0)
+	}
+	workqueue.ParallelizeUntil(ctx, maxConcurrentAccessReviews, len(checks), func(i int) {
+		c := checks[i]
+		resource := types.KindToAPIResource[c.kind]
+		review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      c.verb,
+					Group:     resource.Group,
+					Resource:  resource.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		lock.Lock()
+		defer lock.Unlock()
+		if err != nil {
+			if reviewErr == nil {
+				reviewErr = err
+				cancel()
+			}
+			return
+		}
+		if review.Status.Allowed {
+			result[c.kind] = append(result[c.kind], c.verb)
+		}
+	})
+	if reviewErr != nil {
+		klog.ErrorS(reviewErr, "Could not review access", "namespace", namespace)
+		return nil, reviewErr
+	}
+	for kind := range result {
+		sortVerbs(result[kind])
+	}
+	return result, nil
+}
+
+// sortVerbs sorts verbs in the order of Verbs.
+func sortVerbs(verbs []string) {
+	order := make(map[string]int, len(Verbs))
+	for i, verb := range Verbs {
+		order[verb] = i
+	}
+	sort.Slice(verbs, func(i, j int) bool {
+		return order[verbs[i]] < order[verbs[j]]
+	})
+}
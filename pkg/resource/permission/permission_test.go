@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permission
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+func TestVerbsFromRules(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"policy.karmada.io"}, Resources: []string{"propagationpolicies"}},
+		{Verbs: []string{"delete"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"nginx"}},
+	}
+	cases := []struct {
+		kind     types.ResourceKind
+		expected []string
+	}{
+		{types.ResourceKindPropagationPolicy, Verbs},
+		{types.ResourceKindDeployment, []string{"get", "list", "watch"}},
+		{types.ResourceKindCluster, []string{"get", "list", "watch"}},
+	}
+	for _, c := range cases {
+		actual := verbsFromRules(rules, []types.ResourceKind{c.kind})[c.kind]
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("verbsFromRules() for %s == %v, expected %v", c.kind, actual, c.expected)
+		}
+	}
+}
+
+func TestGetPermissions(t *testing.T) {
+	// the rules review of the namespace grants everything through a namespaced role binding,
+	// the user may only get clusters cluster wide
+	rulesReview := func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, &authorizationv1.SelfSubjectRulesReview{
+			Status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+			},
+		}, nil
+	}
+	cases := []struct {
+		name          string
+		accessReview  clienttesting.ReactionFunc
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name: "cluster scoped kinds use access reviews",
+			accessReview: func(action clienttesting.Action) (bool, runtime.Object, error) {
+				review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = attributes.Namespace == "" && attributes.Verb == "get"
+				return true, review, nil
+			},
+			expected: []string{"get"},
+		},
+		{
+			name: "failed access reviews are returned",
+			accessReview: func(action clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("authorizer unavailable")
+			},
+			expectedError: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "selfsubjectrulesreviews", rulesReview)
+			kubeClient.PrependReactor("create", "selfsubjectaccessreviews", c.accessReview)
+
+			permissions, err := GetPermissions(kubeClient, []string{DefaultNamespace},
+				[]types.ResourceKind{types.ResourceKindCluster, types.ResourceKindDeployment})
+			if c.expectedError {
+				if err == nil {
+					t.Fatalf("GetPermissions() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPermissions() returned error %v", err)
+			}
+			if actual := permissions.Cluster[types.ResourceKindCluster]; !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("GetPermissions() for %s == %v, expected %v", types.ResourceKindCluster, actual, c.expected)
+			}
+			if actual := permissions.Namespaces[0].Resources[types.ResourceKindDeployment]; !reflect.DeepEqual(actual, Verbs) {
+				t.Errorf("GetPermissions() for %s == %v, expected %v", types.ResourceKindDeployment, actual, Verbs)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
+)
+
+// ResourceReference refers to a resource selected by policies.
+type ResourceReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// PropagationCandidate is a propagation policy matching a resource.
+type PropagationCandidate struct {
+	propagationpolicy.PolicyReference `json:",inline"`
+	// Specificity is how the most specific selector of the policy matches the resource: name, labelSelector or all.
+	Specificity string `json:"specificity"`
+	Winner      bool   `json:"winner"`
+	// Reason explains why the policy loses to the winner, it's empty for the winner.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PropagationConflict is a resource matched by more than one propagation policy.
+type PropagationConflict struct {
+	Resource ResourceReference `json:"resource"`
+	// Policies are the matching policies ordered by precedence, the first one wins.
+	Policies []PropagationCandidate `json:"policies"`
+	// ClaimedBy is the policy currently propagating the resource. It may differ from the winner since a claimed
+	// resource is only taken over by a policy with preemption enabled.
+	ClaimedBy *propagationpolicy.PolicyReference `json:"claimedBy,omitempty"`
+}
+
+// OverrideCandidate is an override policy matching a resource.
+type OverrideCandidate struct {
+	overridepolicy.PolicyReference `json:",inline"`
+	// Specificity is how the most specific selector of the policy matches the resource: name, labelSelector or all.
+	Specificity string `json:"specificity"`
+	// Order is the position the policy is applied at, starting from 1.
+	Order int `json:"order"`
+}
+
+// OverrideOverlap is a resource matched by more than one override policy.
+type OverrideOverlap struct {
+	Resource ResourceReference `json:"resource"`
+	// Policies are the matching policies in the order they're applied, the overriders of a later policy take
+	// effect if several policies override the same field. The rules of a policy may still only target some clusters.
+	Policies []OverrideCandidate `json:"policies"`
+}
+
+// PolicyConflicts are the resources matched by several policies of the same purpose.
+type PolicyConflicts struct {
+	Propagation []PropagationConflict `json:"propagation"`
+	Override    []OverrideOverlap     `json:"override"`
+	// Warnings are problems which don't prevent the analysis, e.g. a selector of an unknown kind.
+	Warnings []string `json:"warnings"`
+	// Truncated tells the analysis stopped at the limit, more resources may conflict.
+	Truncated bool `json:"truncated"`
+}
+
+// Index holds the policies of all four kinds, the namespaced ones are grouped by namespace.
+type Index struct {
+	propagationPolicies        map[string][]v1alpha1.PropagationPolicy
+	clusterPropagationPolicies []v1alpha1.ClusterPropagationPolicy
+	overridePolicies           map[string][]v1alpha1.OverridePolicy
+	clusterOverridePolicies    []v1alpha1.ClusterOverridePolicy
+}
+
+// NewIndex lists the PropagationPolicies, ClusterPropagationPolicies, OverridePolicies and ClusterOverridePolicies
+// of karmada control plane.
+func NewIndex(ctx context.Context, karmadaClient karmadaclientset.Interface) (*Index, error) {
+	index := &Index{
+		propagationPolicies: make(map[string][]v1alpha1.PropagationPolicy),
+		overridePolicies:    make(map[string][]v1alpha1.OverridePolicy),
+	}
+	propagationPolicies, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range propagationPolicies.Items {
+		index.propagationPolicies[p.Namespace] = append(index.propagationPolicies[p.Namespace], p)
+	}
+	clusterPropagationPolicies, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	index.clusterPropagationPolicies = clusterPropagationPolicies.Items
+	overridePolicies, err := karmadaClient.PolicyV1alpha1().OverridePolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range overridePolicies.Items {
+		index.overridePolicies[p.Namespace] = append(index.overridePolicies[p.Namespace], p)
+	}
+	clusterOverridePolicies, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	index.clusterOverridePolicies = clusterOverridePolicies.Items
+	return index, nil
+}
+
+// listChunkSize is the number of resources listed at a time during the analysis.
+const listChunkSize = 500
+
+// AnalyzeConflicts evaluates the policies of karmada control plane against the resources their selectors refer
+// to and reports the resources matched by several propagation or override policies. Only resources in namespace
+// are evaluated if it's not empty. Resources are listed with dynamicClient, mapper resolves the kinds of selectors.
+// The analysis stops once limit resources with a conflict or overlap are found, a non-positive limit analyzes all
+// resources.
+func AnalyzeConflicts(ctx context.Context, karmadaClient karmadaclientset.Interface, dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper, namespace string, limit int) (*PolicyConflicts, error) {
+	index, err := NewIndex(ctx, karmadaClient)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := &PolicyConflicts{
+		Propagation: make([]PropagationConflict, 0),
+		Override:    make([]OverrideOverlap, 0),
+		Warnings:    make([]string, 0),
+	}
+	found := 0
+	for _, gvk := range index.selectedKinds() {
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			conflicts.Warnings = append(conflicts.Warnings, fmt.Sprintf("kind %s of apiVersion %s is not served by karmada apiserver", gvk.Kind, gvk.GroupVersion()))
+			continue
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameRoot && len(namespace) > 0 {
+			continue
+		}
+		options := metav1.ListOptions{Limit: listChunkSize}
+		for {
+			list, err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				if limit > 0 && found >= limit {
+					conflicts.Truncated = true
+					return conflicts, nil
+				}
+				resource := &list.Items[i]
+				if propagationpolicy.IsReservedNamespace(resource.GetNamespace()) {
+					continue
+				}
+				conflict, overlap := index.PropagationConflict(resource), index.OverrideOverlap(resource)
+				if conflict != nil {
+					conflicts.Propagation = append(conflicts.Propagation, *conflict)
+				}
+				if overlap != nil {
+					conflicts.Override = append(conflicts.Override, *overlap)
+				}
+				if conflict != nil || overlap != nil {
+					found++
+				}
+			}
+			if options.Continue = list.GetContinue(); len(options.Continue) == 0 {
+				break
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// selectedKinds returns the kinds referred to by the selectors of all policies.
+func (i *Index) selectedKinds() []schema.GroupVersionKind {
+	seen := make(map[schema.GroupVersionKind]bool)
+	kinds := make([]schema.GroupVersionKind, 0)
+	add := func(selectors []v1alpha1.ResourceSelector) {
+		for _, selector := range selectors {
+			gvk := schema.FromAPIVersionAndKind(selector.APIVersion, selector.Kind)
+			if !seen[gvk] {
+				seen[gvk] = true
+				kinds = append(kinds, gvk)
+			}
+		}
+	}
+	for _, policies := range i.propagationPolicies {
+		for _, p := range policies {
+			add(p.Spec.ResourceSelectors)
+		}
+	}
+	for _, p := range i.clusterPropagationPolicies {
+		add(p.Spec.ResourceSelectors)
+	}
+	for _, policies := range i.overridePolicies {
+		for _, p := range policies {
+			add(p.Spec.ResourceSelectors)
+		}
+	}
+	for _, p := range i.clusterOverridePolicies {
+		add(p.Spec.ResourceSelectors)
+	}
+	sort.Slice(kinds, func(a, b int) bool {
+		return kinds[a].String() < kinds[b].String()
+	})
+	return kinds
+}
+
+// PropagationConflict returns the propagation policies matching resource ordered by the precedence of karmada,
+// it's nil if fewer than two policies match. A PropagationPolicy in the namespace of resource always takes
+// precedence over ClusterPropagationPolicies, then the higher explicit priority wins, then the more specific
+// selector and at last the name sorting first.
+func (i *Index) PropagationConflict(resource *unstructured.Unstructured) *PropagationConflict {
+	type candidate struct {
+		PropagationCandidate
+		implicitPriority karmadautil.ImplicitPriority
+	}
+	candidates := make([]candidate, 0)
+	// karmada only matches PropagationPolicies against resources in their own namespace
+	if len(resource.GetNamespace()) > 0 {
+		for _, p := range i.propagationPolicies[resource.GetNamespace()] {
+			if priority := karmadautil.ResourceMatchSelectorsPriority(resource, p.Spec.ResourceSelectors...); priority > karmadautil.PriorityMisMatch {
+				candidates = append(candidates, candidate{
+					PropagationCandidate: PropagationCandidate{PolicyReference: propagationpolicy.PolicyReference{
+						Kind: propagationpolicy.KindPropagationPolicy, Namespace: p.Namespace, Name: p.Name, Priority: p.ExplicitPriority(),
+					}},
+					implicitPriority: priority,
+				})
+			}
+		}
+	}
+	for _, p := range i.clusterPropagationPolicies {
+		if priority := karmadautil.ResourceMatchSelectorsPriority(resource, p.Spec.ResourceSelectors...); priority > karmadautil.PriorityMisMatch {
+			candidates = append(candidates, candidate{
+				PropagationCandidate: PropagationCandidate{PolicyReference: propagationpolicy.PolicyReference{
+					Kind: propagationpolicy.KindClusterPropagationPolicy, Name: p.Name, Priority: p.ExplicitPriority(),
+				}},
+				implicitPriority: priority,
+			})
+		}
+	}
+	if len(candidates) < 2 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		ca, cb := candidates[a], candidates[b]
+		if ca.Kind != cb.Kind {
+			return ca.Kind == propagationpolicy.KindPropagationPolicy
+		}
+		if ca.Priority != cb.Priority {
+			return ca.Priority > cb.Priority
+		}
+		if ca.implicitPriority != cb.implicitPriority {
+			return ca.implicitPriority > cb.implicitPriority
+		}
+		return ca.Name < cb.Name
+	})
+	winner := candidates[0]
+	conflict := &PropagationConflict{
+		Resource:  toResourceReference(resource),
+		Policies:  make([]PropagationCandidate, 0, len(candidates)),
+		ClaimedBy: i.claimant(resource),
+	}
+	for n, c := range candidates {
+		c.Specificity = specificity(c.implicitPriority)
+		switch {
+		case n == 0:
+			c.Winner = true
+		case c.Kind != winner.Kind:
+			c.Reason = "a PropagationPolicy in the namespace of the resource takes precedence over ClusterPropagationPolicies"
+		case c.Priority != winner.Priority:
+			c.Reason = fmt.Sprintf("priority %d is lower than %d of %s", c.Priority, winner.Priority, winner.Name)
+		case c.implicitPriority != winner.implicitPriority:
+			c.Reason = fmt.Sprintf("selector matching by %s is less specific than by %s of %s", c.Specificity, specificity(winner.implicitPriority), winner.Name)
+		default:
+			c.Reason = fmt.Sprintf("same priority and specificity as %s, which sorts first by name", winner.Name)
+		}
+		conflict.Policies = append(conflict.Policies, c.PropagationCandidate)
+	}
+	return conflict
+}
+
+// OverrideOverlap returns the override policies matching resource in the order karmada applies them, it's nil
+// if fewer than two policies match. ClusterOverridePolicies are applied before OverridePolicies, policies of the
+// same kind from the least to the most specific selector and then by name.
+func (i *Index) OverrideOverlap(resource *unstructured.Unstructured) *OverrideOverlap {
+	type candidate struct {
+		OverrideCandidate
+		implicitPriority karmadautil.ImplicitPriority
+	}
+	collect := func(kind, namespace, name string, selectors []v1alpha1.ResourceSelector, candidates []candidate) []candidate {
+		if len(selectors) > 0 && !karmadautil.ResourceMatchSelectors(resource, selectors...) {
+			return candidates
+		}
+		return append(candidates, candidate{
+			OverrideCandidate: OverrideCandidate{PolicyReference: overridepolicy.PolicyReference{Kind: kind, Namespace: namespace, Name: name}},
+			implicitPriority:  implicitPriority(resource, selectors),
+		})
+	}
+	byPriority := func(candidates []candidate) {
+		sort.SliceStable(candidates, func(a, b int) bool {
+			if candidates[a].implicitPriority != candidates[b].implicitPriority {
+				return candidates[a].implicitPriority < candidates[b].implicitPriority
+			}
+			return candidates[a].Name < candidates[b].Name
+		})
+	}
+
+	clusterCandidates := make([]candidate, 0)
+	for _, p := range i.clusterOverridePolicies {
+		clusterCandidates = collect(overridepolicy.KindClusterOverridePolicy, "", p.Name, p.Spec.ResourceSelectors, clusterCandidates)
+	}
+	byPriority(clusterCandidates)
+	namespacedCandidates := make([]candidate, 0)
+	// karmada only applies OverridePolicies to resources in their own namespace
+	if len(resource.GetNamespace()) > 0 {
+		for _, p := range i.overridePolicies[resource.GetNamespace()] {
+			namespacedCandidates = collect(overridepolicy.KindOverridePolicy, p.Namespace, p.Name, p.Spec.ResourceSelectors, namespacedCandidates)
+		}
+	}
+	byPriority(namespacedCandidates)
+
+	candidates := append(clusterCandidates, namespacedCandidates...)
+	if len(candidates) < 2 {
+		return nil
+	}
+	overlap := &OverrideOverlap{
+		Resource: toResourceReference(resource),
+		Policies: make([]OverrideCandidate, 0, len(candidates)),
+	}
+	for n, c := range candidates {
+		c.Order = n + 1
+		c.Specificity = specificity(c.implicitPriority)
+		overlap.Policies = append(overlap.Policies, c.OverrideCandidate)
+	}
+	return overlap
+}
+
+// claimant returns the propagation policy claiming resource according to its annotations.
+func (i *Index) claimant(resource *unstructured.Unstructured) *propagationpolicy.PolicyReference {
+	annotations := resource.GetAnnotations()
+	if name := annotations[v1alpha1.PropagationPolicyNameAnnotation]; len(name) > 0 {
+		reference := &propagationpolicy.PolicyReference{
+			Kind:      propagationpolicy.KindPropagationPolicy,
+			Namespace: annotations[v1alpha1.PropagationPolicyNamespaceAnnotation],
+			Name:      name,
+		}
+		for _, p := range i.propagationPolicies[reference.Namespace] {
+			if p.Name == name {
+				reference.Priority = p.ExplicitPriority()
+			}
+		}
+		return reference
+	}
+	if name := annotations[v1alpha1.ClusterPropagationPolicyAnnotation]; len(name) > 0 {
+		reference := &propagationpolicy.PolicyReference{Kind: propagationpolicy.KindClusterPropagationPolicy, Name: name}
+		for _, p := range i.clusterPropagationPolicies {
+			if p.Name == name {
+				reference.Priority = p.ExplicitPriority()
+			}
+		}
+		return reference
+	}
+	return nil
+}
+
+// implicitPriority returns how specific the selectors of an override policy match resource, override policies are
+// applied from the least to the most specific so that the latter take effect. A policy without selectors matches
+// all resources.
+func implicitPriority(resource *unstructured.Unstructured, selectors []v1alpha1.ResourceSelector) karmadautil.ImplicitPriority {
+	if len(selectors) == 0 {
+		return karmadautil.PriorityMatchAll
+	}
+	return karmadautil.ResourceMatchSelectorsPriority(resource, selectors...)
+}
+
+func specificity(priority karmadautil.ImplicitPriority) string {
+	switch priority {
+	case karmadautil.PriorityMatchName:
+		return "name"
+	case karmadautil.PriorityMatchLabelSelector:
+		return "labelSelector"
+	case karmadautil.PriorityMatchAll:
+		return "all"
+	}
+	return "none"
+}
+
+func toResourceReference(resource *unstructured.Unstructured) ResourceReference {
+	return ResourceReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+	}
+}
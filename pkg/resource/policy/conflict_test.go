@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
+	"github.com/karmada-io/dashboard/pkg/testing/helper"
+)
+
+func byName(name string) v1alpha1.ResourceSelector {
+	return v1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment", Name: name}
+}
+
+func byLabel(key, value string) v1alpha1.ResourceSelector {
+	return v1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment",
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{key: value}}}
+}
+
+func analyzeConflicts(t *testing.T, limit int) *PolicyConflicts {
+	high := int32(10)
+	karmadaClient := karmadafake.NewSimpleClientset(
+		&v1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "by-label"},
+			Spec:       v1alpha1.PropagationSpec{ResourceSelectors: []v1alpha1.ResourceSelector{byLabel("app", "web")}},
+		},
+		&v1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "by-name"},
+			Spec:       v1alpha1.PropagationSpec{ResourceSelectors: []v1alpha1.ResourceSelector{byName("web")}},
+		},
+		&v1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prioritized"},
+			Spec:       v1alpha1.PropagationSpec{Priority: &high, ResourceSelectors: []v1alpha1.ResourceSelector{byLabel("tier", "frontend")}},
+		},
+		&v1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "elsewhere"},
+			Spec:       v1alpha1.PropagationSpec{ResourceSelectors: []v1alpha1.ResourceSelector{byLabel("app", "web")}},
+		},
+		&v1alpha1.ClusterPropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			Spec:       v1alpha1.PropagationSpec{Priority: &high, ResourceSelectors: []v1alpha1.ResourceSelector{byName("web")}},
+		},
+		&v1alpha1.ClusterOverridePolicy{ObjectMeta: metav1.ObjectMeta{Name: "all"}},
+		&v1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a-by-name"},
+			Spec:       v1alpha1.OverrideSpec{ResourceSelectors: []v1alpha1.ResourceSelector{byName("web")}},
+		},
+		&v1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "z-by-label"},
+			Spec:       v1alpha1.OverrideSpec{ResourceSelectors: []v1alpha1.ResourceSelector{byLabel("app", "web")}},
+		},
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		helper.NewDeployment("default", "web", map[string]string{"app": "web"}, map[string]string{
+			v1alpha1.PropagationPolicyNamespaceAnnotation: "default",
+			v1alpha1.PropagationPolicyNameAnnotation:      "by-label",
+		}),
+		helper.NewDeployment("default", "frontend", map[string]string{"app": "web", "tier": "frontend"}, nil),
+		helper.NewDeployment("default", "single", map[string]string{"app": "api"}, nil),
+		helper.NewDeployment("karmada-system", "web", map[string]string{"app": "web"}, nil),
+	)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	conflicts, err := AnalyzeConflicts(context.TODO(), karmadaClient, dynamicClient, mapper, "", limit)
+	if err != nil {
+		t.Fatalf("AnalyzeConflicts() error = %v", err)
+	}
+	return conflicts
+}
+
+func TestAnalyzePropagationConflicts(t *testing.T) {
+	conflicts := analyzeConflicts(t, 0)
+	propagation := make(map[string]PropagationConflict)
+	for _, conflict := range conflicts.Propagation {
+		propagation[conflict.Resource.Name] = conflict
+	}
+	cases := []struct {
+		resource    string
+		policies    []string
+		specificity string
+		claimedBy   string
+	}{
+		// the PropagationPolicy matching by name beats the one matching by label and the prioritized cluster policy
+		{"web", []string{"by-name", "by-label", "global"}, "name", "by-label"},
+		{"frontend", []string{"prioritized", "by-label"}, "labelSelector", ""},
+	}
+	if len(conflicts.Propagation) != len(cases) || conflicts.Truncated {
+		t.Fatalf("expected %d propagation conflicts, got %+v", len(cases), conflicts.Propagation)
+	}
+	for _, c := range cases {
+		conflict, ok := propagation[c.resource]
+		if !ok {
+			t.Errorf("expected %s to conflict", c.resource)
+			continue
+		}
+		names := make([]string, 0, len(conflict.Policies))
+		for _, p := range conflict.Policies {
+			names = append(names, p.Name)
+		}
+		if !reflect.DeepEqual(names, c.policies) || !conflict.Policies[0].Winner || conflict.Policies[0].Specificity != c.specificity {
+			t.Errorf("policies of %s == %+v, expected %v won by %s", c.resource, conflict.Policies, c.policies, c.specificity)
+		}
+		if claimedBy := conflict.ClaimedBy; (claimedBy == nil) != (len(c.claimedBy) == 0) || (claimedBy != nil && claimedBy.Name != c.claimedBy) {
+			t.Errorf("%s is claimed by %+v, expected %q", c.resource, claimedBy, c.claimedBy)
+		}
+	}
+	if web := propagation["web"]; web.Policies[2].Kind != propagationpolicy.KindClusterPropagationPolicy {
+		t.Errorf("expected global to be a ClusterPropagationPolicy, got %+v", web.Policies[2])
+	}
+}
+
+func TestAnalyzeOverrideOverlaps(t *testing.T) {
+	conflicts := analyzeConflicts(t, 0)
+	overlaps := make(map[string]OverrideOverlap)
+	for _, overlap := range conflicts.Override {
+		overlaps[overlap.Resource.Name] = overlap
+	}
+	cases := []struct {
+		resource string
+		order    []string
+	}{
+		{"web", []string{"all", "z-by-label", "a-by-name"}},
+		{"frontend", []string{"all", "z-by-label"}},
+		// single is overridden by the ClusterOverridePolicy only
+		{"single", nil},
+	}
+	for _, c := range cases {
+		overlap, ok := overlaps[c.resource]
+		if ok != (len(c.order) > 0) {
+			t.Errorf("overlap of %s == %+v, expected %v", c.resource, overlap, c.order)
+			continue
+		}
+		for n, name := range c.order {
+			if overlap.Policies[n].Name != name || overlap.Policies[n].Order != n+1 {
+				t.Errorf("expected %s to be applied to %s at %d, got %+v", name, c.resource, n+1, overlap.Policies[n])
+			}
+		}
+		if ok && overlap.Policies[0].Kind != overridepolicy.KindClusterOverridePolicy {
+			t.Errorf("expected the ClusterOverridePolicy to be applied first to %s, got %+v", c.resource, overlap.Policies[0])
+		}
+	}
+}
+
+func TestAnalyzeConflictsLimit(t *testing.T) {
+	// frontend and web both conflict and overlap, deployments are listed in the order of namespace and name
+	cases := []struct {
+		limit     int
+		found     int
+		truncated bool
+	}{
+		{limit: 0, found: 2},
+		{limit: 1, found: 1, truncated: true},
+		// the analysis stops before karmada-system/web, which may have conflicted
+		{limit: 2, found: 2, truncated: true},
+		{limit: 3, found: 2},
+	}
+	for _, c := range cases {
+		conflicts := analyzeConflicts(t, c.limit)
+		found := len(conflicts.Propagation)
+		if found != c.found || len(conflicts.Override) != c.found || conflicts.Truncated != c.truncated {
+			t.Errorf("AnalyzeConflicts() with limit %d found %d resources, truncated %v, expected %d, %v",
+				c.limit, found, conflicts.Truncated, c.found, c.truncated)
+		}
+	}
+}
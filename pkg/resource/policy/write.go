@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// permanentIDLabels are assigned by karmada to identify policies, they're kept when the labels of a policy are replaced.
+var permanentIDLabels = []string{v1alpha1.PropagationPolicyPermanentIDLabel, v1alpha1.ClusterPropagationPolicyPermanentIDLabel}
+
+// Object is a PropagationPolicy, ClusterPropagationPolicy, OverridePolicy or ClusterOverridePolicy.
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// Client is the part of the typed client of a policy kind the writes go through, the clients of namespaced
+// policies are bound to a namespace.
+type Client[T Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Update(ctx context.Context, policy T, opts metav1.UpdateOptions) (T, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// Update replaces the spec of the policy named after policy by setSpec. The labels and annotations are replaced
+// as well if policy sets them, except for the permanent id karmada assigned, and other metadata is kept.
+// The resourceVersion of policy is required, the update fails with a conflict if the policy was changed since.
+// Nothing is persisted if dryRun is set.
+func Update[T Object](ctx context.Context, client Client[T], resource schema.GroupResource, policy T,
+	setSpec func(current, policy T), dryRun []string) (T, error) {
+	var current T
+	resourceVersion := policy.GetResourceVersion()
+	if len(resourceVersion) == 0 {
+		return current, k8serrors.NewBadRequest(fmt.Sprintf("resourceVersion of %s %s is required", resource.String(), policy.GetName()))
+	}
+	current, err := client.Get(ctx, policy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return current, err
+	}
+	if resourceVersion != current.GetResourceVersion() {
+		return current, k8serrors.NewConflict(resource, policy.GetName(),
+			fmt.Errorf("resourceVersion %s is outdated, the latest is %s", resourceVersion, current.GetResourceVersion()))
+	}
+
+	updated := current.DeepCopyObject().(T)
+	if labels := policy.GetLabels(); labels != nil {
+		replaced := make(map[string]string, len(labels))
+		for key, value := range labels {
+			replaced[key] = value
+		}
+		for _, key := range permanentIDLabels {
+			if value, ok := current.GetLabels()[key]; ok {
+				replaced[key] = value
+			}
+		}
+		updated.SetLabels(replaced)
+	}
+	if annotations := policy.GetAnnotations(); annotations != nil {
+		updated.SetAnnotations(annotations)
+	}
+	setSpec(updated, policy)
+	// the apiserver rejects the update as well if the policy is changed after it was read above
+	return client.Update(ctx, updated, metav1.UpdateOptions{DryRun: dryRun})
+}
+
+// Patch applies the JSON merge patch to the policy. The patch must contain metadata.resourceVersion, so that
+// it fails with a conflict if the policy was changed since, and must not change the permanent id karmada
+// assigned. Nothing is persisted if dryRun is set.
+func Patch[T Object](ctx context.Context, client Client[T], resource schema.GroupResource, name string, patch []byte,
+	dryRun []string) (T, error) {
+	var patched T
+	if err := validatePatch(resource, name, patch); err != nil {
+		return patched, err
+	}
+	return client.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{DryRun: dryRun})
+}
+
+// validatePatch checks that the JSON merge patch is conditional and leaves the permanent id labels alone.
+func validatePatch(resource schema.GroupResource, name string, patch []byte) error {
+	var metadata struct {
+		Metadata struct {
+			ResourceVersion string                     `json:"resourceVersion"`
+			Labels          map[string]json.RawMessage `json:"labels"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(patch, &metadata); err != nil {
+		return k8serrors.NewBadRequest(fmt.Sprintf("invalid JSON merge patch: %v", err))
+	}
+	if len(metadata.Metadata.ResourceVersion) == 0 {
+		return k8serrors.NewBadRequest(fmt.Sprintf("metadata.resourceVersion of %s %s is required in the patch", resource.String(), name))
+	}
+	for _, key := range permanentIDLabels {
+		if _, ok := metadata.Metadata.Labels[key]; ok {
+			return k8serrors.NewBadRequest(fmt.Sprintf("label %s of %s %s is assigned by karmada and can not be patched", key, resource.String(), name))
+		}
+	}
+	return nil
+}
+
+// Delete deletes the policy, the deletion is conditional if resourceVersion is not empty.
+func Delete[T Object](ctx context.Context, client Client[T], name, resourceVersion string) error {
+	options := metav1.DeleteOptions{}
+	if len(resourceVersion) > 0 {
+		options.Preconditions = &metav1.Preconditions{ResourceVersion: &resourceVersion}
+	}
+	return client.Delete(ctx, name, options)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdate(t *testing.T) {
+	permanentID := map[string]string{v1alpha1.PropagationPolicyPermanentIDLabel: "id"}
+	tests := []struct {
+		name            string
+		resourceVersion string
+		labels          map[string]string
+		annotations     map[string]string
+		wantErr         func(error) bool
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "outdated resourceVersion",
+			resourceVersion: "1",
+			wantErr:         k8serrors.IsConflict,
+		},
+		{
+			name:            "latest resourceVersion keeps metadata not submitted",
+			resourceVersion: "2",
+			wantLabels:      map[string]string{v1alpha1.PropagationPolicyPermanentIDLabel: "id", "team": "web"},
+			wantAnnotations: map[string]string{"note": "keep"},
+		},
+		{
+			name:    "no resourceVersion",
+			wantErr: k8serrors.IsBadRequest,
+		},
+		{
+			name:            "submitted labels and annotations replace the stored ones except for the permanent id",
+			resourceVersion: "2",
+			labels:          map[string]string{"tier": "frontend"},
+			annotations:     map[string]string{},
+			wantLabels:      map[string]string{v1alpha1.PropagationPolicyPermanentIDLabel: "id", "tier": "frontend"},
+			wantAnnotations: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := map[string]string{"team": "web"}
+			for key, value := range permanentID {
+				labels[key] = value
+			}
+			client := karmadafake.NewSimpleClientset(&v1alpha1.PropagationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					Name:            "web",
+					ResourceVersion: "2",
+					Labels:          labels,
+					Annotations:     map[string]string{"note": "keep"},
+				},
+				Spec: v1alpha1.PropagationSpec{SchedulerName: "default-scheduler"},
+			})
+			policy := &v1alpha1.PropagationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					Name:            "web",
+					ResourceVersion: tt.resourceVersion,
+					Labels:          tt.labels,
+					Annotations:     tt.annotations,
+				},
+				Spec: v1alpha1.PropagationSpec{SchedulerName: "custom-scheduler"},
+			}
+
+			updated, err := Update(context.TODO(), client.PolicyV1alpha1().PropagationPolicies("default"),
+				v1alpha1.Resource(v1alpha1.ResourcePluralPropagationPolicy), policy,
+				func(current, policy *v1alpha1.PropagationPolicy) {
+					current.Spec = policy.Spec
+				}, nil)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if updated.Spec.SchedulerName != "custom-scheduler" {
+				t.Errorf("expected the spec to be replaced, got %+v", updated.Spec)
+			}
+			if !reflect.DeepEqual(updated.Labels, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", updated.Labels, tt.wantLabels)
+			}
+			if len(updated.Annotations) != len(tt.wantAnnotations) || (len(tt.wantAnnotations) > 0 && !reflect.DeepEqual(updated.Annotations, tt.wantAnnotations)) {
+				t.Errorf("annotations = %v, want %v", updated.Annotations, tt.wantAnnotations)
+			}
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr func(error) bool
+	}{
+		{
+			name:  "conditional patch",
+			patch: `{"metadata":{"resourceVersion":"2","labels":{"team":"web"}},"spec":{"schedulerName":"custom-scheduler"}}`,
+		},
+		{
+			name:    "no resourceVersion",
+			patch:   `{"spec":{"schedulerName":"custom-scheduler"}}`,
+			wantErr: k8serrors.IsBadRequest,
+		},
+		{
+			name:    "permanent id label",
+			patch:   `{"metadata":{"resourceVersion":"2","labels":{"` + v1alpha1.ClusterPropagationPolicyPermanentIDLabel + `":null}}}`,
+			wantErr: k8serrors.IsBadRequest,
+		},
+		{
+			name:    "invalid patch",
+			patch:   `spec: {}`,
+			wantErr: k8serrors.IsBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := karmadafake.NewSimpleClientset(&v1alpha1.ClusterPropagationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "global",
+					ResourceVersion: "2",
+					Labels:          map[string]string{v1alpha1.ClusterPropagationPolicyPermanentIDLabel: "id"},
+				},
+			})
+			patched, err := Patch(context.TODO(), client.PolicyV1alpha1().ClusterPropagationPolicies(),
+				v1alpha1.Resource(v1alpha1.ResourcePluralClusterPropagationPolicy), "global", []byte(tt.patch), nil)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Patch() error = %v", err)
+			}
+			if patched.Spec.SchedulerName != "custom-scheduler" || patched.Labels[v1alpha1.ClusterPropagationPolicyPermanentIDLabel] != "id" {
+				t.Errorf("unexpected patched policy %+v", patched.ObjectMeta)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name            string
+		resourceVersion string
+	}{
+		{name: "unconditional"},
+		{name: "latest resourceVersion", resourceVersion: "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := karmadafake.NewSimpleClientset(&v1alpha1.ClusterOverridePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "global", ResourceVersion: "2"},
+			})
+			policies := client.PolicyV1alpha1().ClusterOverridePolicies()
+			if err := Delete(context.TODO(), policies, "global", tt.resourceVersion); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := policies.Get(context.TODO(), "global", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+				t.Errorf("expected the policy to be deleted, got %v", err)
+			}
+		})
+	}
+}
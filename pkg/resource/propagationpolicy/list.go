@@ -24,7 +24,6 @@ import (
 
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	"k8s.io/client-go/kubernetes"
 
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
@@ -56,18 +55,18 @@ type PropagationPolicy struct {
 }
 
 // GetPropagationPolicyList returns a list of all propagations in the karmada control-plance.
-func GetPropagationPolicyList(client karmadaclientset.Interface, k8sClient kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*PropagationPolicyList, error) {
+func GetPropagationPolicyList(karmadaClient karmadaclientset.Interface, verber client.ResourceVerber, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*PropagationPolicyList, error) {
 	log.Println("Getting list of namespaces")
-	propagationpolicies, err := client.PolicyV1alpha1().PropagationPolicies(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+	propagationpolicies, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toPropagationPolicyList(k8sClient, propagationpolicies.Items, nonCriticalErrors, dsQuery), nil
+	return toPropagationPolicyList(verber, propagationpolicies.Items, nonCriticalErrors, dsQuery), nil
 }
 
-func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alpha1.PropagationPolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *PropagationPolicyList {
+func toPropagationPolicyList(verberClient client.ResourceVerber, propagationpolicies []v1alpha1.PropagationPolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *PropagationPolicyList {
 	propagationpolicyList := &PropagationPolicyList{
 		PropagationPolicys: make([]PropagationPolicy, 0),
 		ListMeta:           types.ListMeta{TotalItems: len(propagationpolicies)},
@@ -77,10 +76,6 @@ func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alp
 	propagationpolicyList.ListMeta = types.ListMeta{TotalItems: filteredTotal}
 	propagationpolicyList.Errors = nonCriticalErrors
 
-	verberClient, err := client.VerberClient(nil)
-	if err != nil {
-		panic(err)
-	}
 	for _, propagationpolicy := range propagationpolicies {
 		relatedResources := make([]string, 0)
 		for _, rs := range propagationpolicy.Spec.ResourceSelectors {
@@ -88,7 +83,7 @@ func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alp
 			if getErr != nil {
 				continue
 			}
-			if errors.IsNotFound(err) || getRes == nil {
+			if getRes == nil {
 				continue
 			}
 			relatedResources = append(relatedResources, fmt.Sprintf("%s/%s", rs.Namespace, rs.Name))
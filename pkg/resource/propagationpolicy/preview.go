@@ -0,0 +1,453 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// KindPropagationPolicy is the kind of namespaced propagation policies.
+	KindPropagationPolicy = "PropagationPolicy"
+	// KindClusterPropagationPolicy is the kind of cluster scoped propagation policies.
+	KindClusterPropagationPolicy = "ClusterPropagationPolicy"
+)
+
+// PreviewPolicy is a propagation policy which may not have been created yet, Namespace is empty for
+// a ClusterPropagationPolicy.
+type PreviewPolicy struct {
+	Namespace string
+	Name      string
+	Spec      v1alpha1.PropagationSpec
+}
+
+// PolicyReference refers to a propagation policy which claims resources.
+type PolicyReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Priority is the explicit priority of the policy, it's compared when a policy preempts another.
+	Priority int32 `json:"priority"`
+}
+
+// PreviewResource is a resource selected by the ResourceSelectors of the previewed policy.
+type PreviewResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// ClaimedBy is the policy currently claiming the resource, it's nil if the resource isn't propagated.
+	ClaimedBy *PolicyReference `json:"claimedBy,omitempty"`
+	// WouldClaim tells whether the previewed policy would propagate the resource once it's saved.
+	WouldClaim bool `json:"wouldClaim"`
+	// Reason explains why the resource would or would not be claimed when it's already claimed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PreviewCluster is the result of evaluating the placement of the previewed policy against a cluster.
+type PreviewCluster struct {
+	Name     string `json:"name"`
+	Eligible bool   `json:"eligible"`
+	// Reasons tell why the cluster is excluded, it's empty if the cluster is eligible.
+	Reasons []string `json:"reasons,omitempty"`
+	// MissingAPIs are the apiVersion and kind of the selected resources the cluster doesn't serve, resources of
+	// these kinds are not scheduled to it. The cluster is excluded if it serves none of them.
+	MissingAPIs []string `json:"missingAPIs,omitempty"`
+}
+
+// PreviewSpreadConstraint is a spread constraint of the previewed policy with the groups the eligible
+// clusters fall into.
+type PreviewSpreadConstraint struct {
+	v1alpha1.SpreadConstraint `json:",inline"`
+	Groups                    []string `json:"groups"`
+	// Satisfied is false if there are fewer groups than MinGroups.
+	Satisfied bool   `json:"satisfied"`
+	Message   string `json:"message,omitempty"`
+}
+
+// PropagationPreview tells which resources a propagation policy would claim and which clusters they could
+// be scheduled to, it's evaluated against the current state of karmada control plane.
+type PropagationPreview struct {
+	Resources []PreviewResource `json:"resources"`
+	Clusters  []PreviewCluster  `json:"clusters"`
+	// AffinityName is the term of ClusterAffinities the eligible clusters are selected by, the scheduler
+	// tries the terms in order until one of them has eligible clusters.
+	AffinityName      string                    `json:"affinityName,omitempty"`
+	SpreadConstraints []PreviewSpreadConstraint `json:"spreadConstraints"`
+	// Warnings are problems of the policy which don't prevent the preview, e.g. a selector of an unknown kind.
+	Warnings []string `json:"warnings"`
+}
+
+// Preview evaluates the ResourceSelectors of policy against the resources in karmada apiserver and its
+// Placement against the current clusters. Resources are looked up with dynamicClient, mapper resolves the
+// apiVersion and kind of the selectors.
+func Preview(ctx context.Context, karmadaClient karmadaclientset.Interface, dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper, policy *PreviewPolicy) (*PropagationPreview, error) {
+	preview := &PropagationPreview{Warnings: make([]string, 0)}
+	resources, err := previewResources(ctx, karmadaClient, dynamicClient, mapper, policy, preview)
+	if err != nil {
+		return nil, err
+	}
+	preview.Resources = resources
+
+	clusters, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	previewPlacement(clusters.Items, policy.Spec, selectedKinds(resources), preview)
+	return preview, nil
+}
+
+func previewResources(ctx context.Context, karmadaClient karmadaclientset.Interface, dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper, policy *PreviewPolicy, preview *PropagationPreview) ([]PreviewResource, error) {
+	claimants := make(map[string]*PolicyReference)
+	seen := make(map[string]bool)
+	resources := make([]PreviewResource, 0)
+	for _, selector := range policy.Spec.ResourceSelectors {
+		gvk := schema.FromAPIVersionAndKind(selector.APIVersion, selector.Kind)
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("kind %s of apiVersion %s is not served by karmada apiserver", selector.Kind, selector.APIVersion))
+			continue
+		}
+		namespace := selector.Namespace
+		if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+			if len(policy.Namespace) > 0 {
+				preview.Warnings = append(preview.Warnings, fmt.Sprintf("a PropagationPolicy can not select the cluster scoped kind %s", selector.Kind))
+				continue
+			}
+			namespace = ""
+		} else if len(policy.Namespace) > 0 {
+			// a PropagationPolicy only selects resources in its own namespace
+			namespace = policy.Namespace
+		}
+
+		list, err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			object := &list.Items[i]
+			key := strings.Join([]string{object.GetAPIVersion(), object.GetKind(), object.GetNamespace(), object.GetName()}, "/")
+			if seen[key] || IsReservedNamespace(object.GetNamespace()) || !karmadautil.ResourceMatches(object, selector) {
+				continue
+			}
+			seen[key] = true
+			claimant, err := getClaimant(ctx, karmadaClient, object, claimants)
+			if err != nil {
+				return nil, err
+			}
+			wouldClaim, reason := wouldClaim(policy, claimant)
+			resources = append(resources, PreviewResource{
+				APIVersion: object.GetAPIVersion(),
+				Kind:       object.GetKind(),
+				Namespace:  object.GetNamespace(),
+				Name:       object.GetName(),
+				ClaimedBy:  claimant,
+				WouldClaim: wouldClaim,
+				Reason:     reason,
+			})
+		}
+	}
+	return resources, nil
+}
+
+// selectedKinds returns the distinct kinds of resources in the order they are first seen.
+func selectedKinds(resources []PreviewResource) []metav1.TypeMeta {
+	kinds := make([]metav1.TypeMeta, 0)
+	seen := make(map[metav1.TypeMeta]bool)
+	for _, resource := range resources {
+		kind := metav1.TypeMeta{APIVersion: resource.APIVersion, Kind: resource.Kind}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// IsReservedNamespace reports whether resources in the namespace are ignored by karmada, they are never
+// propagated regardless of the policies selecting them.
+func IsReservedNamespace(namespace string) bool {
+	return namespace == "karmada-system" || namespace == "karmada-cluster" ||
+		strings.HasPrefix(namespace, "karmada-es-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// getClaimant returns the policy claiming the object, the policies are cached in claimants by kind, namespace
+// and name since most resources are claimed by a handful of policies.
+func getClaimant(ctx context.Context, karmadaClient karmadaclientset.Interface, object *unstructured.Unstructured,
+	claimants map[string]*PolicyReference) (*PolicyReference, error) {
+	annotations := object.GetAnnotations()
+	reference := &PolicyReference{}
+	if name := annotations[v1alpha1.PropagationPolicyNameAnnotation]; len(name) > 0 {
+		reference.Kind = KindPropagationPolicy
+		reference.Namespace = annotations[v1alpha1.PropagationPolicyNamespaceAnnotation]
+		reference.Name = name
+	} else if name = annotations[v1alpha1.ClusterPropagationPolicyAnnotation]; len(name) > 0 {
+		reference.Kind = KindClusterPropagationPolicy
+		reference.Name = name
+	} else {
+		return nil, nil
+	}
+
+	key := reference.Kind + "/" + reference.Namespace + "/" + reference.Name
+	if cached, ok := claimants[key]; ok {
+		return cached, nil
+	}
+	var priority *int32
+	if reference.Kind == KindPropagationPolicy {
+		claimant, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(reference.Namespace).Get(ctx, reference.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			priority = claimant.Spec.Priority
+		}
+	} else {
+		claimant, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, reference.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			priority = claimant.Spec.Priority
+		}
+	}
+	if priority != nil {
+		reference.Priority = *priority
+	}
+	claimants[key] = reference
+	return reference, nil
+}
+
+// wouldClaim tells whether policy would claim a resource currently claimed by claimant, it follows the
+// preemption rules of karmada: a PropagationPolicy preempts any ClusterPropagationPolicy, otherwise a policy
+// only preempts a policy of the same kind with a lower priority, and only if its preemption is Always.
+func wouldClaim(policy *PreviewPolicy, claimant *PolicyReference) (bool, string) {
+	if claimant == nil {
+		return true, ""
+	}
+	kind := KindClusterPropagationPolicy
+	if len(policy.Namespace) > 0 {
+		kind = KindPropagationPolicy
+	}
+	if claimant.Kind == kind && claimant.Namespace == policy.Namespace && claimant.Name == policy.Name {
+		return true, "already claimed by this policy"
+	}
+	if policy.Spec.Preemption != v1alpha1.PreemptAlways {
+		return false, fmt.Sprintf("claimed by %s %s and preemption is disabled", claimant.Kind, claimantName(claimant))
+	}
+	if kind == KindPropagationPolicy && claimant.Kind == KindClusterPropagationPolicy {
+		return true, fmt.Sprintf("preempts %s %s", claimant.Kind, claimantName(claimant))
+	}
+	if kind == KindClusterPropagationPolicy && claimant.Kind == KindPropagationPolicy {
+		return false, fmt.Sprintf("claimed by %s %s, a ClusterPropagationPolicy can not preempt a PropagationPolicy", claimant.Kind, claimantName(claimant))
+	}
+	var priority int32
+	if policy.Spec.Priority != nil {
+		priority = *policy.Spec.Priority
+	}
+	if priority > claimant.Priority {
+		return true, fmt.Sprintf("preempts %s %s of lower priority %d", claimant.Kind, claimantName(claimant), claimant.Priority)
+	}
+	return false, fmt.Sprintf("claimed by %s %s of priority %d which is not lower", claimant.Kind, claimantName(claimant), claimant.Priority)
+}
+
+func claimantName(claimant *PolicyReference) string {
+	if len(claimant.Namespace) > 0 {
+		return claimant.Namespace + "/" + claimant.Name
+	}
+	return claimant.Name
+}
+
+// previewPlacement evaluates the filters of karmada scheduler which depend on the policy: cluster affinity,
+// taint toleration, api enablement and spread constraint. Filters depending on the workload, e.g. the
+// resources it requests, are not evaluated.
+func previewPlacement(clusters []clusterv1alpha1.Cluster, spec v1alpha1.PropagationSpec, kinds []metav1.TypeMeta,
+	preview *PropagationPreview) {
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	// the reasons which don't depend on the affinity term
+	reasons := make([][]string, len(clusters))
+	missingAPIs := make([][]string, len(clusters))
+	for i := range clusters {
+		reasons[i] = filterCluster(&clusters[i], spec)
+		for _, kind := range kinds {
+			if !isAPIEnabled(&clusters[i], kind.APIVersion, kind.Kind) {
+				missingAPIs[i] = append(missingAPIs[i], kind.APIVersion+"/"+kind.Kind)
+			}
+		}
+		if len(kinds) > 0 && len(missingAPIs[i]) == len(kinds) {
+			reasons[i] = append(reasons[i], "none of the apis of the selected resources is enabled")
+		}
+	}
+
+	// the scheduler tries the terms of ClusterAffinities in order, the first one with eligible clusters wins
+	var affinities []v1alpha1.ClusterAffinityTerm
+	if len(spec.Placement.ClusterAffinities) > 0 {
+		affinities = spec.Placement.ClusterAffinities
+	} else if spec.Placement.ClusterAffinity != nil {
+		affinities = []v1alpha1.ClusterAffinityTerm{{ClusterAffinity: *spec.Placement.ClusterAffinity}}
+	} else {
+		affinities = []v1alpha1.ClusterAffinityTerm{{}}
+	}
+	var results []PreviewCluster
+	for _, affinity := range affinities {
+		results = make([]PreviewCluster, 0, len(clusters))
+		eligible := 0
+		for i := range clusters {
+			result := PreviewCluster{Name: clusters[i].Name, Reasons: reasons[i], MissingAPIs: missingAPIs[i]}
+			if !karmadautil.ClusterMatches(&clusters[i], affinity.ClusterAffinity) {
+				reason := "not selected by cluster affinity"
+				if len(affinity.AffinityName) > 0 {
+					reason = fmt.Sprintf("not selected by cluster affinity %s", affinity.AffinityName)
+				}
+				result.Reasons = append([]string{reason}, result.Reasons...)
+			}
+			result.Eligible = len(result.Reasons) == 0
+			if result.Eligible {
+				eligible++
+			}
+			results = append(results, result)
+		}
+		preview.AffinityName = affinity.AffinityName
+		if eligible > 0 {
+			break
+		}
+	}
+	preview.Clusters = results
+	preview.SpreadConstraints = previewSpreadConstraints(clusters, results, spec.Placement.SpreadConstraints)
+}
+
+// filterCluster returns why the cluster can not be scheduled to apart from the cluster affinity and the apis
+// it enables.
+func filterCluster(cluster *clusterv1alpha1.Cluster, spec v1alpha1.PropagationSpec) []string {
+	var reasons []string
+	for i := range cluster.Spec.Taints {
+		taint := &cluster.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		if !toleratesTaint(spec.Placement.ClusterTolerations, taint) {
+			reasons = append(reasons, fmt.Sprintf("taint %s is not tolerated", taint.ToString()))
+		}
+	}
+	for _, constraint := range spec.Placement.SpreadConstraints {
+		if len(constraint.SpreadByField) > 0 && constraint.SpreadByField != v1alpha1.SpreadByFieldCluster &&
+			len(spreadGroups(cluster, constraint)) == 0 {
+			reasons = append(reasons, fmt.Sprintf("%s is not set, it's required to spread by it", constraint.SpreadByField))
+		}
+	}
+	return reasons
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAPIEnabled(cluster *clusterv1alpha1.Cluster, apiVersion, kind string) bool {
+	for _, enablement := range cluster.Status.APIEnablements {
+		if enablement.GroupVersion != apiVersion {
+			continue
+		}
+		for _, resource := range enablement.Resources {
+			if resource.Kind == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spreadGroups returns the groups the cluster falls into by the spread constraint.
+func spreadGroups(cluster *clusterv1alpha1.Cluster, constraint v1alpha1.SpreadConstraint) []string {
+	if len(constraint.SpreadByLabel) > 0 {
+		if value, ok := cluster.Labels[constraint.SpreadByLabel]; ok {
+			return []string{value}
+		}
+		return nil
+	}
+	var value string
+	switch constraint.SpreadByField {
+	case v1alpha1.SpreadByFieldProvider:
+		value = cluster.Spec.Provider
+	case v1alpha1.SpreadByFieldRegion:
+		value = cluster.Spec.Region
+	case v1alpha1.SpreadByFieldZone:
+		return cluster.Spec.Zones
+	default:
+		value = cluster.Name
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	return []string{value}
+}
+
+func previewSpreadConstraints(clusters []clusterv1alpha1.Cluster, results []PreviewCluster,
+	constraints []v1alpha1.SpreadConstraint) []PreviewSpreadConstraint {
+	previews := make([]PreviewSpreadConstraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		groups := make(map[string]bool)
+		for i := range clusters {
+			if !results[i].Eligible {
+				continue
+			}
+			for _, group := range spreadGroups(&clusters[i], constraint) {
+				groups[group] = true
+			}
+		}
+		preview := PreviewSpreadConstraint{SpreadConstraint: constraint, Groups: make([]string, 0, len(groups))}
+		for group := range groups {
+			preview.Groups = append(preview.Groups, group)
+		}
+		sort.Strings(preview.Groups)
+
+		minGroups := constraint.MinGroups
+		if minGroups == 0 {
+			minGroups = 1
+		}
+		preview.Satisfied = len(preview.Groups) >= minGroups
+		if !preview.Satisfied {
+			preview.Message = fmt.Sprintf("%d group(s) of eligible clusters, at least %d required", len(preview.Groups), minGroups)
+		} else if constraint.MaxGroups > 0 && len(preview.Groups) > constraint.MaxGroups {
+			preview.Message = fmt.Sprintf("at most %d of %d groups will be selected", constraint.MaxGroups, len(preview.Groups))
+		}
+		previews = append(previews, preview)
+	}
+	return previews
+}
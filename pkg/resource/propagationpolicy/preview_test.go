@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/karmada-io/dashboard/pkg/testing/helper"
+)
+
+func TestPreview(t *testing.T) {
+	priority := int32(10)
+	karmadaClient := karmadafake.NewSimpleClientset(
+		&v1alpha1.PropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+			Spec:       v1alpha1.PropagationSpec{Priority: &priority},
+		},
+		&v1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "global"}},
+		helper.NewCluster("member1", "us-east", map[string]string{"env": "prod"}),
+		helper.NewCluster("member2", "eu-west", map[string]string{"env": "prod"},
+			corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectNoSchedule}),
+		helper.NewCluster("member3", "", map[string]string{"env": "prod"}),
+		helper.NewCluster("member4", "us-west", map[string]string{"env": "test"}),
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		helper.NewDeployment("default", "free", map[string]string{"app": "web"}, nil),
+		helper.NewDeployment("default", "taken", map[string]string{"app": "web"}, map[string]string{
+			v1alpha1.PropagationPolicyNamespaceAnnotation: "default",
+			v1alpha1.PropagationPolicyNameAnnotation:      "existing",
+		}),
+		helper.NewDeployment("default", "global", map[string]string{"app": "web"}, map[string]string{
+			v1alpha1.ClusterPropagationPolicyAnnotation: "global",
+		}),
+		helper.NewDeployment("default", "other", map[string]string{"app": "db"}, nil),
+		helper.NewDeployment("prod", "free", map[string]string{"app": "web"}, nil),
+	)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	policy := &PreviewPolicy{
+		Namespace: "default",
+		Name:      "web",
+		Spec: v1alpha1.PropagationSpec{
+			ResourceSelectors: []v1alpha1.ResourceSelector{
+				{APIVersion: "apps/v1", Kind: "Deployment", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+				{APIVersion: "example.io/v1", Kind: "Unknown"},
+			},
+			Preemption: v1alpha1.PreemptAlways,
+			Placement: v1alpha1.Placement{
+				ClusterAffinity:   &v1alpha1.ClusterAffinity{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				SpreadConstraints: []v1alpha1.SpreadConstraint{{SpreadByField: v1alpha1.SpreadByFieldRegion, MinGroups: 2}},
+			},
+		},
+	}
+	preview, err := Preview(context.TODO(), karmadaClient, dynamicClient, mapper, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(preview.Warnings) != 1 {
+		t.Errorf("expected a warning of the unknown kind, got %v", preview.Warnings)
+	}
+	claims := make(map[string]PreviewResource)
+	for _, resource := range preview.Resources {
+		claims[resource.Namespace+"/"+resource.Name] = resource
+	}
+	resourceCases := []struct {
+		resource   string
+		wouldClaim bool
+		// claimedBy is the kind of policy which claims the resource, empty if it's unclaimed
+		claimedBy string
+	}{
+		{"default/free", true, ""},
+		// the existing policy has a higher priority
+		{"default/taken", false, KindPropagationPolicy},
+		// a PropagationPolicy preempts a ClusterPropagationPolicy
+		{"default/global", true, KindClusterPropagationPolicy},
+	}
+	if len(claims) != len(resourceCases) {
+		t.Fatalf("expected the web deployments of namespace default, got %+v", preview.Resources)
+	}
+	for _, c := range resourceCases {
+		resource := claims[c.resource]
+		if resource.WouldClaim != c.wouldClaim || (resource.ClaimedBy == nil) != (len(c.claimedBy) == 0) ||
+			(resource.ClaimedBy != nil && resource.ClaimedBy.Kind != c.claimedBy) {
+			t.Errorf("preview of %s == %+v, expected wouldClaim %v claimed by %q", c.resource, resource, c.wouldClaim, c.claimedBy)
+		}
+	}
+
+	eligible := make(map[string]PreviewCluster)
+	for _, cluster := range preview.Clusters {
+		eligible[cluster.Name] = cluster
+	}
+	clusterCases := []struct {
+		cluster  string
+		eligible bool
+	}{
+		{"member1", true},
+		// tainted
+		{"member2", false},
+		// without region
+		{"member3", false},
+		// not matched by the cluster affinity
+		{"member4", false},
+	}
+	for _, c := range clusterCases {
+		cluster := eligible[c.cluster]
+		if cluster.Eligible != c.eligible || (!c.eligible && len(cluster.Reasons) != 1) {
+			t.Errorf("preview of %s == %+v, expected eligible %v or excluded for one reason", c.cluster, cluster, c.eligible)
+		}
+	}
+	if len(preview.SpreadConstraints) != 1 || preview.SpreadConstraints[0].Satisfied || len(preview.SpreadConstraints[0].Groups) != 1 {
+		t.Errorf("expected unsatisfied spread constraint, got %+v", preview.SpreadConstraints)
+	}
+}
+
+func TestPreviewClusterAffinities(t *testing.T) {
+	clusters := []clusterv1alpha1.Cluster{
+		*helper.NewCluster("member1", "us-east", map[string]string{"env": "prod"},
+			corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectNoExecute}),
+		*helper.NewCluster("member2", "eu-west", map[string]string{"env": "backup"}),
+	}
+	spec := v1alpha1.PropagationSpec{
+		Placement: v1alpha1.Placement{
+			ClusterAffinities: []v1alpha1.ClusterAffinityTerm{
+				{AffinityName: "primary", ClusterAffinity: v1alpha1.ClusterAffinity{ClusterNames: []string{"member1"}}},
+				{AffinityName: "backup", ClusterAffinity: v1alpha1.ClusterAffinity{ClusterNames: []string{"member2"}}},
+			},
+		},
+	}
+	// ConfigMaps are not served by the fixture clusters
+	kinds := []metav1.TypeMeta{{APIVersion: "apps/v1", Kind: "Deployment"}, {APIVersion: "v1", Kind: "ConfigMap"}}
+	cases := []struct {
+		name         string
+		tolerations  []corev1.Toleration
+		affinityName string
+		eligible     []bool
+	}{
+		{
+			name:         "falls back to the backup clusters",
+			affinityName: "backup",
+			eligible:     []bool{false, true},
+		},
+		{
+			name:         "tolerated primary cluster",
+			tolerations:  []corev1.Toleration{{Key: "maintenance", Operator: corev1.TolerationOpExists}},
+			affinityName: "primary",
+			eligible:     []bool{true, false},
+		},
+	}
+	for _, c := range cases {
+		spec.Placement.ClusterTolerations = c.tolerations
+		preview := &PropagationPreview{}
+		previewPlacement(clusters, spec, kinds, preview)
+		if preview.AffinityName != c.affinityName || preview.Clusters[0].Eligible != c.eligible[0] ||
+			preview.Clusters[1].Eligible != c.eligible[1] || len(preview.Clusters[0].MissingAPIs) != 1 {
+			t.Errorf("%s: unexpected preview %s %+v", c.name, preview.AffinityName, preview.Clusters)
+		}
+	}
+}
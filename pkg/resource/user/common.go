@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// ServiceAccountCell is a cell representation of the ServiceAccount of a user.
+type ServiceAccountCell corev1.ServiceAccount
+
+// GetProperty returns value of a given property.
+func (c ServiceAccountCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(c.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(c.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.TypeProperty:
+		return dataselect.StdComparableString(c.ObjectMeta.Annotations[roleAnnotation])
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []corev1.ServiceAccount) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ServiceAccountCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []corev1.ServiceAccount {
+	std := make([]corev1.ServiceAccount, len(cells))
+	for i := range std {
+		std[i] = corev1.ServiceAccount(cells[i].(ServiceAccountCell))
+	}
+	return std
+}
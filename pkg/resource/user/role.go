@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+)
+
+// Role is a preset set of permissions a dashboard user can be granted.
+type Role string
+
+const (
+	// RoleViewer can read all resources of karmada control plane except secrets.
+	RoleViewer Role = "viewer"
+	// RolePolicyEditor can read like viewer and manage propagation and override policies.
+	RolePolicyEditor Role = "policy-editor"
+	// RoleClusterAdmin can do anything, it's bound to the built-in cluster-admin ClusterRole.
+	RoleClusterAdmin Role = "cluster-admin"
+	// RoleNamespaceEditor can manage all resources in the namespaces the user is granted.
+	RoleNamespaceEditor Role = "namespace-editor"
+)
+
+const (
+	viewerClusterRoleName       = "karmada-dashboard:viewer"
+	policyEditorClusterRoleName = "karmada-dashboard:policy-editor"
+	editorClusterRoleName       = "karmada-dashboard:editor"
+	clusterAdminClusterRoleName = "cluster-admin"
+)
+
+var (
+	readVerbs = []string{"get", "list", "watch"}
+	allVerbs  = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+
+	// karmadaAPIGroups are the api groups of karmada and the workloads it propagates.
+	karmadaAPIGroups = []string{
+		"apps", "batch", "networking.k8s.io", "autoscaling", "policy", "storage.k8s.io", "apiextensions.k8s.io",
+		"cluster.karmada.io", "policy.karmada.io", "work.karmada.io", "config.karmada.io", "apps.karmada.io",
+		"autoscaling.karmada.io", "networking.karmada.io", "search.karmada.io",
+	}
+	// coreResources are the core resources viewers may read, secrets are left out on purpose.
+	coreResources = []string{
+		"namespaces", "configmaps", "services", "endpoints", "events", "pods", "pods/log", "serviceaccounts",
+		"persistentvolumeclaims", "persistentvolumes", "resourcequotas", "limitranges", "nodes",
+	}
+	policyResources = []string{
+		"propagationpolicies", "clusterpropagationpolicies", "overridepolicies", "clusteroverridepolicies",
+	}
+)
+
+// presetClusterRoles are the ClusterRoles created by dashboard, keyed by name.
+var presetClusterRoles = map[string][]rbacv1.PolicyRule{
+	viewerClusterRoleName: {
+		{APIGroups: []string{""}, Resources: coreResources, Verbs: readVerbs},
+		{APIGroups: karmadaAPIGroups, Resources: []string{"*"}, Verbs: readVerbs},
+	},
+	policyEditorClusterRoleName: {
+		{APIGroups: []string{"policy.karmada.io"}, Resources: policyResources, Verbs: allVerbs},
+	},
+	editorClusterRoleName: {
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: allVerbs},
+	},
+}
+
+// clusterRolesOf returns the ClusterRoles bound to users of the role, editor of namespaces is bound with
+// RoleBindings in each namespace and with ClusterRoleBindings for the others.
+func clusterRolesOf(role Role) ([]string, error) {
+	switch role {
+	case RoleViewer:
+		return []string{viewerClusterRoleName}, nil
+	case RolePolicyEditor:
+		return []string{viewerClusterRoleName, policyEditorClusterRoleName}, nil
+	case RoleClusterAdmin:
+		return []string{clusterAdminClusterRoleName}, nil
+	case RoleNamespaceEditor:
+		return []string{editorClusterRoleName}, nil
+	default:
+		return nil, fmt.Errorf("unknown role %q, supported roles are %s, %s, %s and %s",
+			role, RoleViewer, RolePolicyEditor, RoleClusterAdmin, RoleNamespaceEditor)
+	}
+}
+
+// ensureClusterRole creates the preset ClusterRole or updates its rules to the latest ones, the built-in
+// ClusterRoles are left untouched.
+func ensureClusterRole(kubeClient kubeclient.Interface, name string) error {
+	rules, ok := presetClusterRoles[name]
+	if !ok {
+		return nil
+	}
+	clusterRoles := kubeClient.RbacV1().ClusterRoles()
+	existing, err := clusterRoles.Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = clusterRoles.Create(context.TODO(), &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{managedByLabel: managedByValue},
+			},
+			Rules: rules,
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Rules, rules) {
+		return nil
+	}
+	existing = existing.DeepCopy()
+	existing.Rules = rules
+	_, err = clusterRoles.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+const (
+	// DefaultNamespace is the namespace of karmada control plane the ServiceAccounts of dashboard users live in.
+	DefaultNamespace = "karmada-dashboard-users"
+	// DefaultTokenExpiration is the lifetime of tokens issued without an explicit expiration.
+	DefaultTokenExpiration = time.Hour
+	// MinTokenExpiration is the shortest lifetime the TokenRequest API accepts.
+	MinTokenExpiration = 10 * time.Minute
+
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "karmada-dashboard"
+	// userLabel is set on the ServiceAccount and bindings of a user to the name of the user.
+	userLabel = "dashboard.karmada.io/user"
+	// roleAnnotation and namespacesAnnotation record the role a user was created with.
+	roleAnnotation       = "dashboard.karmada.io/role"
+	namespacesAnnotation = "dashboard.karmada.io/namespaces"
+	bindingPrefix        = "karmada-dashboard:user:"
+)
+
+// Spec is the specification of a dashboard user to create.
+type Spec struct {
+	Name string
+	Role Role
+	// Namespaces are the namespaces a namespace-editor may manage, it's ignored for other roles.
+	Namespaces []string
+}
+
+// User is a dashboard user backed by a ServiceAccount.
+type User struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+	Role       Role             `json:"role"`
+	Namespaces []string         `json:"namespaces,omitempty"`
+}
+
+// UserList contains a list of dashboard users.
+type UserList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+	Users    []User         `json:"users"`
+}
+
+// Token is a time-bound token of a dashboard user.
+type Token struct {
+	Token     string      `json:"token"`
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// CreateUser creates the ServiceAccount of user in namespace and binds it to the ClusterRoles of its role.
+func CreateUser(kubeClient kubeclient.Interface, namespace string, spec *Spec) (*User, error) {
+	if err := validate(spec); err != nil {
+		return nil, err
+	}
+	clusterRoles, err := clusterRolesOf(spec.Role)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	if err = ensureNamespace(kubeClient, namespace); err != nil {
+		return nil, err
+	}
+	for _, clusterRole := range clusterRoles {
+		if err = ensureClusterRole(kubeClient, clusterRole); err != nil {
+			return nil, err
+		}
+	}
+
+	annotations := map[string]string{roleAnnotation: string(spec.Role)}
+	if spec.Role == RoleNamespaceEditor {
+		annotations[namespacesAnnotation] = strings.Join(spec.Namespaces, ",")
+	}
+	sa, err := kubeClient.CoreV1().ServiceAccounts(namespace).Create(context.TODO(), &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        spec.Name,
+			Namespace:   namespace,
+			Labels:      userLabels(spec.Name),
+			Annotations: annotations,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createBindings(kubeClient, sa, spec, clusterRoles); err != nil {
+		// do not leave a user with partial permissions behind
+		if deleteErr := DeleteUser(kubeClient, namespace, spec.Name); deleteErr != nil {
+			return nil, fmt.Errorf("%v, and the user could not be cleaned up: %v", err, deleteErr)
+		}
+		return nil, err
+	}
+	user := toUser(sa)
+	return &user, nil
+}
+
+// GetUserList returns the dashboard users in namespace.
+func GetUserList(kubeClient kubeclient.Interface, namespace string, dsQuery *dataselect.DataSelectQuery) (*UserList, error) {
+	serviceAccounts, err := kubeClient.CoreV1().ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{managedByLabel: managedByValue}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(serviceAccounts.Items), dsQuery)
+	serviceAccountList := fromCells(cells)
+	result := &UserList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Users:    make([]User, 0, len(serviceAccountList)),
+	}
+	for i := range serviceAccountList {
+		result.Users = append(result.Users, toUser(&serviceAccountList[i]))
+	}
+	return result, nil
+}
+
+// GetUser returns the dashboard user of name.
+func GetUser(kubeClient kubeclient.Interface, namespace, name string) (*User, error) {
+	sa, err := getServiceAccount(kubeClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	user := toUser(sa)
+	return &user, nil
+}
+
+// CreateToken issues a token of user with the TokenRequest API, the token is invalidated once the user is deleted.
+func CreateToken(kubeClient kubeclient.Interface, namespace, name string, expiration time.Duration) (*Token, error) {
+	if expiration == 0 {
+		expiration = DefaultTokenExpiration
+	}
+	if expiration < MinTokenExpiration {
+		return nil, errors.NewBadRequest(fmt.Sprintf("token expiration must be at least %s", MinTokenExpiration))
+	}
+	if _, err := getServiceAccount(kubeClient, namespace, name); err != nil {
+		return nil, err
+	}
+	expirationSeconds := int64(expiration.Seconds())
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(namespace).CreateToken(context.TODO(), name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &Token{Token: tokenRequest.Status.Token, ExpiresAt: tokenRequest.Status.ExpirationTimestamp}, nil
+}
+
+// DeleteUser revokes the user by deleting its bindings and ServiceAccount, which invalidates all tokens issued to it.
+func DeleteUser(kubeClient kubeclient.Interface, namespace, name string) error {
+	selector := labels.SelectorFromSet(userLabels(name)).String()
+	clusterRoleBindings, err := kubeClient.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		err = kubeClient.RbacV1().ClusterRoleBindings().Delete(context.TODO(), binding.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	roleBindings, err := kubeClient.RbacV1().RoleBindings(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, binding := range roleBindings.Items {
+		err = kubeClient.RbacV1().RoleBindings(binding.Namespace).Delete(context.TODO(), binding.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	err = kubeClient.CoreV1().ServiceAccounts(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func validate(spec *Spec) error {
+	if msgs := validation.IsDNS1123Subdomain(spec.Name); len(msgs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("invalid user name %q: %s", spec.Name, strings.Join(msgs, "; ")))
+	}
+	if spec.Role != RoleNamespaceEditor {
+		return nil
+	}
+	if len(spec.Namespaces) == 0 {
+		return errors.NewBadRequest(fmt.Sprintf("namespaces must be specified for role %s", RoleNamespaceEditor))
+	}
+	for _, namespace := range spec.Namespaces {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			return errors.NewBadRequest(fmt.Sprintf("invalid namespace %q: %s", namespace, strings.Join(msgs, "; ")))
+		}
+	}
+	return nil
+}
+
+func ensureNamespace(kubeClient kubeclient.Interface, namespace string) error {
+	_, err := kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: map[string]string{managedByLabel: managedByValue},
+		},
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// createBindings binds the ServiceAccount to the ClusterRoles, with a RoleBinding in each namespace for
+// namespace-editor and with ClusterRoleBindings for other roles.
+func createBindings(kubeClient kubeclient.Interface, sa *corev1.ServiceAccount, spec *Spec, clusterRoles []string) error {
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: sa.Namespace}}
+	for _, clusterRole := range clusterRoles {
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole}
+		objectMeta := metav1.ObjectMeta{
+			Name:   bindingPrefix + spec.Name + ":" + strings.TrimPrefix(clusterRole, "karmada-dashboard:"),
+			Labels: userLabels(spec.Name),
+		}
+		if spec.Role != RoleNamespaceEditor {
+			_, err := kubeClient.RbacV1().ClusterRoleBindings().Create(context.TODO(), &rbacv1.ClusterRoleBinding{
+				ObjectMeta: objectMeta,
+				Subjects:   subjects,
+				RoleRef:    roleRef,
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, namespace := range spec.Namespaces {
+			namespacedMeta := *objectMeta.DeepCopy()
+			namespacedMeta.Namespace = namespace
+			_, err := kubeClient.RbacV1().RoleBindings(namespace).Create(context.TODO(), &rbacv1.RoleBinding{
+				ObjectMeta: namespacedMeta,
+				Subjects:   subjects,
+				RoleRef:    roleRef,
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getServiceAccount returns the ServiceAccount of user, ServiceAccounts not created by dashboard are reported
+// as not found so that they can not be managed as dashboard users.
+func getServiceAccount(kubeClient kubeclient.Interface, namespace, name string) (*corev1.ServiceAccount, error) {
+	sa, err := kubeClient.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if sa.Labels[managedByLabel] != managedByValue {
+		return nil, errors.NewNotFound(fmt.Sprintf("user %s not found", name))
+	}
+	return sa, nil
+}
+
+func userLabels(name string) map[string]string {
+	return map[string]string{managedByLabel: managedByValue, userLabel: name}
+}
+
+func toUser(sa *corev1.ServiceAccount) User {
+	user := User{
+		ObjectMeta: types.NewObjectMeta(sa.ObjectMeta),
+		TypeMeta:   types.NewTypeMeta(types.ResourceKindUser),
+		Role:       Role(sa.Annotations[roleAnnotation]),
+	}
+	if namespaces := sa.Annotations[namespacesAnnotation]; len(namespaces) > 0 {
+		user.Namespaces = strings.Split(namespaces, ",")
+	}
+	return user
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+func TestCreateAndDeleteUser(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	alice, err := CreateUser(kubeClient, DefaultNamespace, &Spec{Name: "alice", Role: RolePolicyEditor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice.Role != RolePolicyEditor {
+		t.Errorf("expected role %s, got %s", RolePolicyEditor, alice.Role)
+	}
+	clusterRoleBindings, _ := kubeClient.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if len(clusterRoleBindings.Items) != 2 {
+		t.Errorf("expected 2 ClusterRoleBindings for policy-editor, got %d", len(clusterRoleBindings.Items))
+	}
+	for _, name := range []string{viewerClusterRoleName, policyEditorClusterRoleName} {
+		if _, err = kubeClient.RbacV1().ClusterRoles().Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+			t.Errorf("expected ClusterRole %s to be created: %v", name, err)
+		}
+	}
+
+	editor, err := CreateUser(kubeClient, DefaultNamespace, &Spec{Name: "bob", Role: RoleNamespaceEditor, Namespaces: []string{"foo", "bar"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(editor.Namespaces) != 2 {
+		t.Errorf("expected namespaces of editor to be recorded, got %v", editor.Namespaces)
+	}
+	for _, namespace := range []string{"foo", "bar"} {
+		roleBindings, _ := kubeClient.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+		if len(roleBindings.Items) != 1 {
+			t.Errorf("expected 1 RoleBinding in %s, got %d", namespace, len(roleBindings.Items))
+		}
+	}
+
+	// ServiceAccounts not created by dashboard are not users
+	_, _ = kubeClient.CoreV1().ServiceAccounts(DefaultNamespace).Create(context.TODO(), &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: DefaultNamespace},
+	}, metav1.CreateOptions{})
+	list, err := GetUserList(kubeClient, DefaultNamespace, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ListMeta.TotalItems != 2 {
+		t.Errorf("expected 2 users, got %d", list.ListMeta.TotalItems)
+	}
+	if _, err = GetUser(kubeClient, DefaultNamespace, "default"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found for unmanaged ServiceAccount, got %v", err)
+	}
+
+	if err = DeleteUser(kubeClient, DefaultNamespace, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	for _, namespace := range []string{"foo", "bar"} {
+		roleBindings, _ := kubeClient.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+		if len(roleBindings.Items) != 0 {
+			t.Errorf("expected RoleBindings in %s to be deleted, got %d", namespace, len(roleBindings.Items))
+		}
+	}
+	if _, err = kubeClient.CoreV1().ServiceAccounts(DefaultNamespace).Get(context.TODO(), "bob", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ServiceAccount to be deleted, got %v", err)
+	}
+	clusterRoleBindings, _ = kubeClient.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if len(clusterRoleBindings.Items) != 2 {
+		t.Errorf("expected ClusterRoleBindings of other users to be kept, got %d", len(clusterRoleBindings.Items))
+	}
+}
+
+func TestCreateUserValidation(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	for _, spec := range []*Spec{
+		{Name: "Invalid_Name", Role: RoleViewer},
+		{Name: "carol", Role: "superuser"},
+		{Name: "carol", Role: RoleNamespaceEditor},
+	} {
+		if _, err := CreateUser(kubeClient, DefaultNamespace, spec); !apierrors.IsBadRequest(err) {
+			t.Errorf("expected bad request for %+v, got %v", spec, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helper provides the fixtures shared by the tests of dashboard packages.
+package helper
+
+import (
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NewDeployment returns a Deployment with the given labels and annotations, and a single nginx container named
+// after it.
+func NewDeployment(namespace, name string, labels, annotations map[string]string) *unstructured.Unstructured {
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": name, "image": "docker.io/library/nginx:1.25"},
+					},
+				},
+			},
+		},
+	}}
+	deployment.SetNamespace(namespace)
+	deployment.SetName(name)
+	deployment.SetLabels(labels)
+	deployment.SetAnnotations(annotations)
+	return deployment
+}
+
+// NewCluster returns a member cluster in region which serves Deployments.
+func NewCluster(name, region string, labels map[string]string, taints ...corev1.Taint) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       clusterv1alpha1.ClusterSpec{Region: region, Taints: taints},
+		Status: clusterv1alpha1.ClusterStatus{
+			APIEnablements: []clusterv1alpha1.APIEnablement{
+				{GroupVersion: "apps/v1", Resources: []clusterv1alpha1.APIResource{{Name: "deployments", Kind: "Deployment"}}},
+			},
+		},
+	}
+}
@@ -21,8 +21,9 @@ import {
   useMemo,
   useState,
   useCallback,
+  useEffect,
 } from 'react';
-import { Me } from '@/services/auth.ts';
+import { Me, RefreshToken } from '@/services/auth.ts';
 import { karmadaClient } from '@/services';
 import { useQuery } from '@tanstack/react-query';
 
@@ -36,6 +37,8 @@ const AuthContext = createContext<{
   setToken: () => {},
 });
 
+const tokenRefreshInterval = 5 * 60 * 1000;
+
 const AuthProvider = ({ children }: { children: ReactNode }) => {
   const [token, setToken_] = useState(localStorage.getItem('token'));
   const setToken = useCallback((newToken: string) => {
@@ -49,23 +52,38 @@ const AuthProvider = ({ children }: { children: ReactNode }) => {
         karmadaClient.defaults.headers.common[
           'Authorization'
         ] = `Bearer ${token}`;
-        // localStorage.setItem("token", token);
-        const ret = await Me();
-        return ret.data;
       } else {
-        // delete karmadaClient.defaults.headers.common["Authorization"];
-        // localStorage.removeItem("token");
-        return {
-          authenticated: false,
-        };
+        // users signed in with a kubeconfig are authenticated by the session cookie
+        delete karmadaClient.defaults.headers.common['Authorization'];
       }
+      const ret = await Me();
+      return {
+        authenticated: ret.code === 200 && !!ret.data?.authenticated,
+      };
     },
   });
+  const authenticated = !!(data && data.authenticated);
+  useEffect(() => {
+    if (!authenticated) {
+      return;
+    }
+    // renew the dashboard token or session before its idle ttl lapses
+    const timer = setInterval(async () => {
+      const ret = await RefreshToken();
+      if (ret.code === 200 && ret.data.token) {
+        localStorage.setItem('token', ret.data.token);
+        karmadaClient.defaults.headers.common[
+          'Authorization'
+        ] = `Bearer ${ret.data.token}`;
+      }
+    }, tokenRefreshInterval);
+    return () => clearInterval(timer);
+  }, [token, authenticated]);
   const ctxValue = useMemo(() => {
-    if (data && token) {
+    if (data) {
       return {
         authenticated: !!data.authenticated,
-        token,
+        token: token || '',
         setToken,
       };
     } else {
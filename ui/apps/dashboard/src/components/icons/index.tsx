@@ -53,6 +53,7 @@ import {
   Trash2,
   ChevronUp,
   ChevronDown,
+  Key,
 } from 'lucide-react';
 
 export type Icon = LucideIcon;
@@ -73,6 +74,7 @@ export const Icons = {
   basicConfig: Settings2,
   advancedConfig: SlidersHorizontal,
   addon: Blocks,
+  register: Key,
   delete: Trash2,
   up: ChevronUp,
   down: ChevronDown,
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import i18nInstance from '@/utils/i18n';
+import { useState } from 'react';
+import Panel from '@/components/panel';
+import {
+  Button,
+  Form,
+  Input,
+  InputNumber,
+  message,
+  Modal,
+  Popconfirm,
+  Table,
+  TableColumnProps,
+  Tag,
+  Typography,
+} from 'antd';
+import { Icons } from '@/components/icons';
+import { useQuery } from '@tanstack/react-query';
+import {
+  BootstrapToken,
+  CreateBootstrapToken,
+  CreatedBootstrapToken,
+  DeleteBootstrapToken,
+  GetBootstrapTokens,
+} from '@/services/bootstraptoken.ts';
+
+interface RegisterFormValues {
+  description?: string;
+  ttlHours?: number;
+  apiServerEndpoint?: string;
+}
+
+const BootstrapTokenManage = () => {
+  const [messageApi, messageContextHolder] = message.useMessage();
+  const [form] = Form.useForm<RegisterFormValues>();
+  const [formOpen, setFormOpen] = useState(false);
+  const [creating, setCreating] = useState(false);
+  const [created, setCreated] = useState<CreatedBootstrapToken>();
+  const { data, isLoading, refetch } = useQuery({
+    queryKey: ['GetBootstrapTokens'],
+    queryFn: async () => {
+      const ret = await GetBootstrapTokens({});
+      return ret.data;
+    },
+  });
+  const columns: TableColumnProps<BootstrapToken>[] = [
+    {
+      title: i18nInstance.t('3e1c985a7b14b82d955e898200584b4c', '令牌ID'),
+      key: 'id',
+      dataIndex: 'id',
+      width: 150,
+    },
+    {
+      title: i18nInstance.t('3bdd08adab6ea90b9164b20a0e4151ac', '描述'),
+      key: 'description',
+      dataIndex: 'description',
+      render: (v?: string) => v || '-',
+    },
+    {
+      title: i18nInstance.t('9c5d49bda7000887a8d10670ef1b1ed7', '用途'),
+      key: 'usages',
+      dataIndex: 'usages',
+      render: (usages: string[]) =>
+        usages.map((usage) => <Tag key={usage}>{usage}</Tag>),
+    },
+    {
+      title: i18nInstance.t('1fa23f4daa01d5ddfae6179aa43f0720', '过期时间'),
+      key: 'expires',
+      dataIndex: 'expires',
+      width: 200,
+      render: (v?: string) =>
+        v || i18nInstance.t('718ab6a0b78630b8e60e778b47e49a15', '永不过期'),
+    },
+    {
+      title: i18nInstance.t('eca37cb0726c51702f70c486c1c38cf3', '创建时间'),
+      key: 'creationTimestamp',
+      dataIndex: 'creationTimestamp',
+      width: 200,
+    },
+    {
+      title: i18nInstance.t('2b6bc0f293f5ca01b006206c2535ccbc', '操作'),
+      key: 'op',
+      width: 120,
+      render: (_, r) => {
+        return (
+          <Popconfirm
+            placement="topRight"
+            title={i18nInstance.t('8610b3cfdd90730c2312a19d0d8c367a', {
+              id: r.id,
+            })}
+            onConfirm={async () => {
+              const ret = await DeleteBootstrapToken(r.id);
+              if (ret.code === 200) {
+                await messageApi.success(
+                  i18nInstance.t(
+                    '78a451d8bd651b3d068ea53a5444c367',
+                    '令牌撤销成功',
+                  ),
+                );
+                await refetch();
+              } else {
+                await messageApi.error(
+                  i18nInstance.t(
+                    '832bc3571fb577f0c56066b93edfe79b',
+                    '令牌撤销失败',
+                  ),
+                );
+              }
+            }}
+            okText={i18nInstance.t('e83a256e4f5bb4ff8b3d804b5473217a', '确认')}
+            cancelText={i18nInstance.t(
+              '625fb26b4b3340f7872b411f401e754c',
+              '取消',
+            )}
+          >
+            <Button size={'small'} type="link" danger>
+              {i18nInstance.t('bd9fcf46b4e5993f97fe04ee9ebcd7ed', '撤销')}
+            </Button>
+          </Popconfirm>
+        );
+      },
+    },
+  ];
+  return (
+    <Panel>
+      <div className={'flex flex-row justify-end mb-4'}>
+        <Button
+          type={'primary'}
+          icon={<Icons.add width={16} height={16} />}
+          className="flex flex-row items-center"
+          onClick={() => {
+            form.resetFields();
+            setFormOpen(true);
+          }}
+        >
+          {i18nInstance.t('db7a495881b49522c974e58742fcd5cb', '生成注册命令')}
+        </Button>
+      </div>
+      <Table
+        rowKey={(r: BootstrapToken) => r.id}
+        columns={columns}
+        loading={isLoading}
+        dataSource={data?.tokens || []}
+      />
+
+      <Modal
+        title={i18nInstance.t(
+          'db7a495881b49522c974e58742fcd5cb',
+          '生成注册命令',
+        )}
+        open={formOpen}
+        confirmLoading={creating}
+        onOk={async () => {
+          const values = await form.validateFields();
+          setCreating(true);
+          try {
+            const ret = await CreateBootstrapToken({
+              description: values.description,
+              ttlSeconds: values.ttlHours ? values.ttlHours * 3600 : undefined,
+              apiServerEndpoint: values.apiServerEndpoint,
+            });
+            if (ret.code === 200) {
+              setFormOpen(false);
+              setCreated(ret.data);
+              await refetch();
+            } else {
+              await messageApi.error(
+                i18nInstance.t(
+                  '3db1dd08bbadf021eaac664373e88dc6',
+                  '注册命令生成失败',
+                ),
+              );
+            }
+          } finally {
+            setCreating(false);
+          }
+        }}
+        onCancel={() => setFormOpen(false)}
+        okText={i18nInstance.t('e83a256e4f5bb4ff8b3d804b5473217a', '确认')}
+        cancelText={i18nInstance.t('625fb26b4b3340f7872b411f401e754c', '取消')}
+        destroyOnClose
+      >
+        <Form form={form} layout="vertical" initialValues={{ ttlHours: 24 }}>
+          <Form.Item
+            name="description"
+            label={i18nInstance.t('3bdd08adab6ea90b9164b20a0e4151ac', '描述')}
+          >
+            <Input />
+          </Form.Item>
+          <Form.Item
+            name="ttlHours"
+            label={i18nInstance.t(
+              '1232aab051dd0ef08d6406bdccc225c1',
+              '有效期(小时)',
+            )}
+            rules={[{ required: true }]}
+          >
+            <InputNumber min={1} className={'w-full'} />
+          </Form.Item>
+          <Form.Item
+            name="apiServerEndpoint"
+            label={i18nInstance.t(
+              '1aa9b53d94636e74133f5a5d0be836d7',
+              'Karmada API Server 地址',
+            )}
+            tooltip={i18nInstance.t(
+              '87ac3a805764320d5f4b9bc42d8e06aa',
+              '成员集群访问 Karmada API Server 的地址，为空时使用默认地址',
+            )}
+          >
+            <Input placeholder={'https://karmada-apiserver:5443'} />
+          </Form.Item>
+        </Form>
+      </Modal>
+
+      <Modal
+        title={i18nInstance.t('7ed55e66a50b3d686cc16206bbe6f24b', '注册命令')}
+        open={!!created}
+        onCancel={() => setCreated(undefined)}
+        footer={null}
+        width={800}
+      >
+        <Typography.Paragraph type="warning">
+          {i18nInstance.t(
+            '5a22bfc36ee298dc7107a3b51cd92271',
+            '令牌只显示一次，请在成员集群中执行以下命令完成注册',
+          )}
+        </Typography.Paragraph>
+        <Typography.Paragraph code copyable>
+          {created?.registerCommand}
+        </Typography.Paragraph>
+      </Modal>
+      {messageContextHolder}
+    </Panel>
+  );
+};
+export default BootstrapTokenManage;
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import i18nInstance from '@/utils/i18n';
+import { useState } from 'react';
+import Panel from '@/components/panel';
+import {
+  Button,
+  message,
+  Popconfirm,
+  Segmented,
+  Space,
+  Table,
+  TableColumnProps,
+  Tag,
+} from 'antd';
+import { useQuery } from '@tanstack/react-query';
+import {
+  ApproveCSR,
+  CertificateSigningRequest,
+  DenyCSR,
+  GetCSRs,
+} from '@/services/csr.ts';
+
+type StatusFilter = 'Pending' | 'All';
+
+const statusColors: Record<CertificateSigningRequest['status'], string> = {
+  Pending: 'gold',
+  Approved: 'green',
+  Denied: 'red',
+  Failed: 'red',
+};
+
+const CSRManage = () => {
+  const [messageApi, messageContextHolder] = message.useMessage();
+  const [statusFilter, setStatusFilter] = useState<StatusFilter>('Pending');
+  const { data, isLoading, refetch } = useQuery({
+    queryKey: ['GetCSRs'],
+    queryFn: async () => {
+      const ret = await GetCSRs({});
+      return ret.data;
+    },
+  });
+  const csrs = (data?.certificateSigningRequests || []).filter(
+    (csr) => statusFilter === 'All' || csr.status === statusFilter,
+  );
+  const columns: TableColumnProps<CertificateSigningRequest>[] = [
+    {
+      title: i18nInstance.t('d7ec2d3fea4756bc1642e0f10c180cf5', '名称'),
+      key: 'name',
+      width: 250,
+      render: (_, r) => r.objectMeta.name,
+    },
+    {
+      title: i18nInstance.t('6c3b57677c939cde9adb294fc75b5a0e', '申请者'),
+      key: 'username',
+      dataIndex: 'username',
+    },
+    {
+      title: i18nInstance.t('6259e2ee0332b5e9a2376b97b24f0a93', '证书通用名称'),
+      key: 'commonName',
+      dataIndex: 'commonName',
+    },
+    {
+      title: i18nInstance.t('74fe5f9e99dcf491781c9b5474d8ae53', '组织'),
+      key: 'organizations',
+      dataIndex: 'organizations',
+      render: (organizations: string[]) =>
+        organizations.map((o) => <Tag key={o}>{o}</Tag>),
+    },
+    {
+      title: i18nInstance.t('c9cf132bb5801ddc31157914bb42c9b5', '签名者'),
+      key: 'signerName',
+      dataIndex: 'signerName',
+    },
+    {
+      title: i18nInstance.t('3fea7ca76cdece641436d7ab0d02ab1b', '状态'),
+      key: 'status',
+      dataIndex: 'status',
+      width: 120,
+      render: (v: CertificateSigningRequest['status']) => (
+        <Tag color={statusColors[v]}>{v}</Tag>
+      ),
+    },
+    {
+      title: i18nInstance.t('eca37cb0726c51702f70c486c1c38cf3', '创建时间'),
+      key: 'creationTimestamp',
+      width: 200,
+      render: (_, r) => r.objectMeta.creationTimestamp,
+    },
+    {
+      title: i18nInstance.t('2b6bc0f293f5ca01b006206c2535ccbc', '操作'),
+      key: 'op',
+      width: 150,
+      render: (_, r) => {
+        if (r.status !== 'Pending') {
+          return '-';
+        }
+        return (
+          <Space.Compact>
+            <Popconfirm
+              placement="topRight"
+              title={i18nInstance.t('f0ee2aab3f70a990843602d585427124', {
+                name: r.objectMeta.name,
+              })}
+              onConfirm={async () => {
+                const ret = await ApproveCSR(r.objectMeta.name);
+                if (ret.code === 200) {
+                  await messageApi.success(
+                    i18nInstance.t(
+                      'ae95ee740187e28033baea9830e07609',
+                      '证书签名请求已批准',
+                    ),
+                  );
+                  await refetch();
+                } else {
+                  await messageApi.error(
+                    i18nInstance.t(
+                      '3db0e692e12fac8f37b7feb106ba8664',
+                      '批准失败',
+                    ),
+                  );
+                }
+              }}
+              okText={i18nInstance.t(
+                'e83a256e4f5bb4ff8b3d804b5473217a',
+                '确认',
+              )}
+              cancelText={i18nInstance.t(
+                '625fb26b4b3340f7872b411f401e754c',
+                '取消',
+              )}
+            >
+              <Button size={'small'} type="link">
+                {i18nInstance.t('7a712b9a4574166830b69a07ce78056e', '批准')}
+              </Button>
+            </Popconfirm>
+            <Popconfirm
+              placement="topRight"
+              title={i18nInstance.t('a50a531f5cd4d56889269e8fdb63afc8', {
+                name: r.objectMeta.name,
+              })}
+              onConfirm={async () => {
+                const ret = await DenyCSR(r.objectMeta.name);
+                if (ret.code === 200) {
+                  await messageApi.success(
+                    i18nInstance.t(
+                      '178e074a43798dede0f1b14af1027e18',
+                      '证书签名请求已拒绝',
+                    ),
+                  );
+                  await refetch();
+                } else {
+                  await messageApi.error(
+                    i18nInstance.t(
+                      '8403be97872c6c4823fd32998eedb387',
+                      '拒绝失败',
+                    ),
+                  );
+                }
+              }}
+              okText={i18nInstance.t(
+                'e83a256e4f5bb4ff8b3d804b5473217a',
+                '确认',
+              )}
+              cancelText={i18nInstance.t(
+                '625fb26b4b3340f7872b411f401e754c',
+                '取消',
+              )}
+            >
+              <Button size={'small'} type="link" danger>
+                {i18nInstance.t('7173f80900ea2ff9fec6568115611305', '拒绝')}
+              </Button>
+            </Popconfirm>
+          </Space.Compact>
+        );
+      },
+    },
+  ];
+  return (
+    <Panel>
+      <div className={'flex flex-row justify-between mb-4'}>
+        <Segmented
+          value={statusFilter}
+          options={[
+            {
+              label: i18nInstance.t(
+                'b0bf01a4a8655d44002ac29f69f12061',
+                '待审批',
+              ),
+              value: 'Pending',
+            },
+            {
+              label: i18nInstance.t('a8b0c20416853bda54120bf19477ad11', '全部'),
+              value: 'All',
+            },
+          ]}
+          onChange={(value) => setStatusFilter(value as StatusFilter)}
+        />
+      </div>
+      <Table
+        rowKey={(r: CertificateSigningRequest) => r.objectMeta.name}
+        columns={columns}
+        loading={isLoading}
+        dataSource={csrs}
+      />
+      {messageContextHolder}
+    </Panel>
+  );
+};
+export default CSRManage;
@@ -19,10 +19,13 @@ import { Alert, Button, Card, Collapse, Input, message } from 'antd';
 import styles from './index.module.less';
 import { cn } from '@/utils/cn.ts';
 import { useState } from 'react';
-import { Login } from '@/services/auth.ts';
+import { Login, LoginWithKubeconfig } from '@/services/auth.ts';
 import { useNavigate } from 'react-router-dom';
 import { useAuth } from '@/components/auth';
 
+// a kubeconfig is pasted instead of a token if it looks like a yaml document
+const isKubeconfig = (v: string) => /^\s*(apiVersion|clusters|kind):/m.test(v);
+
 const LoginPage = () => {
   const [authToken, setAuthToken] = useState('');
   const [messageApi, contextHolder] = message.useMessage();
@@ -82,7 +85,9 @@ const LoginPage = () => {
               type="primary"
               onClick={async () => {
                 try {
-                  const ret = await Login(authToken);
+                  const ret = isKubeconfig(authToken)
+                    ? await LoginWithKubeconfig(authToken)
+                    : await Login(authToken);
                   if (ret.code === 200) {
                     await messageApi.success(
                       i18nInstance.t(
@@ -91,7 +96,7 @@ const LoginPage = () => {
                       ),
                     );
                     setTimeout(() => {
-                      setToken(authToken);
+                      setToken(ret.data.token);
                       navigate('/overview');
                     }, 1000);
                   } else {
@@ -103,16 +103,18 @@ const Overview = () => {
               {data?.memberClusterStatus?.memorySummary?.allocatedMemory &&
                 (
                   data.memberClusterStatus.memorySummary.allocatedMemory /
-                  8 /
+                  1024 /
                   1024 /
                   1024
                 ).toFixed(2)}
               GiB /
               {data?.memberClusterStatus?.memorySummary?.totalMemory &&
-                data.memberClusterStatus.memorySummary.totalMemory /
-                  8 /
+                (
+                  data.memberClusterStatus.memorySummary.totalMemory /
+                  1024 /
                   1024 /
-                  1024}
+                  1024
+                ).toFixed(2)}
               GiB
             </span>
           </div>
@@ -42,6 +42,7 @@ import {
 import { Failover, Permission, Reschedule } from '@/pages/advanced-config';
 import { BuildInAddon, ThridPartyAddon } from '@/pages/addon';
 import ClusterManage from '@/pages/cluster-manage';
+import { BootstrapToken, CSR } from '@/pages/cluster-register';
 import Login from '@/pages/login';
 import { Icons } from '@/components/icons';
 
@@ -172,6 +173,42 @@ export function getRoutes() {
             isPage: false,
           },
         },
+        {
+          path: '/cluster-register',
+          handle: {
+            sidebarKey: 'CLUSTER-REGISTER',
+            sidebarName: i18nInstance.t(
+              '7331023a8754ea717f296be2b0f2edc5',
+              '集群注册',
+            ),
+            icon: <Icons.register {...IconStyles} />,
+            isPage: false,
+          },
+          children: [
+            {
+              path: 'bootstrap-token',
+              element: <BootstrapToken />,
+              handle: {
+                sidebarKey: 'BOOTSTRAP-TOKEN',
+                sidebarName: i18nInstance.t(
+                  '9426555a7d5dd4461f1faff8f63f1a08',
+                  '引导令牌',
+                ),
+              },
+            },
+            {
+              path: 'csr',
+              element: <CSR />,
+              handle: {
+                sidebarKey: 'CSR',
+                sidebarName: i18nInstance.t(
+                  '9bbc38d4b76d4d29fd6fdee366ac4a2d',
+                  '证书签名请求',
+                ),
+              },
+            },
+          ],
+        },
         {
           path: '/basic-config',
           handle: {
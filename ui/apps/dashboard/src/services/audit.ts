@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+} from '@/services/base.ts';
+
+export interface AuditEvent {
+  id: string;
+  timestamp: string;
+  // empty if karmada apiserver could not authenticate the request
+  user: string;
+  // the name claimed by the credentials of an unauthenticated request, it may be forged
+  unverifiedUser?: string;
+  credentialDigest?: string;
+  sourceIP: string;
+  method: string;
+  route: string;
+  path: string;
+  cluster?: string;
+  kind?: string;
+  namespace?: string;
+  name?: string;
+  requestDigest?: string;
+  outcome: 'success' | 'failure';
+  code: number;
+  message?: string;
+  latencyMs: number;
+}
+
+export async function GetAuditEvents(query: DataSelectQuery) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: {
+        totalItems: number;
+      };
+      events: AuditEvent[];
+    }>
+  >('/audit', {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
+}
@@ -16,8 +16,13 @@ limitations under the License.
 
 import { IResponse, karmadaClient } from '@/services/base.ts';
 
+export interface TokenResponse {
+  token: string;
+  expiresAt: string;
+}
+
 export async function Login(token: string) {
-  const resp = await karmadaClient.post<IResponse<{ token: string }>>(
+  const resp = await karmadaClient.post<IResponse<TokenResponse>>(
     `/login`,
     { token },
     {
@@ -30,6 +35,30 @@ export async function Login(token: string) {
   return resp.data;
 }
 
+// LoginWithKubeconfig signs in with the token or client certificate of the
+// context in kubeconfig, the credentials are kept in a session cookie so the
+// returned token is empty.
+export async function LoginWithKubeconfig(
+  kubeconfig: string,
+  context?: string,
+) {
+  const resp = await karmadaClient.post<IResponse<TokenResponse>>(`/login`, {
+    kubeconfig,
+    context,
+  });
+  return resp.data;
+}
+
+export async function GetKubeconfigContexts(kubeconfig: string) {
+  const resp = await karmadaClient.post<
+    IResponse<{
+      contexts: string[];
+      currentContext: string;
+    }>
+  >(`/login/kubeconfig/contexts`, { kubeconfig });
+  return resp.data;
+}
+
 export async function Me() {
   const resp = await karmadaClient.get<
     IResponse<{
@@ -38,3 +67,45 @@ export async function Me() {
   >(`me`);
   return resp.data;
 }
+
+export async function RefreshToken() {
+  const resp =
+    await karmadaClient.post<IResponse<TokenResponse>>(`/token/refresh`);
+  return resp.data;
+}
+
+export async function Logout() {
+  const resp = await karmadaClient.post<IResponse<null>>(`/logout`);
+  return resp.data;
+}
+
+export interface UserPermissions {
+  user: {
+    username: string;
+    uid?: string;
+    groups: string[];
+  };
+  cluster: Record<string, string[]>;
+  namespaces: {
+    namespace: string;
+    resources: Record<string, string[]>;
+  }[];
+}
+
+export async function GetPermissions(query?: {
+  namespace?: string[];
+  kind?: string[];
+}) {
+  const params = {} as Record<string, string>;
+  if (query?.namespace) {
+    params['namespace'] = query.namespace.join(',');
+  }
+  if (query?.kind) {
+    params['kind'] = query.kind.join(',');
+  }
+  const resp = await karmadaClient.get<IResponse<UserPermissions>>(
+    `/me/permissions`,
+    { params },
+  );
+  return resp.data;
+}
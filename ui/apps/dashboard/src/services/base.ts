@@ -31,10 +31,36 @@ export const karmadaClient = axios.create({
   baseURL,
 });
 
+const safeMethods = ['get', 'head', 'options'];
+// mutating requests must carry a csrf token issued for their action, which
+// is the first segment of the path, e.g. `cluster` for `/cluster/member1`
+karmadaClient.interceptors.request.use(async (config) => {
+  const method = (config.method || 'get').toLowerCase();
+  if (safeMethods.includes(method)) {
+    return config;
+  }
+  const action = _.trimStart(config.url || '', '/').split(/[/?]/)[0];
+  const resp = await axios.get<IResponse<{ token: string }>>(
+    `${baseURL}/csrftoken/${action}`,
+  );
+  config.headers.set('X-CSRF-TOKEN', resp.data.data.token);
+  return config;
+});
+
+// StatusCause is a field level detail of a failed request, e.g. a field
+// rejected by schema validation or an admission webhook
+export interface StatusCause {
+  // e.g. spec.placement.clusterAffinity.clusterNames[0]
+  field?: string;
+  reason?: string;
+  message?: string;
+}
+
 export interface IResponse<Data = {}> {
   code: number;
   message: string;
   data: Data;
+  causes?: StatusCause[];
 }
 
 export interface DataSelectQuery {
@@ -68,6 +94,7 @@ export interface ObjectMeta {
   annotations: Annotations;
   creationTimestamp: string;
   uid: string;
+  resourceVersion?: string;
 }
 
 export interface TypeMeta {
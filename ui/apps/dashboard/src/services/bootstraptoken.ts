@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+} from '@/services/base.ts';
+
+export interface BootstrapToken {
+  id: string;
+  description?: string;
+  expires?: string;
+  usages: string[];
+  groups: string[];
+  creationTimestamp: string;
+}
+
+export interface CreatedBootstrapToken extends BootstrapToken {
+  // the full token, it's only returned once
+  token: string;
+  // `karmadactl register` command to run against the member cluster
+  registerCommand: string;
+}
+
+export async function CreateBootstrapToken(params: {
+  ttlSeconds?: number;
+  description?: string;
+  apiServerEndpoint?: string;
+}) {
+  const resp = await karmadaClient.post<IResponse<CreatedBootstrapToken>>(
+    '/bootstraptoken',
+    params,
+  );
+  return resp.data;
+}
+
+export async function GetBootstrapTokens(query: DataSelectQuery) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: {
+        totalItems: number;
+      };
+      tokens: BootstrapToken[];
+    }>
+  >('/bootstraptoken', {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
+}
+
+export async function DeleteBootstrapToken(id: string) {
+  const resp = await karmadaClient.delete<IResponse<string>>(
+    `/bootstraptoken/${id}`,
+  );
+  return resp.data;
+}
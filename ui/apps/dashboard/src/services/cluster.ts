@@ -14,7 +14,14 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-import { IResponse, karmadaClient } from './base';
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+} from './base';
+import { Operation } from './operation';
+import { Availability } from './overview';
 
 export interface ObjectMeta {
   name: string;
@@ -33,13 +40,25 @@ export interface NodeSummary {
 }
 
 export interface AllocatedResources {
+  // allocatable cores
   cpuCapacity: number;
   cpuFraction: number;
+  // allocatable bytes
   memoryCapacity: number;
   memoryFraction: number;
   allocatedPods: number;
   podCapacity: number;
   podFraction: number;
+  resources: ResourceUsage[];
+}
+
+export interface ResourceUsage {
+  name: string;
+  // quantities, e.g. 500m or 16Gi
+  allocatable: string;
+  allocated: string;
+  allocating: string;
+  fraction: number;
 }
 
 export interface Cluster {
@@ -60,8 +79,60 @@ export interface ListClusterResp {
   errors: string[];
 }
 
+export interface SecretReference {
+  namespace: string;
+  name: string;
+}
+
+export interface ResourceModel {
+  grade: number;
+  ranges: {
+    name: string;
+    min: string;
+    max: string;
+  }[];
+}
+
 export interface ClusterDetail extends Cluster {
   taints: TaintParam[];
+  id?: string;
+  apiEndpoint?: string;
+  provider?: string;
+  region?: string;
+  zones?: string[];
+  proxyURL?: string;
+  proxyHeader?: Record<string, string>;
+  insecureSkipTLSVerification?: boolean;
+  secretRef?: SecretReference;
+  impersonatorSecretRef?: SecretReference;
+  // nodeCount is the number of nodes falling into the grade
+  resourceModels?: (ResourceModel & { nodeCount: number })[];
+  apiEnablementCount: number;
+  apiEnablementsComplete: boolean;
+}
+
+export interface APIEnablement {
+  group: string;
+  version: string;
+  kind: string;
+  resource: string;
+}
+
+export async function GetClusterAPIEnablements(
+  clusterName: string,
+  // filterBy accepts name (the resource name), kind, group and version
+  query: DataSelectQuery = {},
+) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: { totalItems: number };
+      apiEnablements: APIEnablement[];
+      complete: boolean;
+    }>
+  >(`/cluster/${clusterName}/apienablements`, {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
 }
 
 export async function GetClusters() {
@@ -76,20 +147,78 @@ export async function GetClusterDetail(clusterName: string) {
   return resp.data;
 }
 
+export interface KarmadaAgentSpec {
+  // name of a docker registry in dashboard config
+  registry?: string;
+  image?: string;
+  tag?: string;
+  replicas?: number;
+  resources?: {
+    limits?: Record<string, string>;
+    requests?: Record<string, string>;
+  };
+  nodeSelector?: Record<string, string>;
+  tolerations?: {
+    key?: string;
+    operator?: 'Exists' | 'Equal';
+    value?: string;
+    effect?: string;
+  }[];
+  extraArgs?: string[];
+}
+
 export async function CreateCluster(params: {
   kubeconfig: string;
   clusterName: string;
   mode: 'Push' | 'Pull';
+  memberClusterNamespace?: string;
+  karmadaAgent?: KarmadaAgentSpec;
 }) {
   // /api/v1/cluster
-  const resp = await karmadaClient.post<IResponse<string>>(`/cluster`, {
+  const resp = await karmadaClient.post<IResponse<Operation>>(`/cluster`, {
     memberClusterKubeconfig: params.kubeconfig,
     memberClusterName: params.clusterName,
     syncMode: params.mode,
+    memberClusterNamespace: params.memberClusterNamespace,
+    karmadaAgent: params.karmadaAgent,
   });
   return resp.data;
 }
 
+export interface BatchClusterParam {
+  // name of the context in the kubeconfig
+  context: string;
+  syncMode: 'Push' | 'Pull';
+  memberClusterName: string;
+  memberClusterNamespace?: string;
+  clusterProvider?: string;
+  clusterRegion?: string;
+  clusterZones?: string[];
+  clusterLabels?: Record<string, string>;
+  karmadaAgent?: KarmadaAgentSpec;
+}
+
+export interface BatchClusterResult {
+  context: string;
+  memberClusterName: string;
+  // set if the join has started
+  operation?: Operation;
+  // set if the join could not be started
+  error?: string;
+}
+
+export async function BatchCreateClusters(params: {
+  kubeconfig: string;
+  clusters: BatchClusterParam[];
+  // number of clusters joined at the same time, defaults to 4
+  concurrency?: number;
+}) {
+  const resp = await karmadaClient.post<
+    IResponse<{ results: BatchClusterResult[] }>
+  >(`/cluster/batch`, params);
+  return resp.data;
+}
+
 export interface LabelParam {
   key: string;
   value: string;
@@ -103,8 +232,17 @@ export interface TaintParam {
 
 export async function UpdateCluster(params: {
   clusterName: string;
-  labels: LabelParam[];
-  taints: TaintParam[];
+  labels?: LabelParam[];
+  taints?: TaintParam[];
+  id?: string;
+  provider?: string;
+  region?: string;
+  zones?: string[];
+  proxyURL?: string;
+  proxyHeader?: Record<string, string>;
+  secretRef?: SecretReference;
+  impersonatorSecretRef?: SecretReference;
+  resourceModels?: ResourceModel[];
 }) {
   const { clusterName, ...restParams } = params;
   const resp = await karmadaClient.put<IResponse<ClusterDetail>>(
@@ -114,9 +252,142 @@ export async function UpdateCluster(params: {
   return resp.data;
 }
 
-export async function DeleteCluster(clusterName: string) {
-  const resp = await karmadaClient.delete<IResponse<ClusterDetail>>(
+export async function DeleteCluster(
+  clusterName: string,
+  options?: {
+    // objects created in member cluster are removed if kubeconfig is given
+    kubeconfig?: string;
+    memberClusterNamespace?: string;
+    force?: boolean;
+  },
+) {
+  const resp = await karmadaClient.delete<IResponse<Operation>>(
     `/cluster/${clusterName}`,
+    {
+      data: options && {
+        memberClusterKubeconfig: options.kubeconfig,
+        memberClusterNamespace: options.memberClusterNamespace,
+        force: options.force,
+      },
+    },
+  );
+  return resp.data;
+}
+
+export interface PreflightCheck {
+  name: string;
+  status: 'pass' | 'warn' | 'fail';
+  message: string;
+  remediation?: string;
+}
+
+export async function PreflightCluster(params: {
+  kubeconfig: string;
+  clusterName: string;
+  mode: 'Push' | 'Pull';
+  memberClusterNamespace?: string;
+}) {
+  const resp = await karmadaClient.post<
+    IResponse<{
+      passed: boolean;
+      checks: PreflightCheck[];
+    }>
+  >(`/cluster/preflight`, {
+    memberClusterKubeconfig: params.kubeconfig,
+    memberClusterName: params.clusterName,
+    syncMode: params.mode,
+    memberClusterNamespace: params.memberClusterNamespace,
+  });
+  return resp.data;
+}
+
+export interface KubeconfigOptions {
+  // address of karmada apiserver written into the kubeconfig
+  server?: string;
+  // when set, a short-lived token is minted instead of embedding the current credentials
+  expirationSeconds?: number;
+}
+
+// DownloadClusterKubeconfig fetches a kubeconfig reaching the member cluster through
+// the karmada cluster proxy, all accessible clusters are included if clusterName is empty.
+export async function DownloadClusterKubeconfig(
+  clusterName?: string,
+  options: KubeconfigOptions = {},
+) {
+  const url = clusterName ? `/cluster/${clusterName}/kubeconfig` : `/kubeconfig`;
+  const resp = await karmadaClient.get<string>(url, {
+    params: options,
+    responseType: 'text',
+  });
+  return resp.data;
+}
+
+export interface ClusterConditionTransition {
+  type: string;
+  status: 'True' | 'False' | 'Unknown';
+  reason?: string;
+  message?: string;
+  time: string;
+}
+
+export async function GetClusterHistory(clusterName: string) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      cluster: string;
+      transitions: ClusterConditionTransition[];
+      availability: Availability[];
+    }>
+  >(`/cluster/${clusterName}/history`);
+  return resp.data;
+}
+
+export interface BindingImpact {
+  kind: 'ResourceBinding' | 'ClusterResourceBinding';
+  namespace?: string;
+  name: string;
+  resource: {
+    apiVersion: string;
+    kind: string;
+    namespace?: string;
+    name: string;
+  };
+  replicas: number;
+  action: 'Evict' | 'EvictLater' | 'Remain';
+  tolerationSeconds?: number;
+  candidateClusters: string[];
+}
+
+export interface EvacuationPreview {
+  cluster: string;
+  bindings: BindingImpact[];
+  // bindings evicted without any other cluster to move to
+  unschedulable: number;
+}
+
+export async function PreviewClusterEvacuation(clusterName: string) {
+  const resp = await karmadaClient.post<IResponse<EvacuationPreview>>(
+    `/cluster/${clusterName}/evacuate`,
+  );
+  return resp.data;
+}
+
+// EvacuateCluster taints the cluster and returns the operation tracking the
+// eviction, cancelling the operation removes the taint again. timeoutSeconds
+// defaults to 30 minutes and is clamped to 24 hours.
+export async function EvacuateCluster(
+  clusterName: string,
+  timeoutSeconds?: number,
+) {
+  const resp = await karmadaClient.post<IResponse<Operation>>(
+    `/cluster/${clusterName}/evacuate`,
+    { confirm: true, timeoutSeconds },
+  );
+  return resp.data;
+}
+
+export async function RemoveClusterEvacuation(clusterName: string) {
+  const resp = await karmadaClient.delete<IResponse<string>>(
+    `/cluster/${clusterName}/evacuate`,
   );
   return resp.data;
 }
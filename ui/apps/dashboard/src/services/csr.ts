@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+} from '@/services/base.ts';
+import { ObjectMeta, TypeMeta } from '@/services/cluster.ts';
+
+export interface CertificateSigningRequest {
+  objectMeta: ObjectMeta;
+  typeMeta: TypeMeta;
+  username: string;
+  signerName: string;
+  commonName: string;
+  organizations: string[];
+  status: 'Pending' | 'Approved' | 'Denied' | 'Failed';
+}
+
+export async function GetCSRs(query: DataSelectQuery) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: {
+        totalItems: number;
+      };
+      certificateSigningRequests: CertificateSigningRequest[];
+    }>
+  >('/csr', {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
+}
+
+export async function ApproveCSR(name: string, message?: string) {
+  const resp = await karmadaClient.post<IResponse<CertificateSigningRequest>>(
+    `/csr/${name}/approve`,
+    { message },
+  );
+  return resp.data;
+}
+
+export async function DenyCSR(name: string, message?: string) {
+  const resp = await karmadaClient.post<IResponse<CertificateSigningRequest>>(
+    `/csr/${name}/deny`,
+    { message },
+  );
+  return resp.data;
+}
@@ -48,10 +48,13 @@ export async function GetNamespaces(query: DataSelectQuery) {
 export async function CreateNamespace(params: {
   name: string;
   skipAutoPropagation: boolean;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.post<IResponse<string>>(
     '/namespace',
-    params,
+    data,
+    { params: { dryRun } },
   );
   return resp.data;
 }
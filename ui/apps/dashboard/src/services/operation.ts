@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+} from '@/services/base.ts';
+
+export type OperationPhase =
+  | 'Pending'
+  | 'Running'
+  | 'Succeeded'
+  | 'Failed'
+  | 'Cancelled';
+
+export interface OperationStep {
+  name: string;
+  phase: OperationPhase;
+  message?: string;
+  startedAt?: string;
+  completedAt?: string;
+}
+
+export interface Operation {
+  id: string;
+  type: 'ClusterJoin' | 'ClusterUnjoin' | 'ClusterEvacuate';
+  target: string;
+  user?: string;
+  phase: OperationPhase;
+  message?: string;
+  steps: OperationStep[];
+  createdAt: string;
+  completedAt?: string;
+}
+
+export function IsOperationFinished(operation: Operation) {
+  return ['Succeeded', 'Failed', 'Cancelled'].includes(operation.phase);
+}
+
+export async function GetOperations(query: DataSelectQuery) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: {
+        totalItems: number;
+      };
+      operations: Operation[];
+    }>
+  >('/operation', {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
+}
+
+export async function GetOperation(id: string) {
+  const resp = await karmadaClient.get<IResponse<Operation>>(
+    `/operation/${id}`,
+  );
+  return resp.data;
+}
+
+export async function CancelOperation(id: string) {
+  const resp = await karmadaClient.post<IResponse<Operation>>(
+    `/operation/${id}/cancel`,
+  );
+  return resp.data;
+}
+
+// WatchOperation subscribes to the server-sent progress of operation, onChange
+// is called on every change until the operation finished, the returned
+// function stops watching. fetch is used instead of EventSource since the
+// latter can not carry the Authorization header.
+export function WatchOperation(
+  id: string,
+  onChange: (operation: Operation) => void,
+) {
+  const controller = new AbortController();
+  const headers: Record<string, string> = {};
+  const authorization =
+    karmadaClient.defaults.headers.common['Authorization'];
+  if (authorization) {
+    headers['Authorization'] = String(authorization);
+  }
+  const watch = async () => {
+    const resp = await fetch(
+      `${karmadaClient.defaults.baseURL}/operation/${id}/watch`,
+      { headers, signal: controller.signal, credentials: 'same-origin' },
+    );
+    if (!resp.body) {
+      return;
+    }
+    const reader = resp.body.getReader();
+    const decoder = new TextDecoder();
+    let buffer = '';
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (done) {
+        return;
+      }
+      buffer += decoder.decode(value, { stream: true });
+      const events = buffer.split('\n\n');
+      buffer = events.pop() || '';
+      for (const event of events) {
+        const data = event
+          .split('\n')
+          .filter((line) => line.startsWith('data:'))
+          .map((line) => line.slice('data:'.length))
+          .join('\n');
+        if (data) {
+          onChange(JSON.parse(data) as Operation);
+        }
+      }
+    }
+  };
+  watch().catch(() => {});
+  return () => controller.abort();
+}
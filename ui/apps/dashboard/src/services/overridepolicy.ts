@@ -172,9 +172,55 @@ export async function CreateOverridePolicy(params: {
   namespace: string;
   name: string;
   overrideData: string;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.post<IResponse<string>>(
     '/overridepolicy',
+    data,
+    { params: { dryRun } },
+  );
+  return resp.data;
+}
+
+export interface OverridePolicyReference {
+  kind: 'OverridePolicy' | 'ClusterOverridePolicy';
+  namespace?: string;
+  name: string;
+}
+
+export interface ClusterOverride {
+  cluster: string;
+  // policies applied to the cluster in order
+  appliedPolicies: OverridePolicyReference[];
+  manifest?: string;
+  // unified diff of manifest against the template
+  diff?: string;
+  error?: string;
+}
+
+export interface OverridePreview {
+  selected: boolean;
+  template: string;
+  clusters: ClusterOverride[];
+  warnings: string[];
+}
+
+export async function PreviewOverridePolicy(params: {
+  isClusterScope: boolean;
+  namespace: string;
+  overrideData: string;
+  // manifest of the target resource, takes precedence over resource
+  resourceData?: string;
+  resource?: {
+    apiVersion: string;
+    kind: string;
+    namespace?: string;
+    name: string;
+  };
+}) {
+  const resp = await karmadaClient.post<IResponse<OverridePreview>>(
+    '/overridepolicy/preview',
     params,
   );
   return resp.data;
@@ -185,10 +231,13 @@ export async function UpdateOverridePolicy(params: {
   namespace: string;
   name: string;
   overrideData: string;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.put<IResponse<string>>(
     '/overridepolicy',
-    params,
+    data,
+    { params: { dryRun } },
   );
   return resp.data;
 }
@@ -231,3 +280,68 @@ export async function GetClusterOverridePolicies(params: { keyword?: string }) {
   });
   return resp.data;
 }
+
+export interface OverrideSpec {
+  resourceSelectors?: ResourceSelector[];
+  overrideRules?: OverrideRule[];
+}
+
+export interface ClusterOverridePolicyDetail extends ClusterOverridePolicy {
+  spec: OverrideSpec;
+  errors: string[];
+}
+
+export async function CreateClusterOverridePolicy(params: {
+  name: string;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
+  spec: OverrideSpec;
+}) {
+  const resp = await karmadaClient.post<IResponse<ClusterOverridePolicyDetail>>(
+    '/clusteroverridepolicy',
+    params,
+  );
+  return resp.data;
+}
+
+// labels and annotations replace the existing ones if set, the update fails
+// with a conflict if resourceVersion is outdated
+export async function UpdateClusterOverridePolicy(params: {
+  name: string;
+  resourceVersion: string;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
+  spec: OverrideSpec;
+}) {
+  const { name, ...data } = params;
+  const resp = await karmadaClient.put<IResponse<ClusterOverridePolicyDetail>>(
+    `/clusteroverridepolicy/${name}`,
+    data,
+  );
+  return resp.data;
+}
+
+// patch is a JSON merge patch, it must contain metadata.resourceVersion and
+// must not change the permanent id labels karmada assigned
+export async function PatchClusterOverridePolicy(
+  name: string,
+  patch: Record<string, unknown>,
+) {
+  const resp = await karmadaClient.patch<
+    IResponse<ClusterOverridePolicyDetail>
+  >(`/clusteroverridepolicy/${name}`, patch, {
+    headers: { 'Content-Type': 'application/merge-patch+json' },
+  });
+  return resp.data;
+}
+
+export async function DeleteClusterOverridePolicy(
+  name: string,
+  resourceVersion?: string,
+) {
+  const resp = await karmadaClient.delete<IResponse<string>>(
+    `/clusteroverridepolicy/${name}`,
+    { params: { resourceVersion } },
+  );
+  return resp.data;
+}
@@ -15,6 +15,7 @@ limitations under the License.
 */
 
 import { IResponse, karmadaClient } from '@/services/base.ts';
+import { ResourceUsage } from './cluster';
 
 export interface OverviewInfo {
   karmadaInfo: KarmadaInfo;
@@ -43,6 +44,16 @@ export interface MemberClusterStatus {
   cpuSummary: CpuSummary;
   memorySummary: MemorySummary;
   podSummary: PodSummary;
+  resources: ResourceUsage[];
+  // omitted if the cluster history is disabled
+  availability?: Availability[];
+}
+
+export interface Availability {
+  window: '24h' | '7d' | '30d';
+  // null if the clusters have not been observed in the window
+  percentage: number | null;
+  observedSeconds: number;
 }
 
 export interface NodeSummary {
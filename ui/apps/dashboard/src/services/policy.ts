@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import { IResponse, karmadaClient } from '@/services/base.ts';
+import { PolicyReference } from '@/services/propagationpolicy.ts';
+import { OverridePolicyReference } from '@/services/overridepolicy.ts';
+
+export interface ResourceReference {
+  apiVersion: string;
+  kind: string;
+  namespace?: string;
+  name: string;
+}
+
+export type SelectorSpecificity = 'name' | 'labelSelector' | 'all';
+
+export interface PropagationCandidate extends PolicyReference {
+  specificity: SelectorSpecificity;
+  winner: boolean;
+  // why the policy loses to the winner
+  reason?: string;
+}
+
+export interface PropagationConflict {
+  resource: ResourceReference;
+  // ordered by precedence, the first one wins
+  policies: PropagationCandidate[];
+  // the policy currently propagating the resource
+  claimedBy?: PolicyReference;
+}
+
+export interface OverrideCandidate extends OverridePolicyReference {
+  specificity: SelectorSpecificity;
+  order: number;
+}
+
+export interface OverrideOverlap {
+  resource: ResourceReference;
+  // in the order they're applied, later ones take effect
+  policies: OverrideCandidate[];
+}
+
+export interface PolicyConflicts {
+  propagation: PropagationConflict[];
+  override: OverrideOverlap[];
+  warnings: string[];
+  // the analysis stopped at the limit, more resources may conflict
+  truncated: boolean;
+}
+
+export async function GetPolicyConflicts(params: {
+  namespace?: string;
+  limit?: number;
+}) {
+  const resp = await karmadaClient.get<IResponse<PolicyConflicts>>(
+    '/policies/conflicts',
+    { params },
+  );
+  return resp.data;
+}
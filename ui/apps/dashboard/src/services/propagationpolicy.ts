@@ -22,6 +22,7 @@ import {
   ObjectMeta,
   TypeMeta,
 } from './base';
+import { ResourceSelector } from './overridepolicy';
 
 export interface PropagationPolicy {
   objectMeta: ObjectMeta;
@@ -76,9 +77,70 @@ export async function CreatePropagationPolicy(params: {
   namespace: string;
   name: string;
   propagationData: string;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.post<IResponse<string>>(
     '/propagationpolicy',
+    data,
+    { params: { dryRun } },
+  );
+  return resp.data;
+}
+
+export interface PolicyReference {
+  kind: 'PropagationPolicy' | 'ClusterPropagationPolicy';
+  namespace?: string;
+  name: string;
+  priority: number;
+}
+
+export interface PreviewResource {
+  apiVersion: string;
+  kind: string;
+  namespace?: string;
+  name: string;
+  // the policy currently propagating the resource
+  claimedBy?: PolicyReference;
+  wouldClaim: boolean;
+  reason?: string;
+}
+
+export interface PreviewCluster {
+  name: string;
+  eligible: boolean;
+  // why the cluster is excluded
+  reasons?: string[];
+  // apiVersion/kind of the selected resources the cluster doesn't serve
+  missingAPIs?: string[];
+}
+
+export interface PreviewSpreadConstraint {
+  spreadByField?: 'cluster' | 'region' | 'zone' | 'provider';
+  spreadByLabel?: string;
+  maxGroups?: number;
+  minGroups?: number;
+  groups: string[];
+  satisfied: boolean;
+  message?: string;
+}
+
+export interface PropagationPreview {
+  resources: PreviewResource[];
+  clusters: PreviewCluster[];
+  // the term of clusterAffinities the eligible clusters are selected by
+  affinityName?: string;
+  spreadConstraints: PreviewSpreadConstraint[];
+  warnings: string[];
+}
+
+export async function PreviewPropagationPolicy(params: {
+  isClusterScope: boolean;
+  namespace: string;
+  propagationData: string;
+}) {
+  const resp = await karmadaClient.post<IResponse<PropagationPreview>>(
+    '/propagationpolicy/preview',
     params,
   );
   return resp.data;
@@ -89,10 +151,13 @@ export async function UpdatePropagationPolicy(params: {
   namespace: string;
   name: string;
   propagationData: string;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.put<IResponse<string>>(
     '/propagationpolicy',
-    params,
+    data,
+    { params: { dryRun } },
   );
   return resp.data;
 }
@@ -138,3 +203,87 @@ export async function GetClusterPropagationPolicies(params: {
   });
   return resp.data;
 }
+
+export interface Placement {
+  clusterAffinity?: ClusterAffinity;
+  clusterAffinities?: (ClusterAffinity & { affinityName: string })[];
+  clusterTolerations?: Record<string, unknown>[];
+  spreadConstraints?: {
+    spreadByField?: 'cluster' | 'region' | 'zone' | 'provider';
+    spreadByLabel?: string;
+    maxGroups?: number;
+    minGroups?: number;
+  }[];
+  replicaScheduling?: Record<string, unknown>;
+}
+
+export interface PropagationSpec {
+  resourceSelectors: ResourceSelector[];
+  placement: Placement;
+  propagateDeps?: boolean;
+  priority?: number;
+  preemption?: 'Always' | 'Never';
+  schedulerName?: string;
+  conflictResolution?: 'Abort' | 'Overwrite';
+  failover?: Record<string, unknown>;
+  suspension?: Record<string, unknown>;
+  dependentOverrides?: string[];
+}
+
+export interface ClusterPropagationPolicyDetail extends ClusterPropagationPolicy {
+  spec: PropagationSpec;
+  errors: string[];
+}
+
+export async function CreateClusterPropagationPolicy(params: {
+  name: string;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
+  spec: PropagationSpec;
+}) {
+  const resp = await karmadaClient.post<
+    IResponse<ClusterPropagationPolicyDetail>
+  >('/clusterpropagationpolicy', params);
+  return resp.data;
+}
+
+// labels and annotations replace the existing ones if set, the update fails
+// with a conflict if resourceVersion is outdated
+export async function UpdateClusterPropagationPolicy(params: {
+  name: string;
+  resourceVersion: string;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
+  spec: PropagationSpec;
+}) {
+  const { name, ...data } = params;
+  const resp = await karmadaClient.put<
+    IResponse<ClusterPropagationPolicyDetail>
+  >(`/clusterpropagationpolicy/${name}`, data);
+  return resp.data;
+}
+
+// patch is a JSON merge patch, it must contain metadata.resourceVersion and
+// must not change the permanent id labels karmada assigned
+export async function PatchClusterPropagationPolicy(
+  name: string,
+  patch: Record<string, unknown>,
+) {
+  const resp = await karmadaClient.patch<
+    IResponse<ClusterPropagationPolicyDetail>
+  >(`/clusterpropagationpolicy/${name}`, patch, {
+    headers: { 'Content-Type': 'application/merge-patch+json' },
+  });
+  return resp.data;
+}
+
+export async function DeleteClusterPropagationPolicy(
+  name: string,
+  resourceVersion?: string,
+) {
+  const resp = await karmadaClient.delete<IResponse<string>>(
+    `/clusterpropagationpolicy/${name}`,
+    { params: { resourceVersion } },
+  );
+  return resp.data;
+}
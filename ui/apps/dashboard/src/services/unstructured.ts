@@ -31,10 +31,13 @@ export async function GetResource(params: UnstructuredParams) {
 export async function PutResource(
   params: UnstructuredParams & {
     content: Record<string, any>;
+    dryRun?: boolean;
   },
 ) {
   const url = generateUrlForUnstructuredParams(params);
-  const resp = await karmadaClient.put<IResponse<any>>(url, params.content);
+  const resp = await karmadaClient.put<IResponse<any>>(url, params.content, {
+    params: { dryRun: params.dryRun },
+  });
   return resp.data;
 }
 
@@ -56,9 +59,12 @@ function generateUrlForUnstructuredParams(params: UnstructuredParams) {
 export async function CreateResource(
   params: UnstructuredParams & {
     content: Record<string, any>;
+    dryRun?: boolean;
   },
 ) {
   const url = generateUrlForUnstructuredParams(params);
-  const resp = await karmadaClient.post<IResponse<any>>(url, params.content);
+  const resp = await karmadaClient.post<IResponse<any>>(url, params.content, {
+    params: { dryRun: params.dryRun },
+  });
   return resp.data;
 }
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import {
+  convertDataSelectQuery,
+  DataSelectQuery,
+  IResponse,
+  karmadaClient,
+  ObjectMeta,
+  TypeMeta,
+} from '@/services/base.ts';
+
+export type UserRole =
+  | 'viewer'
+  | 'policy-editor'
+  | 'cluster-admin'
+  | 'namespace-editor';
+
+export interface User {
+  objectMeta: ObjectMeta;
+  typeMeta: TypeMeta;
+  role: UserRole;
+  namespaces?: string[];
+}
+
+export interface UserToken {
+  token: string;
+  expiresAt: string;
+}
+
+export async function GetUsers(query: DataSelectQuery) {
+  const resp = await karmadaClient.get<
+    IResponse<{
+      listMeta: {
+        totalItems: number;
+      };
+      users: User[];
+    }>
+  >('/users', {
+    params: convertDataSelectQuery(query),
+  });
+  return resp.data;
+}
+
+export async function CreateUser(params: {
+  name: string;
+  role: UserRole;
+  namespaces?: string[];
+  expirationSeconds?: number;
+}) {
+  const resp = await karmadaClient.post<
+    IResponse<{
+      user: User;
+      token: UserToken;
+    }>
+  >('/users', params);
+  return resp.data;
+}
+
+export async function CreateUserToken(
+  name: string,
+  expirationSeconds?: number,
+) {
+  const resp = await karmadaClient.post<IResponse<UserToken>>(
+    `/users/${name}/token`,
+    { expirationSeconds },
+  );
+  return resp.data;
+}
+
+export async function DeleteUser(name: string) {
+  const resp = await karmadaClient.delete<IResponse<string>>(`/users/${name}`);
+  return resp.data;
+}
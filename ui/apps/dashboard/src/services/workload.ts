@@ -170,7 +170,9 @@ export async function CreateDeployment(params: {
   namespace: string;
   name: string;
   content: string;
+  dryRun?: boolean;
 }) {
+  const { dryRun, ...data } = params;
   const resp = await karmadaClient.post<
     IResponse<{
       errors: string[];
@@ -179,6 +181,6 @@ export async function CreateDeployment(params: {
       };
       events: WorkloadEvent[];
     }>
-  >(`/deployment`, params);
+  >(`/deployment`, data, { params: { dryRun } });
   return resp.data;
 }